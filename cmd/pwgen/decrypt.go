@@ -0,0 +1,52 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"os"
+
+	"github.com/okamyuji/PasswordGenerator/internal/encrypt"
+)
+
+// 受信者の公開鍵で暗号化された出力を秘密鍵で復号
+//
+// 秘密鍵はageのidentityファイルまたはOpenSSH形式のEd25519秘密鍵を指定する。
+func runDecrypt(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("decrypt", stderr)
+	identityPath := fs.String("identity", "", "秘密鍵のファイル（ageのidentityファイルまたはSSH秘密鍵）")
+	in := fs.String("in", "", "暗号文のファイル（省略時は標準入力）")
+	out := fs.String("out", "", "出力先ファイル（省略時は標準出力）")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *identityPath == "" {
+		return errors.New("秘密鍵のファイル（-identity）を指定してください")
+	}
+
+	key, err := os.ReadFile(*identityPath)
+	if err != nil {
+		return err
+	}
+	identities, err := encrypt.ParseIdentities(key)
+	clear(key)
+	if err != nil {
+		return err
+	}
+
+	var ciphertext []byte
+	if *in == "" || *in == "-" {
+		ciphertext, err = io.ReadAll(io.LimitReader(stdin, encrypt.MaxCiphertextSize+1))
+	} else {
+		ciphertext, err = os.ReadFile(*in)
+	}
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := encrypt.Decrypt(ciphertext, identities)
+	if err != nil {
+		return err
+	}
+	defer clear(plaintext)
+	return writeOutput(*out, stdout, plaintext)
+}
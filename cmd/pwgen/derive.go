@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/okamyuji/PasswordGenerator/internal/derive"
+)
+
+// マスターパスワードや暗号文、シェアの読み込み元（テストで差し替える）
+var stdin io.Reader = os.Stdin
+
+// マスターパスワードからサイトごとのパスワードを導出
+//
+// マスターパスワードはシェルの履歴に残らないよう、環境変数PWGEN_MASTER_PASSWORDか
+// 標準入力の1行目から読み込む。
+func runDerive(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("derive", stderr)
+	cfg := passwordConfigFlags(fs)
+	site := fs.String("site", "", "サイト（例: example.com）")
+	login := fs.String("login", "", "ログイン名")
+	counter := fs.Uint("counter", 1, "カウンター（パスワードを変更するときに増やす）")
+	kdf := fs.String("kdf", string(derive.KDFArgon2id), "鍵導出関数 (argon2id, scrypt)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	k, err := derive.ParseKDF(*kdf)
+	if err != nil {
+		return err
+	}
+	if *counter > 1<<32-1 {
+		return fmt.Errorf("無効なカウンター: %d", *counter)
+	}
+
+	master := os.Getenv("PWGEN_MASTER_PASSWORD")
+	if master == "" {
+		line, err := bufio.NewReader(stdin).ReadString('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+		master = strings.TrimRight(line, "\r\n")
+	}
+
+	password, err := derive.Derive(master, derive.Options{
+		Site:    *site,
+		Login:   *login,
+		Counter: uint32(*counter),
+		KDF:     k,
+		Config:  *cfg,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(stdout, password)
+	return nil
+}
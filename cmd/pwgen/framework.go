@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/okamyuji/PasswordGenerator/internal/preset"
+	"github.com/okamyuji/PasswordGenerator/internal/token"
+)
+
+// フレームワークが期待する形式のシークレットキーを生成
+func runFramework(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("framework", stderr)
+	env := fs.Bool("env", false, ".envの行として出力")
+	fs.Usage = func() {
+		frameworks := preset.Frameworks()
+		names := make([]string, 0, len(frameworks))
+		for _, f := range frameworks {
+			names = append(names, string(f))
+		}
+		fmt.Fprintf(stderr, "用法: pwgen framework [-env] <%s>\n", strings.Join(names, "|"))
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("フレームワークを1つ指定してください")
+	}
+
+	f, err := preset.ParseFramework(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	secret, err := preset.New(token.New()).Generate(f)
+	if err != nil {
+		return err
+	}
+
+	if !*env {
+		fmt.Fprintln(stdout, secret.Value)
+		return nil
+	}
+	line, err := secret.EnvLine()
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(stdout, line)
+	return nil
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/okamyuji/PasswordGenerator/internal/config"
+	"github.com/okamyuji/PasswordGenerator/internal/encrypt"
+	"github.com/okamyuji/PasswordGenerator/internal/format"
+	"github.com/okamyuji/PasswordGenerator/internal/generator"
+)
+
+// パスワード設定のフラグを登録
+func passwordConfigFlags(fs *flag.FlagSet) *config.PasswordConfig {
+	cfg := &config.PasswordConfig{}
+	fs.IntVar(&cfg.Length, "length", 16, "パスワードの長さ")
+	fs.BoolVar(&cfg.UseUppercase, "uppercase", true, "大文字を含める")
+	fs.BoolVar(&cfg.UseLowercase, "lowercase", true, "小文字を含める")
+	fs.BoolVar(&cfg.UseNumbers, "numbers", true, "数字を含める")
+	fs.BoolVar(&cfg.UseSymbols, "symbols", true, "記号を含める")
+	fs.StringVar(&cfg.CustomSymbols, "custom-symbols", "", "使用する記号（省略時は既定の記号）")
+	fs.Func("symbol-profile", "記号を埋め込むコンテキスト (shell, url, json, yaml, xml, sql, jdbc)", func(s string) error {
+		p, err := config.ParseSymbolProfile(s)
+		cfg.SymbolProfile = p
+		return err
+	})
+	fs.BoolVar(&cfg.UseHiragana, "hiragana", false, "ひらがなを含める")
+	fs.BoolVar(&cfg.UseKatakana, "katakana", false, "カタカナを含める")
+	fs.BoolVar(&cfg.UseFullWidthDigits, "fullwidth-digits", false, "全角数字を含める")
+	fs.Func("blocks", "追加するUnicodeブロック（カンマ区切り: latin1, greek, cyrillic, hangul, cjk）", func(s string) error {
+		blocks, err := config.ParseUnicodeBlocks(s)
+		cfg.UnicodeBlocks = append(cfg.UnicodeBlocks, blocks...)
+		return err
+	})
+	fs.Func("keyboard", "入力するキーボード配列（カンマ区切り: us, jis, de, fr。複数指定で共通の位置にある文字のみ）", func(s string) error {
+		layouts, err := config.ParseKeyboardLayouts(s)
+		cfg.KeyboardLayouts = append(cfg.KeyboardLayouts, layouts...)
+		return err
+	})
+	fs.BoolVar(&cfg.MinimizeLayerChanges, "minimize-layers", false, "モバイルキーボードのレイヤー切り替えが少なくなるよう文字をまとめる")
+	fs.IntVar(&cfg.Rules.MaxRepeat, "max-repeat", 0, "同じ文字が連続できる数（0は制限なし）")
+	fs.IntVar(&cfg.Rules.MaxSequence, "max-sequence", 0, "abc・321のような連続した並びの長さの上限（0は制限なし）")
+	classes := "letter, uppercase, lowercase, digit, alphanumeric, symbol"
+	fs.Func("first", "先頭の文字の文字種 ("+classes+")", func(s string) error {
+		c, err := config.ParseCharClass(s)
+		cfg.Rules.FirstClass = c
+		return err
+	})
+	fs.Func("last", "末尾の文字の文字種 ("+classes+")", func(s string) error {
+		c, err := config.ParseCharClass(s)
+		cfg.Rules.LastClass = c
+		return err
+	})
+	fs.Func("forbid", "含めない文字列（大文字小文字を区別しない、複数指定可）", func(s string) error {
+		cfg.Rules.Forbidden = append(cfg.Rules.Forbidden, s)
+		return nil
+	})
+	return cfg
+}
+
+// パスワードを生成して指定されたフォーマットで出力
+func runGenerate(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("generate", stderr)
+	cfg := passwordConfigFlags(fs)
+	formatName := fs.String("format", string(format.FormatText),
+		"出力フォーマット (text, htpasswd, ldif, kubernetes, env, docker-secrets, sealed)")
+	count := fs.Int("count", 1, "生成するシークレット数")
+	keyTemplate := fs.String("key-template", "", "キー名テンプレート（{index} / {username}）")
+	users := fs.String("users", "", "カンマ区切りのユーザー名（htpasswd・LDIFでは必須）")
+	htpasswdAlg := fs.String("htpasswd-alg", string(format.HtpasswdBcrypt), "htpasswdのアルゴリズム (bcrypt, apr1, sha)")
+	ldapScheme := fs.String("ldap-scheme", string(format.LDAPSSHA), "userPasswordのスキーム (SSHA, CRYPT, ARGON2)")
+	baseDN := fs.String("base-dn", "", "LDIFのベースDN")
+	rdnAttr := fs.String("rdn-attr", "uid", "LDIFのRDN属性")
+	secretName := fs.String("secret-name", "", "Kubernetes Secretの名前")
+	namespace := fs.String("namespace", "", "Kubernetes SecretのNamespace")
+	sealKey := fs.String("seal-key", os.Getenv("SEAL_KEY"), "封印ドキュメントのbase64エンコードされた32バイト鍵")
+	out := fs.String("out", "", "出力先ファイル（省略時は標準出力）")
+	var recipientKeys []string
+	fs.Func("recipient", "暗号化先のage公開鍵またはssh-ed25519公開鍵（複数指定可）", func(s string) error {
+		recipientKeys = append(recipientKeys, s)
+		return nil
+	})
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	recipients, err := encrypt.ParseRecipients(recipientKeys...)
+	if err != nil {
+		return err
+	}
+
+	f, err := format.ParseFormat(*formatName)
+	if err != nil {
+		return err
+	}
+	opts := format.Options{
+		Count:             *count,
+		KeyTemplate:       *keyTemplate,
+		HtpasswdAlgorithm: format.HtpasswdAlgorithm(*htpasswdAlg),
+		LDIF: format.LDIFOptions{
+			BaseDN:       *baseDN,
+			RDNAttribute: *rdnAttr,
+			Scheme:       format.LDAPScheme(strings.ToUpper(*ldapScheme)),
+		},
+		SecretName: *secretName,
+		Namespace:  *namespace,
+	}
+	for _, u := range strings.Split(*users, ",") {
+		if u = strings.TrimSpace(u); u != "" {
+			opts.Usernames = append(opts.Usernames, u)
+		}
+	}
+	if f == format.FormatSealed {
+		key, err := base64.StdEncoding.DecodeString(*sealKey)
+		if err != nil {
+			return fmt.Errorf("無効な封印鍵: %w", err)
+		}
+		opts.SealKey = key
+	}
+
+	result, err := format.Encode(generator.New(), *cfg, f, opts)
+	if err != nil {
+		return err
+	}
+	if result.Warning != "" {
+		fmt.Fprintln(stderr, "警告:", result.Warning)
+	}
+
+	defer result.Destroy()
+
+	body := result.Body
+	if f == format.FormatText {
+		body = append(body, '\n')
+		defer clear(body)
+	}
+
+	// ハッシュのみの形式では平文を標準エラーに出力して、ファイルと分けて受け取れるようにする
+	var creds []byte
+	for _, cred := range result.Credentials {
+		creds = fmt.Appendf(creds, "%s:%s\n", cred.Username, cred.Password)
+	}
+	defer clear(creds)
+
+	// 受信者が指定された場合は、ファイルと認証情報をそれぞれ暗号化して平文を出力しない
+	if len(recipients) > 0 {
+		if body, err = encrypt.Armor(body, recipients); err != nil {
+			return err
+		}
+		if len(creds) > 0 {
+			if creds, err = encrypt.Armor(creds, recipients); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := writeOutput(*out, stdout, body); err != nil {
+		return err
+	}
+	_, err = stderr.Write(creds)
+	return err
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/okamyuji/PasswordGenerator/internal/identifier"
+)
+
+// UUIDv4/v7、ULID、NanoIDを生成（秘密としての注意は標準エラーに出力）
+func runIdentifier(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("id", stderr)
+	kind := fs.String("kind", string(identifier.KindUUIDv4), "種類 (uuidv4, uuidv7, ulid, nanoid)")
+	opts := identifier.Options{}
+	fs.IntVar(&opts.Count, "count", identifier.DefaultCount, "生成する識別子数")
+	fs.StringVar(&opts.Alphabet, "alphabet", "", "NanoIDのアルファベット")
+	fs.IntVar(&opts.Size, "size", 0, "NanoIDの長さ（省略時は21）")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var err error
+	if opts.Kind, err = identifier.ParseKind(*kind); err != nil {
+		return err
+	}
+	result, err := identifier.New().Generate(opts)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range result.IDs {
+		fmt.Fprintln(stdout, id)
+	}
+	if result.Warning != "" {
+		fmt.Fprintln(stderr, "注意:", result.Warning)
+	}
+	return nil
+}
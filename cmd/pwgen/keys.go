@@ -0,0 +1,128 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/okamyuji/PasswordGenerator/internal/generator"
+	"github.com/okamyuji/PasswordGenerator/internal/keys"
+)
+
+// OpenSSH形式のSSH鍵ペアを生成
+//
+// -outを指定すると秘密鍵をそのパスに、公開鍵を.pubを付けたパスに書き込む。
+// パスフレーズは標準エラーに出力する。
+func runSSH(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("ssh", stderr)
+	keyType := fs.String("type", string(keys.SSHKeyEd25519), "鍵の種類 (ed25519, rsa)")
+	bits := fs.Int("bits", 0, "RSA鍵のビット数（省略時は3072）")
+	comment := fs.String("comment", "", "鍵のコメント（例: user@host）")
+	passphrase := fs.Bool("passphrase", false, "パスフレーズを生成して秘密鍵を暗号化")
+	out := fs.String("out", "", "秘密鍵の出力先（公開鍵は.pubを付けたパス）")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	t, err := keys.ParseSSHKeyType(*keyType)
+	if err != nil {
+		return err
+	}
+	pair, err := keys.New(generator.New()).GenerateSSH(keys.SSHOptions{
+		Type:       t,
+		Bits:       *bits,
+		Comment:    *comment,
+		Passphrase: *passphrase,
+	})
+	if err != nil {
+		return err
+	}
+
+	if pair.Passphrase != "" {
+		fmt.Fprintf(stderr, "passphrase: %s\n", pair.Passphrase)
+	}
+	if *out == "" {
+		fmt.Fprint(stdout, pair.PrivateKey)
+		fmt.Fprintln(stdout, pair.PublicKey)
+		return nil
+	}
+	if err := os.WriteFile(*out, []byte(pair.PrivateKey), 0o600); err != nil {
+		return err
+	}
+	if err := os.WriteFile(*out+".pub", []byte(pair.PublicKey+"\n"), 0o644); err != nil {
+		return err
+	}
+	fmt.Fprintln(stdout, pair.Fingerprint)
+	return nil
+}
+
+// WireGuardの鍵ペアと、-pskで事前共有鍵を生成
+func runWireGuard(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("wireguard", stderr)
+	psk := fs.Bool("psk", false, "事前共有鍵も生成")
+	pubkey := fs.String("pubkey", "", "秘密鍵から公開鍵を算出（wg pubkeyと同等）")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *pubkey != "" {
+		public, err := keys.WireGuardPublicKey(*pubkey)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(stdout, public)
+		return nil
+	}
+
+	wgKeys, err := keys.New(generator.New()).GenerateWireGuard(*psk)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "private: %s\npublic: %s\n", wgKeys.PrivateKey, wgKeys.PublicKey)
+	if wgKeys.PresharedKey != "" {
+		fmt.Fprintf(stdout, "preshared: %s\n", wgKeys.PresharedKey)
+	}
+	return nil
+}
+
+// JWT署名用のJWKを生成し、-jwksで公開鍵のJWKSを書き出す
+func runJWK(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("jwk", stderr)
+	alg := fs.String("alg", string(keys.JWKES256), "アルゴリズム (HS256/384/512, ES256/384/512, RS256/384/512, PS256/384/512, EdDSA)")
+	bits := fs.Int("bits", 0, "RSA鍵のビット数（省略時は3072）")
+	out := fs.String("out", "", "JWKの出力先ファイル（省略時は標準出力）")
+	jwksPath := fs.String("jwks", "", "公開鍵のJWKSの出力先ファイル")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	algorithm, err := keys.ParseJWKAlgorithm(*alg)
+	if err != nil {
+		return err
+	}
+	result, err := keys.New(generator.New()).GenerateJWK(keys.JWKOptions{Algorithm: algorithm, Bits: *bits})
+	if err != nil {
+		return err
+	}
+
+	body, err := json.MarshalIndent(result.Key, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeOutput(*out, stdout, append(body, '\n')); err != nil {
+		return err
+	}
+
+	if *jwksPath == "" {
+		return nil
+	}
+	if result.JWKS == nil {
+		return fmt.Errorf("対称鍵（%s）には公開鍵がないためJWKSを出力できません", algorithm)
+	}
+	jwks, err := json.MarshalIndent(result.JWKS, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(*jwksPath, append(jwks, '\n'), 0o644)
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// サブコマンドの実装
+type command func(args []string, stdout, stderr io.Writer) error
+
+// サブコマンド名と実装の対応
+var commands = map[string]command{
+	"combine":   runCombine,
+	"decrypt":   runDecrypt,
+	"derive":    runDerive,
+	"framework": runFramework,
+	"generate":  runGenerate,
+	"id":        runIdentifier,
+	"jwk":       runJWK,
+	"mnemonic":  runMnemonic,
+	"recovery":  runRecovery,
+	"sheet":     runSheet,
+	"split":     runSplit,
+	"ssh":       runSSH,
+	"token":     runToken,
+	"totp":      runOTP,
+	"wifi":      runWiFi,
+	"wireguard": runWireGuard,
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		if err != flag.ErrHelp {
+			fmt.Fprintln(os.Stderr, "エラー:", err)
+		}
+		os.Exit(1)
+	}
+}
+
+// 引数からサブコマンドを選んで実行（省略時は generate）
+func run(args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return runGenerate(args, stdout, stderr)
+	}
+	if args[0] == "help" {
+		printUsage(stdout)
+		return nil
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		printUsage(stderr)
+		return fmt.Errorf("不明なサブコマンド: %s", args[0])
+	}
+	return cmd(args[1:], stdout, stderr)
+}
+
+func printUsage(w io.Writer) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(w, "[用法]")
+	fmt.Fprintln(w, "pwgen <サブコマンド> [オプション]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "[サブコマンド]")
+	for _, name := range names {
+		fmt.Fprintln(w, "  "+name)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "各サブコマンドのオプションは pwgen <サブコマンド> -h で表示します")
+}
+
+// フラグセットを作成（エラーはrunの呼び出し元で表示する）
+func newFlagSet(name string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet("pwgen "+name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	return fs
+}
+
+// 結果を-outで指定されたファイルまたは標準出力に書き込む
+func writeOutput(path string, stdout io.Writer, body []byte) error {
+	if path == "" || path == "-" {
+		_, err := stdout.Write(body)
+		return err
+	}
+	return os.WriteFile(path, body, 0o600)
+}
@@ -0,0 +1,626 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"filippo.io/age"
+
+	"github.com/okamyuji/PasswordGenerator/internal/config"
+	"github.com/okamyuji/PasswordGenerator/internal/keys"
+	"github.com/okamyuji/PasswordGenerator/internal/otp"
+	"github.com/okamyuji/PasswordGenerator/internal/recovery"
+	"github.com/okamyuji/PasswordGenerator/internal/sheet"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestRun_Generate(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if err := run([]string{"-length", "20", "-count", "3", "-symbols=false"}, &stdout, &stderr); err != nil {
+		t.Fatalf("run() エラー = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(stdout.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("行数 = %d, want 3", len(lines))
+	}
+	for _, line := range lines {
+		if len(line) != 20 {
+			t.Errorf("パスワードの長さ = %d, want 20", len(line))
+		}
+		if strings.ContainsAny(line, "!@#$%^&*()_+-=[]{}|;:,.<>?") {
+			t.Errorf("記号が含まれています: %s", line)
+		}
+	}
+}
+
+func TestRun_SymbolProfile(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if err := run([]string{"-length", "64", "-symbol-profile", "url"}, &stdout, &stderr); err != nil {
+		t.Fatalf("run() エラー = %v", err)
+	}
+	if strings.ContainsAny(strings.TrimSpace(stdout.String()), ":@/?#[]%") {
+		t.Errorf("URLで使用できない記号が含まれています: %s", stdout.String())
+	}
+
+	if err := run([]string{"-symbol-profile", "csv"}, &stdout, &stderr); err == nil {
+		t.Error("run() 未対応のプロファイルでエラーが返されませんでした")
+	}
+}
+
+func TestRun_Formats(t *testing.T) {
+	sealKey := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32))
+
+	tests := []struct {
+		name         string
+		args         []string
+		wantContains string
+		wantStderr   bool
+	}{
+		{
+			name:         ".env",
+			args:         []string{"generate", "-format", "env", "-count", "2", "-key-template", "DB_{index}"},
+			wantContains: "DB_2='",
+		},
+		{
+			name:         "Kubernetes",
+			args:         []string{"generate", "-format", "kubernetes", "-secret-name", "app"},
+			wantContains: "  name: app\n",
+		},
+		{
+			name:         "封印",
+			args:         []string{"generate", "-format", "sealed", "-seal-key", sealKey},
+			wantContains: `"algorithm": "A256GCM"`,
+		},
+		{
+			name:         "LDIF",
+			args:         []string{"generate", "-format", "ldif", "-users", "alice", "-base-dn", "dc=example"},
+			wantContains: "dn: uid=alice,dc=example",
+			wantStderr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if err := run(tt.args, &stdout, &stderr); err != nil {
+				t.Fatalf("run() エラー = %v", err)
+			}
+			if !strings.Contains(stdout.String(), tt.wantContains) {
+				t.Errorf("出力に %q が含まれていません:\n%s", tt.wantContains, stdout.String())
+			}
+			if tt.wantStderr && !strings.HasPrefix(stderr.String(), "alice:") {
+				t.Errorf("標準エラーに平文の認証情報が出力されていません: %q", stderr.String())
+			}
+		})
+	}
+}
+
+func TestRun_OutFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secrets.tar")
+	var stdout, stderr bytes.Buffer
+	if err := run([]string{"generate", "-format", "docker-secrets", "-out", path}, &stdout, &stderr); err != nil {
+		t.Fatalf("run() エラー = %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("出力ファイルがありません: %v", err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("出力ファイルのパーミッション = %o, want 600", info.Mode().Perm())
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("標準出力に出力されています: %q", stdout.String())
+	}
+}
+
+func TestRun_Token(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	args := []string{"token", "-bytes", "24", "-prefix", "ghp_", "-checksum", "crc32"}
+	if err := run(args, &stdout, &stderr); err != nil {
+		t.Fatalf("run() エラー = %v", err)
+	}
+	tok := strings.TrimSpace(stdout.String())
+	if !strings.HasPrefix(tok, "ghp_") {
+		t.Fatalf("トークンにプレフィックスがありません: %s", tok)
+	}
+
+	stdout.Reset()
+	if err := run(append(args, "-verify", tok), &stdout, &stderr); err != nil {
+		t.Errorf("run() 検証エラー = %v", err)
+	}
+	if strings.TrimSpace(stdout.String()) != "OK" {
+		t.Errorf("検証結果 = %q, want OK", stdout.String())
+	}
+
+	last := "x"
+	if strings.HasSuffix(tok, last) {
+		last = "y"
+	}
+	if err := run(append(args, "-verify", tok[:len(tok)-1]+last), &stdout, &stderr); err == nil {
+		t.Error("run() 改ざんされたトークンでエラーが返されませんでした")
+	}
+}
+
+func TestRun_TOTP(t *testing.T) {
+	dir := t.TempDir()
+	qrPath := filepath.Join(dir, "qr.svg")
+	var stdout, stderr bytes.Buffer
+	if err := run([]string{"totp", "-issuer", "Acme", "-account", "alice", "-qr", qrPath}, &stdout, &stderr); err != nil {
+		t.Fatalf("run() エラー = %v", err)
+	}
+
+	var secret string
+	for _, line := range strings.Split(stdout.String(), "\n") {
+		if v, ok := strings.CutPrefix(line, "secret: "); ok {
+			secret = v
+		}
+	}
+	if secret == "" || !strings.Contains(stdout.String(), "uri: otpauth://totp/Acme:alice?") {
+		t.Fatalf("出力が不正です:\n%s", stdout.String())
+	}
+	svg, err := os.ReadFile(qrPath)
+	if err != nil || !bytes.HasPrefix(svg, []byte("<svg")) {
+		t.Errorf("QRコードのSVGが出力されていません: %v", err)
+	}
+
+	seed, err := otp.DecodeSecret(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, err := otp.TOTP(seed, time.Now(), otp.DefaultPeriod, otp.DefaultDigits, otp.AlgorithmSHA1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout.Reset()
+	if err := run([]string{"totp", "-secret", secret, "-verify", code}, &stdout, &stderr); err != nil {
+		t.Errorf("run() 検証エラー = %v", err)
+	}
+}
+
+func TestRun_Recovery(t *testing.T) {
+	hashPath := filepath.Join(t.TempDir(), "hashes.txt")
+	var stdout, stderr bytes.Buffer
+	args := []string{"recovery", "-count", "3", "-hash", "bcrypt", "-hashes", hashPath}
+	if err := run(args, &stdout, &stderr); err != nil {
+		t.Fatalf("run() エラー = %v", err)
+	}
+
+	codes := strings.Fields(stdout.String())
+	body, err := os.ReadFile(hashPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hashes := strings.Fields(string(body))
+	if len(codes) != 3 || len(hashes) != 3 {
+		t.Fatalf("コード数 = %d, ハッシュ数 = %d, want 3", len(codes), len(hashes))
+	}
+	for i := range codes {
+		if ok, err := recovery.Verify(codes[i], hashes[i]); err != nil || !ok {
+			t.Errorf("Verify(%q) = %v, %v, want true", codes[i], ok, err)
+		}
+	}
+
+	stdout.Reset()
+	if err := run([]string{"recovery", "-sheet", "-title", "Acme"}, &stdout, &stderr); err != nil {
+		t.Fatalf("run() エラー = %v", err)
+	}
+	if !strings.HasPrefix(stdout.String(), "Acme\n") || !strings.Contains(stdout.String(), "10. ") {
+		t.Errorf("シートの出力が不正です:\n%s", stdout.String())
+	}
+}
+
+func TestRun_SSH(t *testing.T) {
+	keyPath := filepath.Join(t.TempDir(), "id_ed25519")
+	var stdout, stderr bytes.Buffer
+	args := []string{"ssh", "-comment", "alice@example.com", "-passphrase", "-out", keyPath}
+	if err := run(args, &stdout, &stderr); err != nil {
+		t.Fatalf("run() エラー = %v", err)
+	}
+
+	passphrase, ok := strings.CutPrefix(strings.TrimSpace(stderr.String()), "passphrase: ")
+	if !ok {
+		t.Fatalf("パスフレーズが出力されていません: %q", stderr.String())
+	}
+	private, err := os.ReadFile(keyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(keyPath)
+	if err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("秘密鍵のパーミッション = %v, %v", info.Mode().Perm(), err)
+	}
+	signer, err := ssh.ParsePrivateKeyWithPassphrase(private, []byte(passphrase))
+	if err != nil {
+		t.Fatalf("秘密鍵の読み込みに失敗: %v", err)
+	}
+
+	public, err := os.ReadFile(keyPath + ".pub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, comment, _, _, err := ssh.ParseAuthorizedKey(public)
+	if err != nil || comment != "alice@example.com" {
+		t.Fatalf("公開鍵 = %q, %v", public, err)
+	}
+	if ssh.FingerprintSHA256(pub) != ssh.FingerprintSHA256(signer.PublicKey()) ||
+		strings.TrimSpace(stdout.String()) != ssh.FingerprintSHA256(pub) {
+		t.Errorf("フィンガープリントが一致しません: %q", stdout.String())
+	}
+}
+
+func TestRun_WireGuard(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if err := run([]string{"wireguard", "-psk"}, &stdout, &stderr); err != nil {
+		t.Fatalf("run() エラー = %v", err)
+	}
+	values := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(stdout.String()), "\n") {
+		if k, v, ok := strings.Cut(line, ": "); ok {
+			values[k] = v
+		}
+	}
+	if values["private"] == "" || values["public"] == "" || values["preshared"] == "" {
+		t.Fatalf("出力が不正です:\n%s", stdout.String())
+	}
+	if public, err := keys.WireGuardPublicKey(values["private"]); err != nil || public != values["public"] {
+		t.Errorf("公開鍵 = %q, want %q", values["public"], public)
+	}
+
+	stdout.Reset()
+	if err := run([]string{"wireguard", "-pubkey", values["private"]}, &stdout, &stderr); err != nil {
+		t.Fatalf("run() エラー = %v", err)
+	}
+	if strings.TrimSpace(stdout.String()) != values["public"] {
+		t.Errorf("-pubkey = %q, want %q", stdout.String(), values["public"])
+	}
+}
+
+func TestRun_JWK(t *testing.T) {
+	jwksPath := filepath.Join(t.TempDir(), "jwks.json")
+	var stdout, stderr bytes.Buffer
+	if err := run([]string{"jwk", "-alg", "EdDSA", "-jwks", jwksPath}, &stdout, &stderr); err != nil {
+		t.Fatalf("run() エラー = %v", err)
+	}
+
+	var key keys.JWK
+	if err := json.Unmarshal(stdout.Bytes(), &key); err != nil {
+		t.Fatalf("JWKの解析に失敗: %v", err)
+	}
+	body, err := os.ReadFile(jwksPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var jwks keys.JWKSet
+	if err := json.Unmarshal(body, &jwks); err != nil {
+		t.Fatalf("JWKSの解析に失敗: %v", err)
+	}
+	if key.D == "" || len(jwks.Keys) != 1 || jwks.Keys[0].D != "" || jwks.Keys[0].Kid != key.Kid {
+		t.Errorf("JWK = %+v, JWKS = %+v", key, jwks)
+	}
+
+	if err := run([]string{"jwk", "-alg", "HS256", "-jwks", jwksPath}, &stdout, &stderr); err == nil {
+		t.Error("run() 対称鍵のJWKSでエラーが返されませんでした")
+	}
+}
+
+func TestRun_Framework(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if err := run([]string{"framework", "rails"}, &stdout, &stderr); err != nil {
+		t.Fatalf("run() エラー = %v", err)
+	}
+	if got := strings.TrimSpace(stdout.String()); len(got) != 128 {
+		t.Errorf("secret_key_base = %q, want 128文字", got)
+	}
+
+	stdout.Reset()
+	if err := run([]string{"framework", "-env", "laravel"}, &stdout, &stderr); err != nil {
+		t.Fatalf("run() エラー = %v", err)
+	}
+	if !strings.HasPrefix(stdout.String(), "APP_KEY='base64:") {
+		t.Errorf("出力 = %q", stdout.String())
+	}
+
+	for _, args := range [][]string{{"framework"}, {"framework", "spring"}} {
+		if err := run(args, &stdout, &stderr); err == nil {
+			t.Errorf("run(%v) エラーが返されませんでした", args)
+		}
+	}
+}
+
+func TestRun_Identifier(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if err := run([]string{"id", "-kind", "ulid", "-count", "3"}, &stdout, &stderr); err != nil {
+		t.Fatalf("run() エラー = %v", err)
+	}
+	ids := strings.Fields(stdout.String())
+	if len(ids) != 3 || len(ids[0]) != 26 {
+		t.Errorf("ULID = %v", ids)
+	}
+	if !strings.HasPrefix(stderr.String(), "注意: ") {
+		t.Errorf("警告が出力されていません: %q", stderr.String())
+	}
+
+	stdout.Reset()
+	stderr.Reset()
+	if err := run([]string{"id", "-kind", "nanoid", "-alphabet", "0123456789abcdef", "-size", "32"}, &stdout, &stderr); err != nil {
+		t.Fatalf("run() エラー = %v", err)
+	}
+	if got := strings.TrimSpace(stdout.String()); len(got) != 32 || stderr.Len() != 0 {
+		t.Errorf("NanoID = %q, 標準エラー = %q", got, stderr.String())
+	}
+}
+
+func TestRun_Mnemonic(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if err := run([]string{"mnemonic", "-words", "12"}, &stdout, &stderr); err != nil {
+		t.Fatalf("run() エラー = %v", err)
+	}
+	values := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(stdout.String()), "\n") {
+		if k, v, ok := strings.Cut(line, ": "); ok {
+			values[k] = v
+		}
+	}
+	if len(values["entropy"]) != 32 || len(strings.Fields(values["mnemonic"])) != 12 {
+		t.Fatalf("出力が不正です:\n%s", stdout.String())
+	}
+
+	stdout.Reset()
+	if err := run([]string{"mnemonic", "-decode", values["mnemonic"]}, &stdout, &stderr); err != nil {
+		t.Fatalf("run() エラー = %v", err)
+	}
+	if got := strings.TrimSpace(stdout.String()); got != values["entropy"] {
+		t.Errorf("-decode = %q, want %q", got, values["entropy"])
+	}
+
+	stdout.Reset()
+	if err := run([]string{"mnemonic", "-encode", values["entropy"]}, &stdout, &stderr); err != nil {
+		t.Fatalf("run() エラー = %v", err)
+	}
+	if got := strings.TrimSpace(stdout.String()); got != values["mnemonic"] {
+		t.Errorf("-encode = %q, want %q", got, values["mnemonic"])
+	}
+
+	if err := run([]string{"mnemonic", "-encode", "zz"}, &stdout, &stderr); err == nil {
+		t.Error("run() 無効な16進数でエラーが返されませんでした")
+	}
+}
+
+func TestRun_Derive(t *testing.T) {
+	t.Setenv("PWGEN_MASTER_PASSWORD", "")
+	stdin = strings.NewReader("correct horse battery staple\n")
+	t.Cleanup(func() { stdin = os.Stdin })
+
+	var stdout, stderr bytes.Buffer
+	if err := run([]string{"derive", "-site", "example.com", "-login", "alice"}, &stdout, &stderr); err != nil {
+		t.Fatalf("run() エラー = %v", err)
+	}
+	if got := strings.TrimSpace(stdout.String()); got != "51pNFf=?p@l#q9fT" {
+		t.Errorf("導出されたパスワード = %q", got)
+	}
+
+	t.Setenv("PWGEN_MASTER_PASSWORD", "correct horse battery staple")
+	stdout.Reset()
+	if err := run([]string{"derive", "-site", "bank.example", "-length", "6", "-uppercase=false", "-lowercase=false", "-symbols=false"}, &stdout, &stderr); err != nil {
+		t.Fatalf("run() エラー = %v", err)
+	}
+	if got := strings.TrimSpace(stdout.String()); got != "478566" {
+		t.Errorf("導出されたPIN = %q", got)
+	}
+}
+
+func TestRun_Decrypt(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	identityPath := filepath.Join(t.TempDir(), "key.txt")
+	if err := os.WriteFile(identityPath, []byte(identity.String()+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"generate", "-format", "htpasswd", "-users", "alice", "-htpasswd-alg", "sha", "-recipient", identity.Recipient().String()}
+	if err := run(args, &stdout, &stderr); err != nil {
+		t.Fatalf("run() エラー = %v", err)
+	}
+	for name, out := range map[string]string{"標準出力": stdout.String(), "標準エラー": stderr.String()} {
+		if !strings.HasPrefix(out, "-----BEGIN AGE ENCRYPTED FILE-----") || strings.Contains(out, "alice") {
+			t.Fatalf("%sが暗号化されていません: %q", name, out)
+		}
+	}
+
+	encryptedCreds := stderr.String()
+	stdin = strings.NewReader(encryptedCreds)
+	t.Cleanup(func() { stdin = os.Stdin })
+	stdout.Reset()
+	if err := run([]string{"decrypt", "-identity", identityPath}, &stdout, &stderr); err != nil {
+		t.Fatalf("run() エラー = %v", err)
+	}
+	if !strings.HasPrefix(stdout.String(), "alice:") {
+		t.Errorf("復号された認証情報 = %q", stdout.String())
+	}
+
+	if err := run([]string{"decrypt"}, &stdout, &stderr); err == nil {
+		t.Error("run() -identity 未指定でエラーが返されませんでした")
+	}
+	if err := run([]string{"generate", "-recipient", "age1invalid"}, &stdout, &stderr); err == nil {
+		t.Error("run() 無効な受信者でエラーが返されませんでした")
+	}
+}
+
+func TestRun_SplitCombine(t *testing.T) {
+	for _, encoding := range []string{"mnemonic", "base32"} {
+		t.Run(encoding, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			args := []string{"split", "-shares", "4", "-threshold", "3", "-encoding", encoding, "-length", "20"}
+			if err := run(args, &stdout, &stderr); err != nil {
+				t.Fatalf("run() エラー = %v", err)
+			}
+			lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+			if len(lines) != 5 || !strings.HasPrefix(lines[0], "secret: ") || !strings.HasPrefix(lines[4], "share 4/4 (threshold 3): ") {
+				t.Fatalf("split の出力 = %q", stdout.String())
+			}
+			secret := strings.TrimPrefix(lines[0], "secret: ")
+
+			// 見出し付きの行をそのまま貼り付けても復元できる
+			stdin = strings.NewReader(strings.Join([]string{lines[4], "", lines[1], lines[3]}, "\n"))
+			t.Cleanup(func() { stdin = os.Stdin })
+			stdout.Reset()
+			if err := run([]string{"combine"}, &stdout, &stderr); err != nil {
+				t.Fatalf("run() エラー = %v", err)
+			}
+			if got := strings.TrimSpace(stdout.String()); got != secret {
+				t.Errorf("復元された秘密 = %q, want %q", got, secret)
+			}
+
+			stdin = strings.NewReader(lines[1] + "\n" + lines[2])
+			if err := run([]string{"combine"}, &stdout, &stderr); err == nil {
+				t.Error("run() しきい値未満でエラーが返されませんでした")
+			}
+		})
+	}
+}
+
+func TestRun_Sheet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sheet.pdf")
+	var stdout, stderr bytes.Buffer
+	if err := run([]string{"sheet", "-users", "alice, bob", "-length", "12", "-out", path}, &stdout, &stderr); err != nil {
+		t.Fatalf("run() エラー = %v", err)
+	}
+	pdf, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("出力ファイルがありません: %v", err)
+	}
+	if !bytes.HasPrefix(pdf, []byte("%PDF-")) || !bytes.Contains(pdf, []byte("/Count 2")) {
+		t.Errorf("2ページのPDFではありません: %.40q", pdf)
+	}
+
+	if err := run([]string{"sheet"}, &stdout, &stderr); err == nil {
+		t.Error("run() -users 未指定でエラーが返されませんでした")
+	}
+	if err := run([]string{"sheet", "-users", "alice", "-length", "100"}, &stdout, &stderr); err == nil {
+		t.Error("run() 長すぎるパスワードでエラーが返されませんでした")
+	}
+	users := strings.TrimSuffix(strings.Repeat("u,", sheet.MaxUsers+1), ",")
+	if err := run([]string{"sheet", "-users", users, "-length", "12"}, &stdout, &stderr); err == nil || !strings.Contains(err.Error(), "ユーザー数") {
+		t.Errorf("run() ユーザー数の上限超過のエラー = %v", err)
+	}
+}
+
+func TestRun_WiFi(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if err := run([]string{"wifi", "-ssid", "Guest;1", "-length", "20", "-hidden", "-format", "text"}, &stdout, &stderr); err != nil {
+		t.Fatalf("run() エラー = %v", err)
+	}
+	payload := strings.TrimSpace(stdout.String())
+	if !strings.HasPrefix(payload, `WIFI:T:WPA;S:Guest\;1;P:`) || !strings.HasSuffix(payload, ";H:true;;") {
+		t.Errorf("Wi-Fiの文字列が不正です: %q", payload)
+	}
+
+	stdout.Reset()
+	stderr.Reset()
+	if err := run([]string{"wifi", "-ssid", "Guest", "-format", "svg"}, &stdout, &stderr); err != nil {
+		t.Fatalf("run() エラー = %v", err)
+	}
+	if !strings.HasPrefix(stdout.String(), "<svg") || !strings.HasPrefix(stderr.String(), "passphrase: ") {
+		t.Errorf("SVGとパスフレーズが出力されていません: %.40q / %q", stdout.String(), stderr.String())
+	}
+
+	if err := run([]string{"wifi"}, &stdout, &stderr); err == nil {
+		t.Error("run() -ssid 未指定でエラーが返されませんでした")
+	}
+	if err := run([]string{"wifi", "-ssid", "Guest", "-length", "64"}, &stdout, &stderr); err == nil {
+		t.Error("run() WPA2には長すぎるパスフレーズでエラーが返されませんでした")
+	}
+}
+
+func TestRun_Kana(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	args := []string{"-length", "10", "-uppercase=false", "-lowercase=false", "-numbers=false", "-symbols=false", "-hiragana", "-blocks", "greek"}
+	if err := run(args, &stdout, &stderr); err != nil {
+		t.Fatalf("run() エラー = %v", err)
+	}
+	password := strings.TrimSpace(stdout.String())
+	if n := utf8.RuneCountInString(password); n != 10 {
+		t.Errorf("文字数 = %d, want 10: %q", n, password)
+	}
+	if !strings.ContainsAny(password, config.Hiragana) {
+		t.Errorf("ひらがなが含まれていません: %q", password)
+	}
+	if stderr.Len() != 0 {
+		t.Errorf("警告は不要です: %q", stderr.String())
+	}
+
+	stdout.Reset()
+	if err := run(append(args, "-symbol-profile", "url"), &stdout, &stderr); err != nil {
+		t.Fatalf("run() エラー = %v", err)
+	}
+	if !strings.HasPrefix(stderr.String(), "警告: ") {
+		t.Errorf("ASCIIのみの用途で警告が出力されていません: %q", stderr.String())
+	}
+
+	if err := run([]string{"-blocks", "klingon"}, &stdout, &stderr); err == nil {
+		t.Error("run() 未対応のUnicodeブロックでエラーが返されませんでした")
+	}
+}
+
+func TestRun_Keyboard(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	args := []string{"-length", "40", "-keyboard", "us,jis", "-minimize-layers"}
+	if err := run(args, &stdout, &stderr); err != nil {
+		t.Fatalf("run() エラー = %v", err)
+	}
+	password := strings.TrimSpace(stdout.String())
+	if len(password) != 40 {
+		t.Errorf("長さ = %d, want 40: %q", len(password), password)
+	}
+	if strings.ContainsAny(password, "@^~\\`&*()_+=[]{}|:") {
+		t.Errorf("USとJISで位置の異なる記号が含まれています: %q", password)
+	}
+
+	if err := run([]string{"-keyboard", "us,fr"}, &stdout, &stderr); err == nil {
+		t.Error("run() 数字を入力できない配列の組み合わせでエラーが返されませんでした")
+	}
+	if err := run([]string{"-keyboard", "dvorak"}, &stdout, &stderr); err == nil {
+		t.Error("run() 未対応のキーボード配列でエラーが返されませんでした")
+	}
+}
+
+func TestRun_Rules(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	args := []string{"-length", "12", "-count", "20", "-max-repeat", "1", "-max-sequence", "2", "-first", "letter", "-last", "alphanumeric", "-forbid", "pw"}
+	if err := run(args, &stdout, &stderr); err != nil {
+		t.Fatalf("run() エラー = %v", err)
+	}
+	rules := config.Rules{MaxRepeat: 1, MaxSequence: 2, FirstClass: config.ClassLetter, LastClass: config.ClassAlphanumeric, Forbidden: []string{"pw"}}
+	for _, password := range strings.Fields(stdout.String()) {
+		if v := rules.Violation([]byte(password)); v != "" {
+			t.Errorf("規則 %s を満たしていません: %q", v, password)
+		}
+	}
+
+	err := run([]string{"-length", "4", "-uppercase=false", "-lowercase=false", "-numbers=false", "-custom-symbols", "!", "-max-repeat", "1"}, &stdout, &stderr)
+	if !errors.Is(err, config.ErrRulesUnsatisfiable) {
+		t.Errorf("run() エラー = %v, want %v", err, config.ErrRulesUnsatisfiable)
+	}
+	if err := run([]string{"-first", "kanji"}, &stdout, &stderr); err == nil {
+		t.Error("run() 未対応の文字種でエラーが返されませんでした")
+	}
+}
+
+func TestRun_UnknownCommand(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if err := run([]string{"unknown"}, &stdout, &stderr); err == nil {
+		t.Error("run() 不明なサブコマンドでエラーが返されませんでした")
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/okamyuji/PasswordGenerator/internal/mnemonic"
+)
+
+// 秘密をBIP-39のニーモニックとして生成、または-encode / -decodeで相互に変換
+func runMnemonic(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("mnemonic", stderr)
+	words := fs.Int("words", mnemonic.DefaultWords, "単語数 (12, 15, 18, 21, 24)")
+	encode := fs.String("encode", "", "ニーモニックに変換する16進数の秘密")
+	decode := fs.String("decode", "", "16進数に戻すニーモニック")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	switch {
+	case *encode != "" && *decode != "":
+		return fmt.Errorf("-encodeと-decodeは同時に指定できません")
+	case *encode != "":
+		entropy, err := hex.DecodeString(strings.TrimSpace(*encode))
+		if err != nil {
+			return fmt.Errorf("無効な16進数です: %w", err)
+		}
+		m, err := mnemonic.Encode(entropy)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(stdout, m)
+		return nil
+	case *decode != "":
+		entropy, err := mnemonic.Decode(*decode)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(stdout, hex.EncodeToString(entropy))
+		return nil
+	}
+
+	result, err := mnemonic.New().Generate(*words)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "entropy: %s\nmnemonic: %s\n", result.Entropy, result.Mnemonic)
+	return nil
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/okamyuji/PasswordGenerator/internal/otp"
+	"github.com/okamyuji/PasswordGenerator/internal/qrcode"
+)
+
+// TOTP/HOTPシードを生成、または-verifyでコードを検証
+func runOTP(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("totp", stderr)
+	otpType := fs.String("type", string(otp.TypeTOTP), "種類 (totp, hotp)")
+	issuer := fs.String("issuer", "", "発行者")
+	account := fs.String("account", "", "アカウント名")
+	algorithm := fs.String("algorithm", string(otp.AlgorithmSHA1), "アルゴリズム (SHA1, SHA256, SHA512)")
+	digits := fs.Int("digits", otp.DefaultDigits, "桁数")
+	period := fs.Int("period", otp.DefaultPeriod, "TOTPの周期（秒）")
+	counter := fs.Uint64("counter", 0, "HOTPのカウンター")
+	qrPath := fs.String("qr", "", "QRコードの出力先（拡張子.svgでSVG、それ以外はPNG）")
+	secret := fs.String("secret", "", "検証するシークレット（-verifyと併用）")
+	verify := fs.String("verify", "", "検証するコード")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	opts := otp.Options{
+		Type:      otp.Type(strings.ToLower(*otpType)),
+		Issuer:    *issuer,
+		Account:   *account,
+		Algorithm: otp.Algorithm(*algorithm),
+		Digits:    *digits,
+		Period:    *period,
+		Counter:   *counter,
+	}
+
+	if *verify != "" {
+		valid, next, err := otp.Verify(*secret, *verify, opts, time.Now())
+		if err != nil {
+			return err
+		}
+		if !valid {
+			return fmt.Errorf("コードが一致しません")
+		}
+		fmt.Fprintln(stdout, "OK")
+		if opts.Type == otp.TypeHOTP {
+			fmt.Fprintf(stdout, "次のカウンター: %d\n", next)
+		}
+		return nil
+	}
+
+	key, err := otp.New().Generate(opts)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "secret: %s\nuri: %s\n", key.Secret, key.URI)
+
+	if *qrPath == "" {
+		return nil
+	}
+	code, err := qrcode.Encode(key.URI)
+	if err != nil {
+		return err
+	}
+	body := code.PNG(qrcode.DefaultScale)
+	if strings.HasSuffix(strings.ToLower(*qrPath), ".svg") {
+		body = []byte(code.SVG())
+	}
+	return os.WriteFile(*qrPath, body, 0o600)
+}
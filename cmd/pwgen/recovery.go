@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/okamyuji/PasswordGenerator/internal/recovery"
+)
+
+// MFAリカバリーコードのセットを生成し、-hashesで保存用ハッシュを書き出す
+func runRecovery(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("recovery", stderr)
+	opts := recovery.Options{}
+	fs.IntVar(&opts.Count, "count", recovery.DefaultCount, "生成するコード数")
+	fs.StringVar(&opts.Format, "format", recovery.DefaultFormat, "コードの書式（x がランダムな文字）")
+	alphabet := fs.String("alphabet", string(recovery.AlphabetCrockford), "アルファベット (crockford, numeric)")
+	hash := fs.String("hash", string(recovery.HashArgon2id), "ハッシュアルゴリズム (argon2id, bcrypt)")
+	hashes := fs.String("hashes", "", "保存用ハッシュを書き出すファイル（- で標準出力）")
+	title := fs.String("title", "", "シートのタイトル")
+	account := fs.String("account", "", "シートに表示するアカウント名")
+	sheet := fs.Bool("sheet", false, "印刷用のテキストシートとして出力")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var err error
+	if opts.Hash, err = recovery.ParseHashAlgorithm(*hash); err != nil {
+		return err
+	}
+	opts.Alphabet = recovery.Alphabet(*alphabet)
+
+	set, err := recovery.New().Generate(opts)
+	if err != nil {
+		return err
+	}
+
+	if *sheet {
+		fmt.Fprint(stdout, recovery.NewSheet(set, *title, *account, time.Now()).Text())
+	} else {
+		for _, c := range set.Codes {
+			fmt.Fprintln(stdout, c.Code)
+		}
+	}
+
+	if *hashes == "" {
+		return nil
+	}
+	var sb strings.Builder
+	for _, c := range set.Codes {
+		sb.WriteString(c.Hash + "\n")
+	}
+	return writeOutput(*hashes, stdout, []byte(sb.String()))
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/okamyuji/PasswordGenerator/internal/generator"
+	"github.com/okamyuji/PasswordGenerator/internal/shamir"
+)
+
+// パスワードを生成し、-shares個のうち-threshold個で復元できるシェアに分割
+func runSplit(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("split", stderr)
+	cfg := passwordConfigFlags(fs)
+	shares := fs.Int("shares", shamir.DefaultShares, "シェアの総数")
+	threshold := fs.Int("threshold", shamir.DefaultThreshold, "復元に必要なシェア数")
+	encodingName := fs.String("encoding", string(shamir.EncodingMnemonic), "シェアのエンコーディング (mnemonic, base32)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	encoding, err := shamir.ParseEncoding(*encodingName)
+	if err != nil {
+		return err
+	}
+	password, err := generator.New().Generate(*cfg)
+	if err != nil {
+		return err
+	}
+	defer password.Destroy()
+
+	parts, err := shamir.New().Split(password.Bytes(), shamir.Options{Shares: *shares, Threshold: *threshold})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprint(stdout, "secret: ")
+	if _, err := password.WriteTo(stdout); err != nil {
+		return err
+	}
+	fmt.Fprintln(stdout)
+	for _, part := range parts {
+		encoded, err := part.Encode(encoding)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "share %d/%d (threshold %d): %s\n", part.Index, len(parts), part.Threshold, encoded)
+	}
+	return nil
+}
+
+// シェアから秘密を復元して検証
+//
+// シェアは1行に1つずつ標準入力または-inのファイルから読み込む。
+// splitの出力の「share 1/5 (threshold 3): 」のような見出しはそのまま貼り付けてよい。
+func runCombine(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("combine", stderr)
+	in := fs.String("in", "", "シェアのファイル（省略時は標準入力）")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	src := stdin
+	if *in != "" && *in != "-" {
+		f, err := os.Open(*in)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		src = f
+	}
+
+	var parts []shamir.Share
+	scanner := bufio.NewScanner(src)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if _, after, ok := strings.Cut(line, ": "); ok && strings.HasPrefix(line, "share ") {
+			line = after
+		}
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "secret:") {
+			continue
+		}
+		part, err := shamir.ParseShare(line)
+		if err != nil {
+			return fmt.Errorf("%d行目: %w", n, err)
+		}
+		parts = append(parts, part)
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if len(parts) == 0 {
+		return errors.New("シェアが入力されていません")
+	}
+
+	restored, err := shamir.Combine(parts)
+	if err != nil {
+		return err
+	}
+	defer restored.Destroy()
+	if _, err := restored.WriteTo(stdout); err != nil {
+		return err
+	}
+	fmt.Fprintln(stdout)
+	return nil
+}
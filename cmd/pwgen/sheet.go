@@ -0,0 +1,53 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/okamyuji/PasswordGenerator/internal/format"
+	"github.com/okamyuji/PasswordGenerator/internal/generator"
+	"github.com/okamyuji/PasswordGenerator/internal/sheet"
+)
+
+// ユーザーごとにパスワードを生成し、1人につき1ページの印刷用PDFを出力
+func runSheet(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("sheet", stderr)
+	cfg := passwordConfigFlags(fs)
+	users := fs.String("users", "", "カンマ区切りのユーザー名")
+	title := fs.String("title", sheet.DefaultTitle, "シートのタイトル")
+	out := fs.String("out", "", "出力先のPDFファイル（省略時は標準出力）")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var usernames []string
+	for _, u := range strings.Split(*users, ",") {
+		if u = strings.TrimSpace(u); u != "" {
+			usernames = append(usernames, u)
+		}
+	}
+	if len(usernames) == 0 {
+		return errors.New("ユーザー名（-users）を指定してください")
+	}
+	if len(usernames) > sheet.MaxUsers {
+		return fmt.Errorf("ユーザー数が最大値を超えています: %d (最大: %d)", len(usernames), sheet.MaxUsers)
+	}
+
+	creds, err := format.GenerateCredentials(generator.New(), *cfg, usernames)
+	if err != nil {
+		return err
+	}
+	s, err := sheet.New(creds, *cfg, *title, time.Now())
+	if err != nil {
+		return err
+	}
+	pdf, err := s.PDF()
+	if err != nil {
+		return err
+	}
+	defer clear(pdf)
+	return writeOutput(*out, stdout, pdf)
+}
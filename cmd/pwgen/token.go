@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/okamyuji/PasswordGenerator/internal/token"
+)
+
+// トークンを生成、または-verifyで指定されたトークンを検証
+func runToken(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("token", stderr)
+	opts := token.Options{}
+	fs.IntVar(&opts.Bytes, "bytes", 0, "乱数のバイト数（省略時は32）")
+	fs.IntVar(&opts.EntropyBits, "bits", 0, "必要なエントロピー（ビット）")
+	encoding := fs.String("encoding", string(token.EncodingBase64URL), "エンコーディング (hex, base32, base64url, base64, zbase32, crockford)")
+	fs.StringVar(&opts.Prefix, "prefix", "", "トークンのプレフィックス（例: ghp_）")
+	checksum := fs.String("checksum", "", "チェックサム (crc32, crockford)")
+	count := fs.Int("count", 1, "生成するトークン数")
+	verify := fs.String("verify", "", "検証するトークン")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var err error
+	if opts.Encoding, err = token.ParseEncoding(*encoding); err != nil {
+		return err
+	}
+	opts.Checksum = token.Checksum(*checksum)
+
+	if *verify != "" {
+		if err := token.Validate(*verify, opts); err != nil {
+			return fmt.Errorf("トークンが無効です: %w", err)
+		}
+		fmt.Fprintln(stdout, "OK")
+		return nil
+	}
+
+	g := token.New()
+	for i := 0; i < *count; i++ {
+		tok, err := g.Generate(opts)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(stdout, tok)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/okamyuji/PasswordGenerator/internal/generator"
+	"github.com/okamyuji/PasswordGenerator/internal/qrcode"
+	"github.com/okamyuji/PasswordGenerator/internal/wifi"
+)
+
+// WPA2パスフレーズを生成し、Wi-Fi接続用のQRコードを出力
+func runWiFi(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("wifi", stderr)
+	cfg := passwordConfigFlags(fs)
+	ssid := fs.String("ssid", "", "ネットワーク名（必須）")
+	hidden := fs.Bool("hidden", false, "SSIDをブロードキャストしないネットワーク")
+	formatName := fs.String("format", "png", "出力フォーマット (png, svg, text)")
+	scale := fs.Int("scale", qrcode.DefaultScale, "PNGの1モジュールあたりのピクセル数")
+	out := fs.String("out", "", "出力先のファイル（省略時は標準出力）")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if err := wifi.ValidateSSID(*ssid); err != nil {
+		return err
+	}
+	switch *formatName {
+	case "png", "svg", "text":
+	default:
+		return fmt.Errorf("未対応のフォーマット: %s (png / svg / text)", *formatName)
+	}
+	passphraseCfg, err := wifi.PassphraseConfig(*cfg)
+	if err != nil {
+		return err
+	}
+
+	password, err := generator.New().Generate(passphraseCfg)
+	if err != nil {
+		return err
+	}
+	defer password.Destroy()
+
+	passphrase := password.Reveal()
+	payload, err := wifi.Network{SSID: *ssid, Passphrase: passphrase, Hidden: *hidden}.Payload()
+	if err != nil {
+		return err
+	}
+	if *formatName == "text" {
+		return writeOutput(*out, stdout, []byte(payload+"\n"))
+	}
+
+	code, err := qrcode.Encode(payload)
+	if err != nil {
+		return err
+	}
+	// 画像と混ざらないよう、掲示用のパスフレーズは標準エラー出力に書く
+	fmt.Fprintln(stderr, "passphrase:", passphrase)
+	if *formatName == "svg" {
+		return writeOutput(*out, stdout, []byte(code.SVG()))
+	}
+	return writeOutput(*out, stdout, code.PNG(*scale))
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"crypto/rand"
 	"embed"
 	"encoding/base64"
@@ -11,7 +12,16 @@ import (
 
 	"github.com/okamyuji/PasswordGenerator/internal/generator"
 	"github.com/okamyuji/PasswordGenerator/internal/handler"
+	"github.com/okamyuji/PasswordGenerator/internal/health"
+	"github.com/okamyuji/PasswordGenerator/internal/identifier"
+	"github.com/okamyuji/PasswordGenerator/internal/keys"
 	"github.com/okamyuji/PasswordGenerator/internal/middleware"
+	"github.com/okamyuji/PasswordGenerator/internal/otp"
+	"github.com/okamyuji/PasswordGenerator/internal/preset"
+	"github.com/okamyuji/PasswordGenerator/internal/recovery"
+	"github.com/okamyuji/PasswordGenerator/internal/shamir"
+	"github.com/okamyuji/PasswordGenerator/internal/share"
+	"github.com/okamyuji/PasswordGenerator/internal/token"
 )
 
 //go:embed templates/* static/* static/img/* static/css/* static/js/*
@@ -44,22 +54,133 @@ func main() {
 	// テンプレートレンダラー
 	templateRenderer := handler.NewEmbedFSTemplateRenderer(content)
 
-	// パスワードジェネレーター
-	passwordGenerator := generator.New()
+	// 起動時の自己診断（各生成モードの既知解テスト）
+	if err := health.SelfTest(); err != nil {
+		logger.Error("自己診断に失敗", "error", err)
+		os.Exit(1)
+	}
+
+	// 乱数源（通常のビルドでは常にcrypto/rand）
+	source, err := randomSource()
+	if err != nil {
+		logger.Error("乱数源の初期化に失敗", "error", err)
+		os.Exit(1)
+	}
+
+	// 生成に使うバイトを連続ヘルステストで監視し、失敗後は生成を停止する
+	rng := health.NewMonitor(source)
+	healthHandler := handler.NewHealthHandler(rng)
+
+	// 生成を行うルートは、乱数源が異常な間は実行せずに503を返す
+	generation := func(h http.HandlerFunc) http.HandlerFunc {
+		return securityMiddleware.Middleware(healthHandler.Guard(h))
+	}
+
+	// パスワードジェネレーター（PWGEN_MLOCK=trueで生成したパスワードをロックしたメモリに置く）
+	passwordGenerator := generator.NewWithReader(rng)
+	if os.Getenv("PWGEN_MLOCK") == "true" {
+		passwordGenerator = passwordGenerator.WithMemoryLock()
+	}
 
 	// 依存性注入を使用したパスワードハンドラー
 	passwordHandler := handler.NewPasswordHandler(templateRenderer, passwordGenerator)
 
-	// ヘルスチェックエンドポイント
-	http.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		if _, err := w.Write([]byte("OK")); err != nil {
-			logger.Error("ヘルスチェックレスポンスの書き込みに失敗", "error", err)
+	// 区切り表記とフォネティックコード付きのパスワードハンドラー
+	readableHandler := handler.NewReadableHandler(passwordGenerator)
+
+	// トークン（APIキー・署名鍵）ハンドラー
+	tokenGenerator := token.NewWithReader(rng)
+	tokenHandler := handler.NewTokenHandler(tokenGenerator)
+
+	// UUID・ULID・NanoIDハンドラー
+	identifierHandler := handler.NewIdentifierHandler(identifier.NewWithReader(rng))
+
+	// フレームワーク用シークレットキーハンドラー
+	presetHandler := handler.NewPresetHandler(preset.NewWithReader(tokenGenerator, rng))
+
+	// TOTP/HOTPシードハンドラー
+	otpHandler := handler.NewOTPHandler(otp.NewWithReader(rng))
+
+	// MFAリカバリーコードハンドラー
+	recoveryHandler := handler.NewRecoveryHandler(templateRenderer, recovery.NewWithReader(rng))
+
+	// SSH・WireGuard鍵、JWKハンドラー（パスフレーズはパスワードジェネレーターで生成）
+	keyHandler := handler.NewKeyHandler(keys.NewWithReader(passwordGenerator, rng))
+
+	// パスワード・Wi-Fi接続情報のQRコードハンドラー
+	qrCodeHandler := handler.NewQRCodeHandler(passwordGenerator)
+
+	// 印刷用の認証情報シートハンドラー
+	sheetHandler := handler.NewSheetHandler(templateRenderer, passwordGenerator)
+
+	// 秘密分散ハンドラー（分割する秘密はパスワードジェネレーターで生成）
+	shamirHandler := handler.NewShamirHandler(shamir.NewWithReader(rng), passwordGenerator)
+
+	// 1回だけ閲覧できる共有リンク（PWGEN_SHARE_DIRを指定するとファイルに保存）
+	var shareStore share.Store = share.NewMemoryStore()
+	if dir := os.Getenv("PWGEN_SHARE_DIR"); dir != "" {
+		fileStore, err := share.NewFileStore(dir)
+		if err != nil {
+			logger.Error("共有リンクの保存先の初期化に失敗", "error", err)
+			os.Exit(1)
 		}
-	})
+		shareStore = fileStore
+	}
+	shareService := share.NewWithReader(shareStore, rng)
+	shareHandler := handler.NewShareHandler(templateRenderer, shareService, passwordGenerator)
+	go func() {
+		for range time.Tick(time.Minute) {
+			if err := shareService.Sweep(context.Background()); err != nil {
+				logger.Error("期限切れの共有リンクの削除に失敗", "error", err)
+			}
+		}
+	}()
+
+	// ヘルスチェックエンドポイント（乱数源のヘルステストに失敗していれば503）
+	http.HandleFunc("/health", healthHandler.Handle)
 
 	// ミドルウェアを使用したメインのパスワード生成ハンドラー
-	http.HandleFunc("/", securityMiddleware.Middleware(passwordHandler.Handle))
+	http.HandleFunc("/", generation(passwordHandler.Handle))
+
+	// 読み上げ用の表現付きパスワード生成API
+	http.HandleFunc("/api/password", generation(readableHandler.Handle))
+
+	// トークン生成API
+	http.HandleFunc("/api/token", generation(tokenHandler.Handle))
+
+	// 識別子生成API
+	http.HandleFunc("/api/identifiers", generation(identifierHandler.Handle))
+
+	// フレームワーク用シークレットキー生成API
+	http.HandleFunc("/api/framework-secret", generation(presetHandler.Handle))
+
+	// TOTP/HOTPシード生成と登録確認API
+	http.HandleFunc("/api/totp", generation(otpHandler.HandleGenerate))
+	http.HandleFunc("/api/totp/verify", securityMiddleware.Middleware(otpHandler.HandleVerify))
+
+	// MFAリカバリーコード生成API
+	http.HandleFunc("/api/recovery-codes", generation(recoveryHandler.Handle))
+
+	// SSH・WireGuard鍵生成API
+	http.HandleFunc("/api/keys/ssh", generation(keyHandler.HandleSSH))
+	http.HandleFunc("/api/keys/wireguard", generation(keyHandler.HandleWireGuard))
+
+	// JWT署名鍵（JWK/JWKS）生成API
+	http.HandleFunc("/api/jwk", generation(keyHandler.HandleJWK))
+
+	// QRコード生成API（PNG / SVG）
+	http.HandleFunc("/api/qrcode", generation(qrCodeHandler.Handle))
+
+	// 印刷用の認証情報シートAPI（HTML / PDF）
+	http.HandleFunc("/api/credential-sheet", generation(sheetHandler.Handle))
+
+	// 秘密分散API
+	http.HandleFunc("/api/shamir", generation(shamirHandler.Handle))
+
+	// 共有リンクの作成・閲覧ページ・開封API
+	http.HandleFunc("/api/share", generation(shareHandler.HandleCreate))
+	http.HandleFunc("/api/share/reveal", securityMiddleware.Middleware(shareHandler.HandleReveal))
+	http.HandleFunc("/share/", securityMiddleware.Middleware(shareHandler.HandleView))
 
 	// セキュリティヘッダー付きの静的ファイル配信
 	fs := http.FileServer(http.FS(content))
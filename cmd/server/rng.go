@@ -0,0 +1,24 @@
+//go:build !insecure_rand
+
+package main
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"os"
+)
+
+// 決定的な乱数のシードを指定する環境変数（insecure_randビルドタグ付きのビルドでのみ有効）
+const deterministicSeedEnv = "PWGEN_INSECURE_DETERMINISTIC_SEED"
+
+// サーバーが使用する乱数源を返す
+//
+// 通常のビルドでは常にcrypto/randを使う。決定的な乱数のシードが指定されている場合は、
+// 予測可能な秘密を配布しないよう起動を中止する。
+func randomSource() (io.Reader, error) {
+	if os.Getenv(deterministicSeedEnv) != "" {
+		return nil, fmt.Errorf("%sは insecure_rand ビルドタグ付きのビルドでのみ使用できます", deterministicSeedEnv)
+	}
+	return rand.Reader, nil
+}
@@ -0,0 +1,28 @@
+//go:build insecure_rand
+
+package main
+
+import (
+	"crypto/rand"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/okamyuji/PasswordGenerator/internal/random"
+)
+
+// 決定的な乱数のシードを指定する環境変数
+const deterministicSeedEnv = "PWGEN_INSECURE_DETERMINISTIC_SEED"
+
+// サーバーが使用する乱数源を返す
+//
+// insecure_randビルドタグ付きのビルドでのみ、シードが指定されていれば決定的な乱数を使う。
+// E2Eテストやゴールデンファイルの作成専用で、本番環境では使用しない。
+func randomSource() (io.Reader, error) {
+	seed := os.Getenv(deterministicSeedEnv)
+	if seed == "" {
+		return rand.Reader, nil
+	}
+	slog.Warn("決定的な乱数を使用しています。生成される値は予測可能です", "env", deterministicSeedEnv)
+	return random.NewDeterministic(seed), nil
+}
@@ -0,0 +1,28 @@
+//go:build insecure_rand
+
+package main
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestRandomSource(t *testing.T) {
+	t.Setenv(deterministicSeedEnv, "golden")
+	read := func() []byte {
+		r, err := randomSource()
+		if err != nil {
+			t.Fatalf("randomSource() エラー = %v", err)
+		}
+		b := make([]byte, 32)
+		if _, err := io.ReadFull(r, b); err != nil {
+			t.Fatalf("Read() エラー = %v", err)
+		}
+		return b
+	}
+
+	if !bytes.Equal(read(), read()) {
+		t.Error("同じシードで異なる乱数列が返されました")
+	}
+}
@@ -0,0 +1,25 @@
+//go:build !insecure_rand
+
+package main
+
+import (
+	"crypto/rand"
+	"testing"
+)
+
+func TestRandomSource(t *testing.T) {
+	t.Setenv(deterministicSeedEnv, "")
+	r, err := randomSource()
+	if err != nil {
+		t.Fatalf("randomSource() エラー = %v", err)
+	}
+	if r != rand.Reader {
+		t.Error("通常のビルドでcrypto/rand以外の乱数源が返されました")
+	}
+
+	// 通常のビルドでシードが指定された場合は起動を中止する
+	t.Setenv(deterministicSeedEnv, "golden")
+	if _, err := randomSource(); err == nil {
+		t.Error("randomSource() シード指定時にエラーが返されませんでした")
+	}
+}
@@ -1,6 +1,6 @@
 class PasswordGenerator {
     constructor() {
-        this.checkboxes = ['uppercase', 'lowercase', 'numbers', 'symbols'];
+        this.checkboxes = ['uppercase', 'lowercase', 'numbers', 'symbols', 'hiragana', 'katakana', 'fullWidthDigits'];
         this.initializeElements();
         this.attachEventListeners();
         this.generatePassword();
@@ -15,8 +15,19 @@ class PasswordGenerator {
             generateButton: document.getElementById('generateButton'),
             copyButton: document.getElementById('copyButton'),
             passwordField: document.getElementById('password'),
-            customSymbols: document.getElementById('customSymbols')
+            customSymbols: document.getElementById('customSymbols'),
+            symbolProfile: document.getElementById('symbolProfile'),
+            chunked: document.getElementById('chunked'),
+            spelling: document.getElementById('spelling'),
+            spellingLanguage: document.getElementById('spellingLanguage'),
+            unicodeBlocks: document.querySelectorAll('input[name="unicodeBlocks"]'),
+            keyboardLayouts: document.querySelectorAll('input[name="keyboardLayouts"]'),
+            minimizeLayerChanges: document.getElementById('minimizeLayerChanges'),
+            rules: ['maxRepeat', 'maxSequence', 'firstClass', 'lastClass', 'forbidden'].map(id => document.getElementById(id)),
+            entropy: document.getElementById('entropy'),
+            warning: document.getElementById('warning')
         };
+        this.letters = [];
     }
 
     attachEventListeners() {
@@ -34,6 +45,28 @@ class PasswordGenerator {
             });
         });
 
+        // Unicodeブロックのイベントリスナー
+        this.elements.unicodeBlocks.forEach(checkbox => {
+            checkbox.addEventListener('change', () => {
+                this.validateOptions();
+                this.generatePassword();
+            });
+        });
+
+        // キーボード配列とレイヤー切り替えのイベントリスナー
+        [...this.elements.keyboardLayouts, this.elements.minimizeLayerChanges].forEach(checkbox => {
+            checkbox.addEventListener('change', () => {
+                this.generatePassword();
+            });
+        });
+
+        // 構造の規則のイベントリスナー
+        this.elements.rules.forEach(input => {
+            input.addEventListener('change', () => {
+                this.generatePassword();
+            });
+        });
+
         // パスワード生成ボタン
         this.elements.generateButton.addEventListener('click', () => {
             this.generatePassword();
@@ -54,6 +87,16 @@ class PasswordGenerator {
             this.generatePassword();
         });
 
+        // 記号プロファイル選択のイベントリスナー
+        this.elements.symbolProfile.addEventListener('change', () => {
+            this.generatePassword();
+        });
+
+        // 読みの言語切り替え（再生成はしない）
+        this.elements.spellingLanguage.addEventListener('change', () => {
+            this.renderSpelling();
+        });
+
         // ページ読み込み時の表示制御
         document.addEventListener('DOMContentLoaded', () => {
             this.elements.symbolsArea.style.display = 
@@ -62,7 +105,8 @@ class PasswordGenerator {
     }
 
     validateOptions() {
-        const anyChecked = this.checkboxes.some(id => document.getElementById(id).checked);
+        const anyChecked = this.checkboxes.some(id => document.getElementById(id).checked) ||
+            Array.from(this.elements.unicodeBlocks).some(checkbox => checkbox.checked);
         this.elements.generateButton.disabled = !anyChecked;
         return anyChecked;
     }
@@ -78,8 +122,23 @@ class PasswordGenerator {
             params.append('numbers', document.getElementById('numbers').checked.toString());
             params.append('symbols', document.getElementById('symbols').checked.toString());
             params.append('customSymbols', this.elements.customSymbols.value);
+            params.append('symbolProfile', this.elements.symbolProfile.value);
+            params.append('hiragana', document.getElementById('hiragana').checked.toString());
+            params.append('katakana', document.getElementById('katakana').checked.toString());
+            params.append('fullWidthDigits', document.getElementById('fullWidthDigits').checked.toString());
+            this.elements.unicodeBlocks.forEach(checkbox => {
+                if (checkbox.checked) params.append('unicodeBlocks', checkbox.value);
+            });
+            this.elements.keyboardLayouts.forEach(checkbox => {
+                if (checkbox.checked) params.append('keyboardLayouts', checkbox.value);
+            });
+            params.append('minimizeLayerChanges', this.elements.minimizeLayerChanges.checked.toString());
+            this.elements.rules.forEach(input => {
+                // 0と空欄は制限なしとして送らない
+                if (input.value && input.value !== '0') params.append(input.id, input.value);
+            });
 
-            const response = await fetch('/', {
+            const response = await fetch('/api/password', {
                 method: 'POST',
                 headers: {
                     'Content-Type': 'application/x-www-form-urlencoded',
@@ -89,18 +148,44 @@ class PasswordGenerator {
             });
 
             if (!response.ok) {
-                throw new Error(`HTTP error! status: ${response.status}`);
+                // 規則を満たせない場合などは、サーバーのエラーメッセージを表示する
+                const message = (await response.text()).trim();
+                throw new Error(message || `HTTP error! status: ${response.status}`);
             }
             
-            const password = await response.text();
-            this.elements.passwordField.value = password;
-            this.elements.copyButton.disabled = !password;
+            const result = await response.json();
+            this.elements.passwordField.value = result.password;
+            this.elements.copyButton.disabled = !result.password;
+            this.elements.chunked.textContent = result.chunked;
+            this.elements.entropy.textContent = `強度: 約${Math.floor(result.entropyBits)}ビット`;
+            this.elements.warning.textContent = result.warning || '';
+            this.letters = result.letters;
+            this.renderSpelling();
         } catch (error) {
             console.error('Error:', error);
             this.elements.passwordField.value = 'エラーが発生しました';
+            this.elements.chunked.textContent = '';
+            this.elements.entropy.textContent = '';
+            this.elements.warning.textContent = error.message;
+            this.letters = [];
+            this.renderSpelling();
         }
     }
 
+    // 1文字ずつの読みを文字の種類で色分けして表示
+    renderSpelling() {
+        const language = this.elements.spellingLanguage.value;
+        const items = this.letters.map(letter => {
+            const item = document.createElement('li');
+            item.className = `char-${letter.class}`;
+            const char = document.createElement('code');
+            char.textContent = letter.char;
+            item.append(char, ` ${letter[language]}`);
+            return item;
+        });
+        this.elements.spelling.replaceChildren(...items);
+    }
+
     copyPassword() {
         this.elements.passwordField.select();
         document.execCommand('copy');
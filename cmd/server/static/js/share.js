@@ -0,0 +1,90 @@
+class ShareViewer {
+    constructor() {
+        this.elements = {
+            container: document.getElementById('share'),
+            message: document.getElementById('shareMessage'),
+            revealButton: document.getElementById('revealButton'),
+            secret: document.getElementById('shareSecret'),
+            copyButton: document.getElementById('shareCopyButton')
+        };
+        this.id = this.elements.container.dataset.id;
+        // 復号鍵はURLフラグメントにのみ含まれ、サーバーには送信されない
+        this.key = window.location.hash.slice(1);
+        this.csrfToken = document.querySelector('meta[name="csrf-token"]')?.getAttribute('content') || '';
+        // ブラウザの履歴に鍵を残さない
+        history.replaceState(null, '', window.location.pathname);
+
+        if (!this.key) {
+            this.showError('リンクに復号鍵が含まれていません。URLを最後までコピーしたか確認してください。');
+            return;
+        }
+        this.elements.revealButton.addEventListener('click', () => this.reveal());
+        this.elements.copyButton.addEventListener('click', () => this.copy());
+    }
+
+    async reveal() {
+        this.elements.revealButton.disabled = true;
+        try {
+            const params = new URLSearchParams();
+            params.append('id', this.id);
+            const response = await fetch('/api/share/reveal', {
+                method: 'POST',
+                headers: {
+                    'Content-Type': 'application/x-www-form-urlencoded',
+                    'X-CSRF-Token': this.csrfToken
+                },
+                body: params
+            });
+            if (response.status === 404) {
+                this.showError('このリンクは既に表示されたか、有効期限が切れています。');
+                return;
+            }
+            if (!response.ok) {
+                throw new Error(`HTTP error! status: ${response.status}`);
+            }
+
+            const sealed = await response.json();
+            const key = await crypto.subtle.importKey('raw', this.decode(this.key, true), 'AES-GCM', false, ['decrypt']);
+            const plaintext = await crypto.subtle.decrypt(
+                { name: 'AES-GCM', iv: this.decode(sealed.nonce), additionalData: new TextEncoder().encode(this.id) },
+                key,
+                this.decode(sealed.ciphertext)
+            );
+
+            this.elements.secret.textContent = new TextDecoder().decode(plaintext);
+            this.elements.secret.hidden = false;
+            this.elements.copyButton.hidden = false;
+            this.elements.revealButton.hidden = true;
+            this.elements.message.textContent = 'このリンクは無効になりました。必要であれば今すぐ保存してください。';
+        } catch (error) {
+            console.error('Error:', error);
+            this.showError('パスワードを表示できませんでした。リンクが正しいか確認してください。');
+        }
+    }
+
+    // base64（urlSafeがtrueならbase64url、パディングなし）をバイト列に変換
+    decode(value, urlSafe = false) {
+        let b64 = urlSafe ? value.replace(/-/g, '+').replace(/_/g, '/') : value;
+        while (b64.length % 4 !== 0) {
+            b64 += '=';
+        }
+        return Uint8Array.from(atob(b64), c => c.charCodeAt(0));
+    }
+
+    copy() {
+        navigator.clipboard.writeText(this.elements.secret.textContent);
+        const originalText = this.elements.copyButton.textContent;
+        this.elements.copyButton.textContent = 'コピーしました';
+        setTimeout(() => {
+            this.elements.copyButton.textContent = originalText;
+        }, 2000);
+    }
+
+    showError(message) {
+        this.elements.message.textContent = message;
+        this.elements.revealButton.hidden = true;
+    }
+}
+
+// インスタンス化
+new ShareViewer();
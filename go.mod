@@ -2,4 +2,15 @@ module github.com/okamyuji/PasswordGenerator
 
 go 1.25.0
 
-require golang.org/x/time v0.15.0
+require (
+	filippo.io/age v1.3.1
+	golang.org/x/crypto v0.54.0
+	golang.org/x/time v0.15.0
+	rsc.io/qr v0.2.0
+)
+
+require (
+	filippo.io/edwards25519 v1.1.0 // indirect
+	filippo.io/hpke v0.4.0 // indirect
+	golang.org/x/sys v0.47.0 // indirect
+)
@@ -0,0 +1,207 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// 入力に使うキーボード配列
+type KeyboardLayout string
+
+const (
+	KeyboardUS     KeyboardLayout = "us"  // 米国（ANSI）
+	KeyboardJIS    KeyboardLayout = "jis" // 日本語（JIS、106/109キー）
+	KeyboardGerman KeyboardLayout = "de"  // ドイツ語（QWERTZ）
+	KeyboardFrench KeyboardLayout = "fr"  // フランス語（AZERTY）
+)
+
+// 物理キーの並び（XKBのキー名）
+//
+// 同じ名前のキーは配列によらず同じスキャンコードを送る。
+var keyRows = [4][]string{
+	{"TLDE", "AE01", "AE02", "AE03", "AE04", "AE05", "AE06", "AE07", "AE08", "AE09", "AE10", "AE11", "AE12", "AE13"},
+	{"AD01", "AD02", "AD03", "AD04", "AD05", "AD06", "AD07", "AD08", "AD09", "AD10", "AD11", "AD12"},
+	{"AC01", "AC02", "AC03", "AC04", "AC05", "AC06", "AC07", "AC08", "AC09", "AC10", "AC11", "BKSL"},
+	{"LSGT", "AB01", "AB02", "AB03", "AB04", "AB05", "AB06", "AB07", "AB08", "AB09", "AB10", "AB11"},
+}
+
+// 配列ごとの各キーの文字（キーごとに通常・Shiftの2文字）
+//
+// AltGrが必要な文字とデッドキーは含めず、\x00で表す。
+var keyboardLayouts = map[KeyboardLayout][4]string{
+	KeyboardUS: {
+		"`~1!2@3#4$5%6^7&8*9(0)-_=+\x00\x00",
+		"qQwWeErRtTyYuUiIoOpP[{]}",
+		"aAsSdDfFgGhHjJkKlL;:'\"\\|",
+		"\x00\x00zZxXcCvVbBnNmM,<.>/?\x00\x00",
+	},
+	KeyboardJIS: {
+		"\x00\x001!2\"3#4$5%6&7'8(9)0\x00-=^~\\|",
+		"qQwWeErRtTyYuUiIoOpP@`[{",
+		"aAsSdDfFgGhHjJkKlL;+:*]}",
+		"\x00\x00zZxXcCvVbBnNmM,<.>/?\\_",
+	},
+	KeyboardGerman: {
+		"\x00°1!2\"3§4$5%6&7/8(9)0=ß?\x00\x00\x00\x00",
+		"qQwWeErRtTzZuUiIoOpPüÜ+*",
+		"aAsSdDfFgGhHjJkKlLöÖäÄ#'",
+		"<>yYxXcCvVbBnNmM,;.:-_\x00\x00",
+	},
+	KeyboardFrench: {
+		"²\x00&1é2\"3'4(5-6è7_8ç9à0)°=+\x00\x00",
+		"aAzZeErRtTyYuUiIoOpP\x00\x00$£",
+		"qQsSdDfFgGhHjJkKlLmMù%*µ",
+		"<>wWxXcCvVbBnN,?;.:/!§\x00\x00",
+	},
+}
+
+// 利用可能なキーボード配列の一覧
+func KeyboardLayouts() []KeyboardLayout {
+	return []KeyboardLayout{KeyboardUS, KeyboardJIS, KeyboardGerman, KeyboardFrench}
+}
+
+// 配列名の一覧を解析（カンマ区切りも可、重複は除く）
+func ParseKeyboardLayouts(values ...string) ([]KeyboardLayout, error) {
+	var layouts []KeyboardLayout
+	for _, v := range values {
+		for _, name := range strings.Split(v, ",") {
+			l := KeyboardLayout(strings.ToLower(strings.TrimSpace(name)))
+			if l == "" || slices.Contains(layouts, l) {
+				continue
+			}
+			if _, ok := keyboardLayouts[l]; !ok {
+				return nil, fmt.Errorf("未対応のキーボード配列: %s", strings.TrimSpace(name))
+			}
+			layouts = append(layouts, l)
+		}
+	}
+	return layouts, nil
+}
+
+// すべての配列で同じキー・同じShift状態で入力できる文字の集合
+//
+// 配列が1つの場合は、デッドキーやAltGrを使わずに入力できる文字の集合となる。
+// 複数の場合は、配列の対応付けがないVMコンソールなどでも同じ文字として入力される。
+func keyboardCharset(layouts []KeyboardLayout) (map[rune]bool, error) {
+	var keys [][]rune
+	for i, l := range layouts {
+		rows, ok := keyboardLayouts[l]
+		if !ok {
+			return nil, fmt.Errorf("未対応のキーボード配列: %s", l)
+		}
+		var flat []rune
+		for _, row := range rows {
+			flat = append(flat, []rune(row)...)
+		}
+		if i == 0 {
+			keys = make([][]rune, len(flat))
+		}
+		for j, r := range flat {
+			keys[j] = append(keys[j], r)
+		}
+	}
+
+	charset := make(map[rune]bool)
+	for _, chars := range keys {
+		if chars[0] != 0 && !slices.ContainsFunc(chars, func(r rune) bool { return r != chars[0] }) {
+			charset[chars[0]] = true
+		}
+	}
+	return charset, nil
+}
+
+// 文字セットからキーボード配列で入力できる文字だけを残す
+func filterKeyboard(charset string, typeable map[rune]bool) string {
+	var sb strings.Builder
+	for _, r := range charset {
+		if typeable[r] {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// モバイルキーボードのレイヤー数
+const NumLayers = 5
+
+// モバイルキーボードで文字を入力するレイヤー
+//
+// 0: 小文字、1: 大文字（Shift）、2: 数字と主な記号、3: その他の記号、4: かななどIMEで切り替える文字。
+func Layer(r rune) int {
+	switch {
+	case r >= 'a' && r <= 'z':
+		return 0
+	case r >= 'A' && r <= 'Z':
+		return 1
+	case r >= '0' && r <= '9' || strings.ContainsRune(mobileNumberSymbols, r):
+		return 2
+	case r < 0x80:
+		return 3
+	case unicode.IsLower(r):
+		return 0
+	case unicode.IsUpper(r):
+		return 1
+	default:
+		return 4
+	}
+}
+
+// iOSやAndroidの数字レイヤーに共通して並ぶ記号
+const mobileNumberSymbols = "-/:;()$&@\".,?!'"
+
+// 文字を指定されたレイヤーの順にまとめ、レイヤーの切り替えを1回ずつに減らす
+//
+// 同じレイヤー内の文字の順序は保つ。rankはレイヤーごとの並び順。
+func GroupByLayer[T byte | rune](chars []T, rank [NumLayers]int) {
+	slices.SortStableFunc(chars, func(a, b T) int {
+		return rank[Layer(rune(a))] - rank[Layer(rune(b))]
+	})
+}
+
+// 配列名をカンマ区切りで連結
+func joinLayouts(layouts []KeyboardLayout) string {
+	names := make([]string, len(layouts))
+	for i, l := range layouts {
+		names[i] = string(l)
+	}
+	return strings.Join(names, ",")
+}
+
+// 文字をレイヤーごとにまとめることで失われるエントロピー（ビット）の上限
+//
+// 並べ替えで区別できなくなるのは同じ文字の組み合わせの並び順で、その数は多項係数 L!/∏nᵢ! となる。
+// これが最大になるのは、含まれるレイヤーに文字数が均等に分かれる場合。
+func layerOrderBits(length int, charsets []string) float64 {
+	var present [NumLayers]bool
+	for _, charset := range charsets {
+		for _, r := range charset {
+			present[Layer(r)] = true
+		}
+	}
+	k := 0
+	for _, p := range present {
+		if p {
+			k++
+		}
+	}
+	if k < 2 {
+		return 0
+	}
+
+	lgamma := func(n int) float64 {
+		v, _ := math.Lgamma(float64(n))
+		return v
+	}
+	bits := lgamma(length + 1)
+	for i := range k {
+		n := length / k
+		if i < length%k {
+			n++
+		}
+		bits -= lgamma(n + 1)
+	}
+	return bits / math.Ln2
+}
@@ -0,0 +1,172 @@
+package config
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseKeyboardLayouts(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []string
+		want    []KeyboardLayout
+		wantErr bool
+	}{
+		{name: "未指定", values: nil, want: nil},
+		{name: "カンマ区切りと重複", values: []string{"JIS, us", "jis"}, want: []KeyboardLayout{KeyboardJIS, KeyboardUS}},
+		{name: "未対応", values: []string{"dvorak"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseKeyboardLayouts(tt.values...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseKeyboardLayouts() エラー = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseKeyboardLayouts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeyboardLayoutTables(t *testing.T) {
+	for _, l := range KeyboardLayouts() {
+		rows, ok := keyboardLayouts[l]
+		if !ok {
+			t.Fatalf("%s の配列表がありません", l)
+		}
+		for i, row := range rows {
+			if got, want := len([]rune(row)), 2*len(keyRows[i]); got != want {
+				t.Errorf("%s の%d段目の文字数 = %d, want %d", l, i+1, got, want)
+			}
+		}
+	}
+}
+
+func TestPasswordConfig_CharsetsKeyboard(t *testing.T) {
+	symbols := PasswordConfig{Length: 16, UseSymbols: true, CustomSymbols: Symbols + "~\\`'\"/§"}
+	tests := []struct {
+		name        string
+		config      PasswordConfig
+		wantContain string
+		wantExclude string
+		wantErr     bool
+	}{
+		{
+			name:        "US",
+			config:      withLayouts(symbols, KeyboardUS),
+			wantContain: "@^~\\`'\"/",
+			wantExclude: "§",
+		},
+		{
+			name:        "ドイツ語はAltGrとデッドキーの記号を除く",
+			config:      withLayouts(symbols, KeyboardGerman),
+			wantContain: "!\"$%&/()=?#'§",
+			wantExclude: "@^~\\`[]{}|",
+		},
+		{
+			name:        "USとJISで同じ位置にある記号のみ",
+			config:      withLayouts(symbols, KeyboardUS, KeyboardJIS),
+			wantContain: "!#$%-;,.<>?/",
+			wantExclude: "@^~\\`&*()_+=[]{}|:'\"",
+		},
+		{
+			name:        "USとドイツ語ではYとZを除く",
+			config:      withLayouts(PasswordConfig{Length: 16, UseLowercase: true}, KeyboardUS, KeyboardGerman),
+			wantContain: "abcx",
+			wantExclude: "yz",
+		},
+		{
+			name:    "USとフランス語では数字が同じ位置にない",
+			config:  withLayouts(PasswordConfig{Length: 16, UseNumbers: true}, KeyboardUS, KeyboardFrench),
+			wantErr: true,
+		},
+		{
+			name:    "かなはキーボードから直接入力できない",
+			config:  withLayouts(PasswordConfig{Length: 16, UseHiragana: true}, KeyboardJIS),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			charsets, err := tt.config.Charsets()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Charsets() エラー = %v, wantErr %v", err, tt.wantErr)
+			}
+			all := strings.Join(charsets, "")
+			for _, r := range tt.wantContain {
+				if !strings.ContainsRune(all, r) {
+					t.Errorf("Charsets() = %q に %q が含まれていません", all, r)
+				}
+			}
+			if strings.ContainsAny(all, tt.wantExclude) {
+				t.Errorf("Charsets() = %q に除外すべき文字 %q が含まれています", all, tt.wantExclude)
+			}
+		})
+	}
+}
+
+func withLayouts(cfg PasswordConfig, layouts ...KeyboardLayout) PasswordConfig {
+	cfg.KeyboardLayouts = layouts
+	return cfg
+}
+
+func TestLayer(t *testing.T) {
+	tests := []struct {
+		chars string
+		want  int
+	}{
+		{chars: "az", want: 0},
+		{chars: "AZ", want: 1},
+		{chars: "09-/:;()$&@\".,?!'", want: 2},
+		{chars: "#%^*+=[]{}_\\|~<>`", want: 3},
+		{chars: "äß", want: 0},
+		{chars: "ÄÖ", want: 1},
+		{chars: "あア０", want: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.chars, func(t *testing.T) {
+			for _, r := range tt.chars {
+				if got := Layer(r); got != tt.want {
+					t.Errorf("Layer(%q) = %d, want %d", r, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestGroupByLayer(t *testing.T) {
+	chars := []byte("a1B#b2A%")
+	GroupByLayer(chars, [NumLayers]int{2, 0, 3, 1, 4})
+	if got, want := string(chars), "BA#%ab12"; got != want {
+		t.Errorf("GroupByLayer() = %q, want %q", got, want)
+	}
+}
+
+func TestPasswordConfig_EntropyBitsMinimizeLayers(t *testing.T) {
+	cfg := PasswordConfig{Length: 12, UseUppercase: true, UseLowercase: true, UseNumbers: true}
+	full, err := cfg.EntropyBits()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.MinimizeLayerChanges = true
+	got, err := cfg.EntropyBits()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 3レイヤーに4文字ずつ分かれた場合の並び順 12!/(4!4!4!) = 34650 通りを差し引く
+	if want := full - math.Log2(34650); math.Abs(got-want) > 1e-9 {
+		t.Errorf("EntropyBits() = %v, want %v", got, want)
+	}
+
+	// レイヤーが1つなら並べ替えで失われるものはない
+	cfg = PasswordConfig{Length: 12, UseLowercase: true, MinimizeLayerChanges: true}
+	if got, _ := cfg.EntropyBits(); math.Abs(got-12*math.Log2(26)) > 1e-9 {
+		t.Errorf("EntropyBits() = %v, want %v", got, 12*math.Log2(26))
+	}
+}
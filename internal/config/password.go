@@ -1,17 +1,170 @@
 package config
 
+import (
+	"fmt"
+	"math"
+	"unicode/utf8"
+)
+
 type PasswordConfig struct {
-	Length        int    `json:"length"`
-	UseUppercase  bool   `json:"useUppercase"`
-	UseLowercase  bool   `json:"useLowercase"`
-	UseNumbers    bool   `json:"useNumbers"`
-	UseSymbols    bool   `json:"useSymbols"`
-	CustomSymbols string `json:"customSymbols"`
+	Length        int           `json:"length"`
+	UseUppercase  bool          `json:"useUppercase"`
+	UseLowercase  bool          `json:"useLowercase"`
+	UseNumbers    bool          `json:"useNumbers"`
+	UseSymbols    bool          `json:"useSymbols"`
+	CustomSymbols string        `json:"customSymbols"`
+	SymbolProfile SymbolProfile `json:"symbolProfile"` // 記号を埋め込むコンテキスト（空文字は制限なし）
+
+	UseHiragana        bool           `json:"useHiragana"`
+	UseKatakana        bool           `json:"useKatakana"`
+	UseFullWidthDigits bool           `json:"useFullWidthDigits"`
+	UnicodeBlocks      []UnicodeBlock `json:"unicodeBlocks,omitempty"`
+
+	KeyboardLayouts      []KeyboardLayout `json:"keyboardLayouts,omitempty"` // 指定した全配列で同じ位置にある文字に限定
+	MinimizeLayerChanges bool             `json:"minimizeLayerChanges"`      // モバイルキーボードのレイヤー切り替えを減らす
+
+	Rules Rules `json:"rules"` // 連続・並び・先頭末尾の文字種などの構造の規則
 }
 
+// パスワードの最大長（文字数。ASCII以外の文字も1文字と数える）
+const MaxLength = 1000
+
 const (
 	Uppercase = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
 	Lowercase = "abcdefghijklmnopqrstuvwxyz"
 	Numbers   = "0123456789"
 	Symbols   = "!@#$%^&*()_+-=[]{}|;:,.<>?"
 )
+
+// 長さを検証し、選択された文字種ごとの文字セットを返す
+//
+// 記号は CustomSymbols（未指定時は Symbols）を記号プロファイルで絞り込んだもの。
+// かな・全角数字と選択したUnicodeブロックはそれぞれ1つの文字セットとする。
+// キーボード配列が指定されていれば、各文字セットをその配列で入力できる文字に絞り込む。
+func (c PasswordConfig) Charsets() ([]string, error) {
+	if c.Length <= 0 {
+		return nil, fmt.Errorf("無効な長さ: %d", c.Length)
+	}
+	if c.Length > MaxLength {
+		return nil, fmt.Errorf("パスワード長が最大値を超えています: %d (最大: %d)", c.Length, MaxLength)
+	}
+
+	var charsets, names []string
+	add := func(name, charset string) {
+		names = append(names, name)
+		charsets = append(charsets, charset)
+	}
+	if c.UseUppercase {
+		add("大文字", Uppercase)
+	}
+	if c.UseLowercase {
+		add("小文字", Lowercase)
+	}
+	if c.UseNumbers {
+		add("数字", Numbers)
+	}
+	if c.UseSymbols {
+		symbols := Symbols
+		if c.CustomSymbols != "" {
+			symbols = c.CustomSymbols
+		}
+		// 記号プロファイルが指定されていればコンテキストで安全な記号に絞り込む
+		symbols, err := c.SymbolProfile.Filter(symbols)
+		if err != nil {
+			return nil, err
+		}
+		if symbols == "" {
+			return nil, fmt.Errorf("記号プロファイル %s で使用できる記号がありません", c.SymbolProfile)
+		}
+		if err := validateGraphemes(symbols); err != nil {
+			return nil, err
+		}
+		add("記号", symbols)
+	}
+	if c.UseHiragana {
+		add("ひらがな", Hiragana)
+	}
+	if c.UseKatakana {
+		add("カタカナ", Katakana)
+	}
+	if c.UseFullWidthDigits {
+		add("全角数字", FullWidthDigits)
+	}
+	for _, b := range c.UnicodeBlocks {
+		charset, err := b.Charset()
+		if err != nil {
+			return nil, err
+		}
+		add(string(b), charset)
+	}
+
+	if len(c.KeyboardLayouts) > 0 {
+		typeable, err := keyboardCharset(c.KeyboardLayouts)
+		if err != nil {
+			return nil, err
+		}
+		for i, charset := range charsets {
+			if charsets[i] = filterKeyboard(charset, typeable); charsets[i] == "" {
+				return nil, fmt.Errorf("キーボード配列 %s で入力できる%sがありません", joinLayouts(c.KeyboardLayouts), names[i])
+			}
+		}
+	}
+
+	if len(charsets) == 0 {
+		return nil, fmt.Errorf("文字タイプが選択されていません")
+	}
+	if err := c.Rules.validate(charsets); err != nil {
+		return nil, err
+	}
+	return charsets, nil
+}
+
+// パスワード1つあたりのエントロピー（ビット）
+//
+// 文字セットのバイト数ではなく文字数から求めるため、かなやUnicodeブロックでも正しい値になる。
+// レイヤーの切り替えを減らす場合は、並び順で失われる分を差し引いた下限を返す。
+// 先頭・末尾の文字種の規則は差し引くが、連続・並び・禁止文字列で除かれる分はわずかなため考慮しない。
+func (c PasswordConfig) EntropyBits() (float64, error) {
+	charsets, err := c.Charsets()
+	if err != nil {
+		return 0, err
+	}
+	size := 0
+	for _, charset := range charsets {
+		size += utf8.RuneCountInString(charset)
+	}
+	bits := float64(c.Length) * math.Log2(float64(size))
+	// 先頭・末尾の文字はその文字種の文字からしか選ばれない
+	for _, class := range []CharClass{c.Rules.FirstClass, c.Rules.LastClass} {
+		if class != "" {
+			bits -= math.Log2(float64(size) / float64(classCount(charsets, class)))
+		}
+	}
+	if c.MinimizeLayerChanges {
+		bits -= layerOrderBits(c.Length, charsets)
+	}
+	return max(bits, 0), nil
+}
+
+// ASCII以外の文字を含む文字セットを選択しているか
+func (c PasswordConfig) NonASCII() bool {
+	if c.UseHiragana || c.UseKatakana || c.UseFullWidthDigits || len(c.UnicodeBlocks) > 0 {
+		return true
+	}
+	if c.UseSymbols {
+		for i := 0; i < len(c.CustomSymbols); i++ {
+			if c.CustomSymbols[i] >= utf8.RuneSelf {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ASCIIのみを前提とする用途にASCII以外の文字を選択した場合の警告（問題がなければ空文字）
+func (c PasswordConfig) ASCIIWarning() string {
+	if !c.NonASCII() || !c.SymbolProfile.ASCIIOnly() {
+		return ""
+	}
+	return fmt.Sprintf("記号プロファイル %s の用途ではASCII以外の文字をそのまま使用できません。かな・全角文字・Unicodeブロックを外すことを推奨します", c.SymbolProfile)
+}
@@ -1,6 +1,9 @@
 package config
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestPasswordConfigValidation(t *testing.T) {
 	tests := []struct {
@@ -58,3 +61,70 @@ func TestPasswordConfigConstants(t *testing.T) {
 		t.Error("記号の定数が空です")
 	}
 }
+
+func TestPasswordConfig_Charsets(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  PasswordConfig
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:   "すべての文字種",
+			config: PasswordConfig{Length: 12, UseUppercase: true, UseLowercase: true, UseNumbers: true, UseSymbols: true},
+			want:   []string{Uppercase, Lowercase, Numbers, Symbols},
+		},
+		{
+			name:   "カスタム記号と記号プロファイル",
+			config: PasswordConfig{Length: 12, UseNumbers: true, UseSymbols: true, CustomSymbols: "!$&_", SymbolProfile: SymbolProfileShell},
+			want:   []string{Numbers, "_"},
+		},
+		{
+			name:   "かな・全角数字とUnicodeブロック",
+			config: PasswordConfig{Length: 12, UseHiragana: true, UseKatakana: true, UseFullWidthDigits: true, UnicodeBlocks: []UnicodeBlock{BlockGreek}},
+			want:   []string{Hiragana, Katakana, FullWidthDigits, unicodeBlocks[BlockGreek]},
+		},
+		{
+			name:    "結合文字を含むカスタム記号",
+			config:  PasswordConfig{Length: 12, UseSymbols: true, CustomSymbols: "!e\u0301"},
+			wantErr: true,
+		},
+		{
+			name:    "未対応のUnicodeブロック",
+			config:  PasswordConfig{Length: 12, UnicodeBlocks: []UnicodeBlock{"klingon"}},
+			wantErr: true,
+		},
+		{
+			name:    "長さが0",
+			config:  PasswordConfig{UseNumbers: true},
+			wantErr: true,
+		},
+		{
+			name:    "最大長を超える",
+			config:  PasswordConfig{Length: MaxLength + 1, UseNumbers: true},
+			wantErr: true,
+		},
+		{
+			name:    "文字種なし",
+			config:  PasswordConfig{Length: 12},
+			wantErr: true,
+		},
+		{
+			name:    "使用できる記号なし",
+			config:  PasswordConfig{Length: 12, UseSymbols: true, CustomSymbols: "$", SymbolProfile: SymbolProfileShell},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.config.Charsets()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Charsets() エラー = %v, wantErr %v", err, tt.wantErr)
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("Charsets() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// 先頭・末尾の文字に求める文字種
+type CharClass string
+
+const (
+	ClassLetter       CharClass = "letter"       // 英字（かななどの文字も含む）
+	ClassUppercase    CharClass = "uppercase"    // 大文字
+	ClassLowercase    CharClass = "lowercase"    // 小文字
+	ClassDigit        CharClass = "digit"        // 数字
+	ClassAlphanumeric CharClass = "alphanumeric" // 英字または数字
+	ClassSymbol       CharClass = "symbol"       // 英字・数字以外
+)
+
+// 文字種の一覧
+func CharClasses() []CharClass {
+	return []CharClass{ClassLetter, ClassUppercase, ClassLowercase, ClassDigit, ClassAlphanumeric, ClassSymbol}
+}
+
+// 文字種名を解析（空文字は制約なし）
+func ParseCharClass(name string) (CharClass, error) {
+	c := CharClass(strings.ToLower(strings.TrimSpace(name)))
+	if c == "" || c.valid() {
+		return c, nil
+	}
+	return "", fmt.Errorf("未対応の文字種: %s", name)
+}
+
+func (c CharClass) valid() bool {
+	for _, class := range CharClasses() {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+// 文字が文字種に含まれるか
+func (c CharClass) Contains(r rune) bool {
+	switch c {
+	case ClassLetter:
+		return unicode.IsLetter(r)
+	case ClassUppercase:
+		return unicode.IsUpper(r)
+	case ClassLowercase:
+		return unicode.IsLower(r)
+	case ClassDigit:
+		return unicode.IsDigit(r)
+	case ClassAlphanumeric:
+		return unicode.IsLetter(r) || unicode.IsDigit(r)
+	case ClassSymbol:
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	default:
+		return true
+	}
+}
+
+// 禁止する部分文字列の最大数
+const MaxForbiddenSubstrings = 100
+
+// 規則を満たすまでに生成をやり直す回数の上限
+const MaxRuleAttempts = 1000
+
+// 規則を満たすパスワードを試行回数の上限までに生成できなかった
+var ErrRulesUnsatisfiable = errors.New("規則を満たすパスワードを生成できませんでした。規則を緩めるか、長さ・文字種を見直してください")
+
+// 既存システムのパスワードポリシーに合わせるための構造の規則
+//
+// 生成したパスワードが規則を満たさなければ全体を生成し直す（棄却サンプリング）。
+// 文字の置き換えで直すと特定の文字が出やすくなるため、部分的な修正は行わない。
+type Rules struct {
+	MaxRepeat   int       `json:"maxRepeat,omitempty"`   // 同じ文字が連続できる数（0は制限なし）
+	MaxSequence int       `json:"maxSequence,omitempty"` // abc・321のような連続した並びの長さの上限（0は制限なし）
+	FirstClass  CharClass `json:"firstClass,omitempty"`  // 先頭の文字の文字種
+	LastClass   CharClass `json:"lastClass,omitempty"`   // 末尾の文字の文字種
+	Forbidden   []string  `json:"forbidden,omitempty"`   // 含めない部分文字列（大文字小文字を区別しない）
+}
+
+// 規則が指定されていないか
+func (r Rules) Empty() bool {
+	return r.MaxRepeat == 0 && r.MaxSequence == 0 && r.FirstClass == "" && r.LastClass == "" && len(r.Forbidden) == 0
+}
+
+// 規則の値と、文字セットに先頭・末尾の文字種の文字があることを検証
+func (r Rules) validate(charsets []string) error {
+	if r.MaxRepeat < 0 {
+		return fmt.Errorf("無効な同じ文字の連続数: %d", r.MaxRepeat)
+	}
+	if r.MaxSequence < 0 {
+		return fmt.Errorf("無効な連続した並びの長さ: %d", r.MaxSequence)
+	}
+	if len(r.Forbidden) > MaxForbiddenSubstrings {
+		return fmt.Errorf("禁止する文字列が多すぎます: %d (最大: %d)", len(r.Forbidden), MaxForbiddenSubstrings)
+	}
+	for _, f := range r.Forbidden {
+		if f == "" {
+			return fmt.Errorf("禁止する文字列が空です")
+		}
+	}
+	for _, c := range []struct {
+		class CharClass
+		name  string
+	}{{r.FirstClass, "先頭"}, {r.LastClass, "末尾"}} {
+		if c.class == "" {
+			continue
+		}
+		if !c.class.valid() {
+			return fmt.Errorf("未対応の文字種: %s", c.class)
+		}
+		if classCount(charsets, c.class) == 0 {
+			return fmt.Errorf("%sの文字種 %s に当たる文字が選択された文字種にありません", c.name, c.class)
+		}
+	}
+	return nil
+}
+
+// 文字セットのうち文字種に含まれる文字の数
+func classCount(charsets []string, class CharClass) int {
+	n := 0
+	for _, charset := range charsets {
+		for _, r := range charset {
+			if class.Contains(r) {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// UTF-8のパスワードが破っている規則の名前（すべて満たしていれば空文字）
+//
+// パスワードを文字列に変換せず、バイト列のまま調べる。
+func (r Rules) Violation(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	if first, _ := utf8.DecodeRune(b); !r.FirstClass.Contains(first) {
+		return "先頭の文字種"
+	}
+	if last, _ := utf8.DecodeLastRune(b); !r.LastClass.Contains(last) {
+		return "末尾の文字種"
+	}
+
+	prev := rune(-1)
+	repeat, sequence, step := 0, 0, 0
+	for i := 0; i < len(b); {
+		c, size := utf8.DecodeRune(b[i:])
+		if c == prev {
+			repeat++
+		} else {
+			repeat = 1
+		}
+		if r.MaxRepeat > 0 && repeat > r.MaxRepeat {
+			return "同じ文字の連続"
+		}
+
+		d := sequenceStep(prev, c)
+		switch {
+		case d == 0:
+			sequence = 1
+		case d == step:
+			sequence++
+		default:
+			sequence = 2
+		}
+		step = d
+		if r.MaxSequence > 0 && sequence > r.MaxSequence {
+			return "連続した並び"
+		}
+
+		for _, f := range r.Forbidden {
+			if hasPrefixFold(b[i:], f) {
+				return "禁止された文字列"
+			}
+		}
+		prev = c
+		i += size
+	}
+	return ""
+}
+
+// 英字・数字が昇順（1）または降順（-1）に隣り合っているか（それ以外は0）
+//
+// 英字は大文字小文字を区別せず、aBcも連続した並びとみなす。
+func sequenceStep(a, b rune) int {
+	if a < 0 || !ClassAlphanumeric.Contains(a) || !ClassAlphanumeric.Contains(b) {
+		return 0
+	}
+	switch unicode.ToLower(b) - unicode.ToLower(a) {
+	case 1:
+		return 1
+	case -1:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// bがprefixで始まるか（大文字小文字を区別しない）
+func hasPrefixFold(b []byte, prefix string) bool {
+	for _, p := range prefix {
+		c, size := utf8.DecodeRune(b)
+		if size == 0 || unicode.ToLower(c) != unicode.ToLower(p) {
+			return false
+		}
+		b = b[size:]
+	}
+	return true
+}
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseCharClass(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    CharClass
+		wantErr bool
+	}{
+		{name: "", want: ""},
+		{name: " Letter ", want: ClassLetter},
+		{name: "alphanumeric", want: ClassAlphanumeric},
+		{name: "kanji", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCharClass(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCharClass() エラー = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseCharClass() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRules_Violation(t *testing.T) {
+	tests := []struct {
+		name     string
+		rules    Rules
+		password string
+		want     string
+	}{
+		{name: "規則なし", rules: Rules{}, password: "aaa123", want: ""},
+		{name: "同じ文字の連続 - 上限内", rules: Rules{MaxRepeat: 2}, password: "aab1bb", want: ""},
+		{name: "同じ文字の連続 - 超過", rules: Rules{MaxRepeat: 2}, password: "xaaab", want: "同じ文字の連続"},
+		{name: "同じ文字の連続 - かな", rules: Rules{MaxRepeat: 1}, password: "あいい", want: "同じ文字の連続"},
+		{name: "昇順の並び", rules: Rules{MaxSequence: 2}, password: "x123y", want: "連続した並び"},
+		{name: "降順の並び", rules: Rules{MaxSequence: 2}, password: "zcba", want: "連続した並び"},
+		{name: "大文字小文字を区別しない並び", rules: Rules{MaxSequence: 2}, password: "aBc", want: "連続した並び"},
+		{name: "向きが変わる並びは別扱い", rules: Rules{MaxSequence: 2}, password: "abab", want: ""},
+		{name: "記号は並びとみなさない", rules: Rules{MaxSequence: 2}, password: "9:;", want: ""},
+		{name: "並び - 上限内", rules: Rules{MaxSequence: 3}, password: "abc9", want: ""},
+		{name: "先頭の文字種", rules: Rules{FirstClass: ClassLetter}, password: "1abc", want: "先頭の文字種"},
+		{name: "末尾の文字種", rules: Rules{LastClass: ClassAlphanumeric}, password: "abc!", want: "末尾の文字種"},
+		{name: "先頭と末尾を満たす", rules: Rules{FirstClass: ClassUppercase, LastClass: ClassDigit}, password: "A!b2", want: ""},
+		{name: "禁止された文字列", rules: Rules{Forbidden: []string{"pass", "admin"}}, password: "xAdMiNx", want: "禁止された文字列"},
+		{name: "禁止された文字列 - 末尾で途切れる", rules: Rules{Forbidden: []string{"pass"}}, password: "xxpas", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rules.Violation([]byte(tt.password)); got != tt.want {
+				t.Errorf("Violation(%q) = %q, want %q", tt.password, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPasswordConfig_CharsetsRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   Rules
+		wantErr bool
+	}{
+		{name: "有効な規則", rules: Rules{MaxRepeat: 2, MaxSequence: 3, FirstClass: ClassLetter, LastClass: ClassDigit, Forbidden: []string{"abc"}}},
+		{name: "負の連続数", rules: Rules{MaxRepeat: -1}, wantErr: true},
+		{name: "負の並びの長さ", rules: Rules{MaxSequence: -1}, wantErr: true},
+		{name: "空の禁止文字列", rules: Rules{Forbidden: []string{""}}, wantErr: true},
+		{name: "未対応の文字種", rules: Rules{FirstClass: "kanji"}, wantErr: true},
+		{name: "選択されていない文字種", rules: Rules{LastClass: ClassSymbol}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := PasswordConfig{Length: 12, UseLowercase: true, UseNumbers: true, Rules: tt.rules}
+			if _, err := cfg.Charsets(); (err != nil) != tt.wantErr {
+				t.Errorf("Charsets() エラー = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPasswordConfig_EntropyBitsRules(t *testing.T) {
+	// 先頭の1文字は36文字ではなく26文字の英字から選ばれる
+	cfg := PasswordConfig{Length: 10, UseLowercase: true, UseNumbers: true, Rules: Rules{FirstClass: ClassLetter}}
+	got, err := cfg.EntropyBits()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 9*math.Log2(36) + math.Log2(26); math.Abs(got-want) > 1e-9 {
+		t.Errorf("EntropyBits() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,106 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// かなと全角数字の文字セット
+//
+// IMEで入力しやすい清音・濁音・半濁音に限り、小書き文字や「ゐ」「ゑ」などは含めない。
+const (
+	Hiragana = "あいうえおかきくけこさしすせそたちつてとなにぬねのはひふへほまみむめもやゆよらりるれろわをん" +
+		"がぎぐげござじずぜぞだぢづでどばびぶべぼぱぴぷぺぽ"
+	Katakana = "アイウエオカキクケコサシスセソタチツテトナニヌネノハヒフヘホマミムメモヤユヨラリルレロワヲン" +
+		"ガギグゲゴザジズゼゾダヂヅデドバビブベボパピプペポ"
+	FullWidthDigits = "０１２３４５６７８９"
+)
+
+// 追加で選択できるUnicodeのブロック
+type UnicodeBlock string
+
+const (
+	BlockLatin1   UnicodeBlock = "latin1"   // Latin-1補助のアクセント付き文字（×と÷を除く）
+	BlockGreek    UnicodeBlock = "greek"    // ギリシャ文字の大文字と小文字
+	BlockCyrillic UnicodeBlock = "cyrillic" // キリル文字の基本の大文字と小文字
+	BlockHangul   UnicodeBlock = "hangul"   // ハングル音節（合成済み）
+	BlockCJK      UnicodeBlock = "cjk"      // CJK統合漢字
+)
+
+// ブロックごとの文字セット
+//
+// いずれも1コードポイントで1文字（書記素）となる合成済みの文字だけを含めるため、
+// 長さはルーン数と書記素数のどちらで数えても一致する。
+var unicodeBlocks = map[UnicodeBlock]string{
+	BlockLatin1:   runeRange(0xc0, 0xff, 0xd7, 0xf7),
+	BlockGreek:    runeRange(0x391, 0x3a9, 0x3a2) + runeRange(0x3b1, 0x3c9),
+	BlockCyrillic: runeRange(0x410, 0x44f),
+	BlockHangul:   runeRange(0xac00, 0xd7a3),
+	BlockCJK:      runeRange(0x4e00, 0x9fff),
+}
+
+// loからhiまでの文字からexcludeを除いた文字列
+func runeRange(lo, hi rune, exclude ...rune) string {
+	var sb strings.Builder
+	for r := lo; r <= hi; r++ {
+		excluded := false
+		for _, e := range exclude {
+			excluded = excluded || r == e
+		}
+		if !excluded {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// 利用可能なUnicodeブロックの一覧
+func UnicodeBlocks() []UnicodeBlock {
+	return []UnicodeBlock{BlockLatin1, BlockGreek, BlockCyrillic, BlockHangul, BlockCJK}
+}
+
+// ブロック名の一覧を解析（カンマ区切りも可、重複は除く）
+func ParseUnicodeBlocks(values ...string) ([]UnicodeBlock, error) {
+	var blocks []UnicodeBlock
+	seen := make(map[UnicodeBlock]bool)
+	for _, v := range values {
+		for _, name := range strings.Split(v, ",") {
+			b := UnicodeBlock(strings.ToLower(strings.TrimSpace(name)))
+			if b == "" || seen[b] {
+				continue
+			}
+			if _, ok := unicodeBlocks[b]; !ok {
+				return nil, fmt.Errorf("未対応のUnicodeブロック: %s", strings.TrimSpace(name))
+			}
+			seen[b] = true
+			blocks = append(blocks, b)
+		}
+	}
+	return blocks, nil
+}
+
+// ブロックの文字セット
+func (b UnicodeBlock) Charset() (string, error) {
+	charset, ok := unicodeBlocks[b]
+	if !ok {
+		return "", fmt.Errorf("未対応のUnicodeブロック: %s", b)
+	}
+	return charset, nil
+}
+
+// 1文字として数えられない結合文字・書式文字・制御文字（ASCII以外）が含まれていないか検証
+//
+// 長さをルーン単位で数えても書記素の数と食い違わないようにする。
+func validateGraphemes(s string) error {
+	for i, r := range s {
+		if s[i] < utf8.RuneSelf {
+			continue
+		}
+		if unicode.IsMark(r) || unicode.Is(unicode.Cf, r) || unicode.IsControl(r) {
+			return fmt.Errorf("1文字として扱えない文字は使用できません: %U", r)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package config
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestScriptCharsets(t *testing.T) {
+	tests := []struct {
+		name    string
+		charset string
+		want    int
+	}{
+		{name: "ひらがな", charset: Hiragana, want: 71},
+		{name: "カタカナ", charset: Katakana, want: 71},
+		{name: "全角数字", charset: FullWidthDigits, want: 10},
+		{name: string(BlockLatin1), charset: unicodeBlocks[BlockLatin1], want: 62},
+		{name: string(BlockGreek), charset: unicodeBlocks[BlockGreek], want: 49},
+		{name: string(BlockCyrillic), charset: unicodeBlocks[BlockCyrillic], want: 64},
+		{name: string(BlockHangul), charset: unicodeBlocks[BlockHangul], want: 11172},
+		{name: string(BlockCJK), charset: unicodeBlocks[BlockCJK], want: 20992},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := utf8.RuneCountInString(tt.charset); got != tt.want {
+				t.Errorf("文字数 = %d, want %d", got, tt.want)
+			}
+			if err := validateGraphemes(tt.charset); err != nil {
+				t.Errorf("validateGraphemes() エラー = %v", err)
+			}
+			seen := make(map[rune]bool)
+			for _, r := range tt.charset {
+				if seen[r] {
+					t.Errorf("重複した文字: %q", r)
+				}
+				seen[r] = true
+			}
+		})
+	}
+}
+
+func TestParseUnicodeBlocks(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []string
+		want    []UnicodeBlock
+		wantErr bool
+	}{
+		{name: "未指定", values: nil, want: nil},
+		{name: "カンマ区切りと重複", values: []string{"Greek, hangul", "greek"}, want: []UnicodeBlock{BlockGreek, BlockHangul}},
+		{name: "未対応", values: []string{"klingon"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseUnicodeBlocks(tt.values...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseUnicodeBlocks() エラー = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseUnicodeBlocks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPasswordConfig_EntropyBits(t *testing.T) {
+	tests := []struct {
+		name   string
+		config PasswordConfig
+		want   float64
+	}{
+		{name: "数字", config: PasswordConfig{Length: 10, UseNumbers: true}, want: 10 * math.Log2(10)},
+		// 3バイトのUTF-8でもバイト数ではなく文字数で数える
+		{name: "ひらがな", config: PasswordConfig{Length: 8, UseHiragana: true}, want: 8 * math.Log2(71)},
+		{name: "ハングルと小文字", config: PasswordConfig{Length: 4, UseLowercase: true, UnicodeBlocks: []UnicodeBlock{BlockHangul}}, want: 4 * math.Log2(26+11172)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.config.EntropyBits()
+			if err != nil {
+				t.Fatalf("EntropyBits() エラー = %v", err)
+			}
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("EntropyBits() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPasswordConfig_ASCIIWarning(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      PasswordConfig
+		wantWarning bool
+	}{
+		{name: "ASCIIのみ", config: PasswordConfig{UseLowercase: true, SymbolProfile: SymbolProfileURL}},
+		{name: "かなと制限のない用途", config: PasswordConfig{UseHiragana: true}},
+		{name: "かなとURL", config: PasswordConfig{UseHiragana: true, SymbolProfile: SymbolProfileURL}, wantWarning: true},
+		{name: "全角の記号とJDBC", config: PasswordConfig{UseSymbols: true, CustomSymbols: "＃", SymbolProfile: SymbolProfileJDBC}, wantWarning: true},
+		{name: "記号を使わない場合のカスタム記号", config: PasswordConfig{CustomSymbols: "＃", SymbolProfile: SymbolProfileJDBC}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.config.ASCIIWarning(); (got != "") != tt.wantWarning {
+				t.Errorf("ASCIIWarning() = %q, wantWarning %v", got, tt.wantWarning)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 記号をそのまま埋め込むコンテキスト
+type SymbolProfile string
+
+const (
+	SymbolProfileShell SymbolProfile = "shell" // POSIXシェルの引用符なしの単語
+	SymbolProfileURL   SymbolProfile = "url"   // URLのuserinfo（RFC 3986）
+	SymbolProfileJSON  SymbolProfile = "json"  // JSON文字列
+	SymbolProfileYAML  SymbolProfile = "yaml"  // YAMLのプレーンスカラー
+	SymbolProfileXML   SymbolProfile = "xml"   // XMLの属性値
+	SymbolProfileSQL   SymbolProfile = "sql"   // SQLの文字列リテラル
+	SymbolProfileJDBC  SymbolProfile = "jdbc"  // JDBC URLのクエリパラメータ
+)
+
+// コンテキストごとにエスケープが必要となる記号
+var symbolProfileExclusions = map[SymbolProfile]string{
+	// メタ文字、グロブ、展開、履歴展開（!）、チルダ展開、引用符
+	SymbolProfileShell: "!\"#$&'()*;<>?[\\]^`{|}~",
+	// unreservedとsub-delims以外、およびユーザーとパスワードの区切り（:）
+	SymbolProfileURL:  "\"#%/:<>?@[\\]^`{|}",
+	SymbolProfileJSON: "\"\\",
+	// プレーンスカラーの先頭で使えない指示子と、フローコンテキストの区切り
+	SymbolProfileYAML: "!\"#%&'*,-:>?@[\\]`{|}~",
+	SymbolProfileXML:  "\"&'<>",
+	// 引用符と、MySQLでエスケープ文字として扱われるバックスラッシュ
+	SymbolProfileSQL: "\"'\\",
+	// URLとして予約された記号、クエリの区切り、SQL Serverのプロパティ区切り（;）
+	SymbolProfileJDBC: "\"#%&+,/:;<=>?@[\\]^`{|}",
+}
+
+// ASCII以外の文字をエスケープなしでは扱えないコンテキスト
+//
+// URLのuserinfoはパーセントエンコーディングが必要で、JDBCドライバーの多くはデコードしない。
+var asciiOnlyProfiles = map[SymbolProfile]bool{
+	SymbolProfileURL:  true,
+	SymbolProfileJDBC: true,
+}
+
+// コンテキストがASCIIのみを前提とするか
+func (p SymbolProfile) ASCIIOnly() bool {
+	return asciiOnlyProfiles[p]
+}
+
+// 利用可能な記号プロファイルの一覧
+func SymbolProfiles() []SymbolProfile {
+	return []SymbolProfile{
+		SymbolProfileShell,
+		SymbolProfileURL,
+		SymbolProfileJSON,
+		SymbolProfileYAML,
+		SymbolProfileXML,
+		SymbolProfileSQL,
+		SymbolProfileJDBC,
+	}
+}
+
+// プロファイル名を解析（空文字はプロファイルなし）
+func ParseSymbolProfile(name string) (SymbolProfile, error) {
+	p := SymbolProfile(strings.ToLower(strings.TrimSpace(name)))
+	if p == "" {
+		return "", nil
+	}
+	if _, ok := symbolProfileExclusions[p]; !ok {
+		return "", fmt.Errorf("未対応の記号プロファイル: %s", name)
+	}
+	return p, nil
+}
+
+// 記号の集合からコンテキストで安全に使える記号だけを残す
+//
+// 空白や制御文字はどのコンテキストでも除外する。
+func (p SymbolProfile) Filter(symbols string) (string, error) {
+	if p == "" {
+		return symbols, nil
+	}
+	excluded, ok := symbolProfileExclusions[p]
+	if !ok {
+		return "", fmt.Errorf("未対応の記号プロファイル: %s", p)
+	}
+
+	var sb strings.Builder
+	for _, r := range symbols {
+		if r <= ' ' || r == 0x7f || strings.ContainsRune(excluded, r) {
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String(), nil
+}
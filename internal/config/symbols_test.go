@@ -0,0 +1,62 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSymbolProfileFilter(t *testing.T) {
+	tests := []struct {
+		profile   SymbolProfile
+		forbidden string
+		want      string
+	}{
+		{profile: SymbolProfileShell, forbidden: "$`'\"\\!&;|<>(){}[]*?#~^", want: "@%_+-=:,."},
+		{profile: SymbolProfileURL, forbidden: ":@/?#[]%", want: "!$&*()_+-=;,."},
+		{profile: SymbolProfileJSON, forbidden: "\"\\", want: Symbols},
+		{profile: SymbolProfileYAML, forbidden: ":#-?,[]{}&*!|>'\"%@`", want: "$^()_+=;.<"},
+		{profile: SymbolProfileXML, forbidden: "<>&\"'", want: "!@#$%^*()_+-=[]{}|;:,.?"},
+		{profile: SymbolProfileSQL, forbidden: "'\"\\", want: Symbols},
+		{profile: SymbolProfileJDBC, forbidden: "&;=+?#%:@/", want: "!$*()_-."},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.profile), func(t *testing.T) {
+			got, err := tt.profile.Filter(Symbols)
+			if err != nil {
+				t.Fatalf("Filter() エラー = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Filter() = %q, want %q", got, tt.want)
+			}
+
+			// カスタム記号に除外対象の文字や空白が含まれていても取り除かれる
+			custom, err := tt.profile.Filter(tt.forbidden + " \t\n")
+			if err != nil {
+				t.Fatalf("Filter() エラー = %v", err)
+			}
+			if custom != "" {
+				t.Errorf("Filter() = %q, 除外すべき文字が含まれています", custom)
+			}
+		})
+	}
+}
+
+func TestSymbolProfileFilter_NoProfile(t *testing.T) {
+	got, err := SymbolProfile("").Filter(Symbols)
+	if err != nil || got != Symbols {
+		t.Errorf("Filter() = %q, %v, want %q", got, err, Symbols)
+	}
+}
+
+func TestParseSymbolProfile(t *testing.T) {
+	for _, p := range SymbolProfiles() {
+		got, err := ParseSymbolProfile(strings.ToUpper(string(p)))
+		if err != nil || got != p {
+			t.Errorf("ParseSymbolProfile(%q) = %v, %v", p, got, err)
+		}
+	}
+	if _, err := ParseSymbolProfile("csv"); err == nil {
+		t.Error("ParseSymbolProfile() 未対応のプロファイルでエラーが返されませんでした")
+	}
+}
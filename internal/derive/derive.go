@@ -0,0 +1,167 @@
+package derive
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+
+	"github.com/okamyuji/PasswordGenerator/internal/config"
+	"github.com/okamyuji/PasswordGenerator/internal/random"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/scrypt"
+)
+
+// シードの導出に使う鍵導出関数
+type KDF string
+
+const (
+	KDFArgon2id KDF = "argon2id"
+	KDFScrypt   KDF = "scrypt"
+)
+
+// 導出結果を将来にわたって固定するため、パラメータは変更しない。
+// 変更が必要な場合は新しいバージョンのドメイン分離文字列とともに追加する。
+const (
+	domain = "pwgen.derive.v1"
+
+	argon2Time    = 3
+	argon2Memory  = 64 * 1024
+	argon2Threads = 4
+
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+
+	seedLen = 32
+)
+
+// パスワード導出のオプション
+type Options struct {
+	Site    string // 大文字小文字と前後の空白は区別しない
+	Login   string
+	Counter uint32 // パスワードを変更するときに増やす（0は1とみなす）
+	KDF     KDF
+	Config  config.PasswordConfig
+}
+
+// KDF名を解析
+func ParseKDF(name string) (KDF, error) {
+	k := KDF(strings.ToLower(strings.TrimSpace(name)))
+	switch k {
+	case "":
+		return KDFArgon2id, nil
+	case KDFArgon2id, KDFScrypt:
+		return k, nil
+	default:
+		return "", fmt.Errorf("未対応の鍵導出関数: %s", name)
+	}
+}
+
+// マスターパスワードとサイト・ログイン名・カウンターからパスワードを導出
+//
+// 同じ入力からは常に同じパスワードが得られる。KDFで導出した32バイトのシードを
+// ChaCha8の鍵として乱数列に展開し、文字種の規則に従ってパスワードを組み立てる。
+func Derive(master string, opts Options) (string, error) {
+	if master == "" {
+		return "", fmt.Errorf("マスターパスワードが指定されていません")
+	}
+	site := strings.ToLower(strings.TrimSpace(opts.Site))
+	if site == "" {
+		return "", fmt.Errorf("サイトが指定されていません")
+	}
+	if opts.Counter == 0 {
+		opts.Counter = 1
+	}
+	charsets, err := opts.Config.Charsets()
+	if err != nil {
+		return "", err
+	}
+
+	salt := saltFor(site, strings.TrimSpace(opts.Login), opts.Counter)
+	var seed [seedLen]byte
+	switch opts.KDF {
+	case KDFArgon2id, "":
+		copy(seed[:], argon2.IDKey([]byte(master), salt, argon2Time, argon2Memory, argon2Threads, seedLen))
+	case KDFScrypt:
+		key, err := scrypt.Key([]byte(master), salt, scryptN, scryptR, scryptP, seedLen)
+		if err != nil {
+			return "", err
+		}
+		copy(seed[:], key)
+	default:
+		return "", fmt.Errorf("未対応の鍵導出関数: %s", opts.KDF)
+	}
+
+	// 構造の規則を破った場合は、同じ乱数列の続きから生成し直す（結果は決定的なまま）
+	r := rand.NewChaCha8(seed)
+	var violation string
+	for range config.MaxRuleAttempts {
+		password, err := render(r, charsets, opts.Config.Length, opts.Config.MinimizeLayerChanges)
+		if err != nil {
+			return "", err
+		}
+		if violation = opts.Config.Rules.Violation([]byte(password)); violation == "" {
+			return password, nil
+		}
+	}
+	return "", fmt.Errorf("%w（%d回試行、最後の違反: %s）", config.ErrRulesUnsatisfiable, config.MaxRuleAttempts, violation)
+}
+
+// ドメイン分離文字列と長さ付きの各入力を連結したソルト
+func saltFor(site, login string, counter uint32) []byte {
+	var salt []byte
+	for _, field := range []string{domain, site, login} {
+		salt = binary.BigEndian.AppendUint32(salt, uint32(len(field)))
+		salt = append(salt, field...)
+	}
+	return binary.BigEndian.AppendUint32(salt, counter)
+}
+
+// 乱数列から、各文字種を1文字以上含むlength文字のパスワードを組み立てる
+//
+// groupLayersが真なら、最後にモバイルキーボードのレイヤーごとに文字をまとめる。
+func render(r *rand.ChaCha8, charsets []string, length int, groupLayers bool) (string, error) {
+	if len(charsets) > length {
+		return "", fmt.Errorf("長さ%dではすべての文字種（%d種類）を含められません", length, len(charsets))
+	}
+
+	result := make([]rune, 0, length)
+	for _, charset := range charsets {
+		s, err := random.String(r, []rune(charset), 1)
+		if err != nil {
+			return "", err
+		}
+		result = append(result, []rune(s)...)
+	}
+	rest, err := random.String(r, []rune(strings.Join(charsets, "")), length-len(charsets))
+	if err != nil {
+		return "", err
+	}
+	result = append(result, []rune(rest)...)
+
+	// Fisher-Yatesで必須文字の位置を混ぜる
+	for i := len(result) - 1; i > 0; i-- {
+		j, err := random.Intn(r, i+1)
+		if err != nil {
+			return "", err
+		}
+		result[i], result[j] = result[j], result[i]
+	}
+
+	if groupLayers {
+		var rank [config.NumLayers]int
+		for i := range rank {
+			rank[i] = i
+		}
+		for i := len(rank) - 1; i > 0; i-- {
+			j, err := random.Intn(r, i+1)
+			if err != nil {
+				return "", err
+			}
+			rank[i], rank[j] = rank[j], rank[i]
+		}
+		config.GroupByLayer(result, rank)
+	}
+	return string(result), nil
+}
@@ -0,0 +1,183 @@
+package derive
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/okamyuji/PasswordGenerator/internal/config"
+)
+
+var fullConfig = config.PasswordConfig{
+	Length:       16,
+	UseUppercase: true,
+	UseLowercase: true,
+	UseNumbers:   true,
+	UseSymbols:   true,
+}
+
+// 導出結果がバージョン間で変わらないことを保証するテストベクター
+//
+// これらの値が変わる変更は、既存の利用者のパスワードをすべて変えてしまう。
+func TestDerive_Vectors(t *testing.T) {
+	const master = "correct horse battery staple"
+
+	tests := []struct {
+		name string
+		opts Options
+		want string
+	}{
+		{
+			name: "argon2id",
+			opts: Options{Site: "example.com", Login: "alice", Config: fullConfig},
+			want: "51pNFf=?p@l#q9fT",
+		},
+		{
+			name: "サイト名の正規化とカウンターの既定値",
+			opts: Options{Site: " Example.COM ", Login: "alice", Counter: 1, KDF: KDFArgon2id, Config: fullConfig},
+			want: "51pNFf=?p@l#q9fT",
+		},
+		{
+			name: "カウンター",
+			opts: Options{Site: "example.com", Login: "alice", Counter: 2, Config: fullConfig},
+			want: "PZqSMjZJ_,7RF|(e",
+		},
+		{
+			name: "scrypt",
+			opts: Options{Site: "example.com", Login: "alice", KDF: KDFScrypt, Config: fullConfig},
+			want: ";VrO_)$)9o2^ft^g",
+		},
+		{
+			name: "数字のみのPIN",
+			opts: Options{Site: "bank.example", Config: config.PasswordConfig{Length: 6, UseNumbers: true}},
+			want: "478566",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Derive(master, tt.opts)
+			if err != nil {
+				t.Fatalf("Derive() エラー = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Derive() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDerive_SatisfiesConfig(t *testing.T) {
+	cfg := config.PasswordConfig{
+		Length:        8,
+		UseUppercase:  true,
+		UseLowercase:  true,
+		UseNumbers:    true,
+		UseSymbols:    true,
+		CustomSymbols: "!$&_",
+		SymbolProfile: config.SymbolProfileShell,
+	}
+	for _, login := range []string{"a", "b", "c", "d"} {
+		got, err := Derive("master", Options{Site: "example.com", Login: login, KDF: KDFScrypt, Config: cfg})
+		if err != nil {
+			t.Fatalf("Derive() エラー = %v", err)
+		}
+		if len(got) != cfg.Length {
+			t.Errorf("長さ = %d, want %d", len(got), cfg.Length)
+		}
+		for _, charset := range []string{config.Uppercase, config.Lowercase, config.Numbers, "_"} {
+			if !strings.ContainsAny(got, charset) {
+				t.Errorf("%q に %q の文字が含まれていません", got, charset)
+			}
+		}
+		if strings.ContainsAny(got, "!$&") {
+			t.Errorf("%q にプロファイルで除外された記号が含まれています", got)
+		}
+	}
+}
+
+func TestDerive_MinimizeLayerChanges(t *testing.T) {
+	cfg := config.PasswordConfig{Length: 16, UseUppercase: true, UseLowercase: true, UseNumbers: true}
+	plain, err := Derive("master", Options{Site: "example.com", KDF: KDFScrypt, Config: cfg})
+	if err != nil {
+		t.Fatalf("Derive() エラー = %v", err)
+	}
+	cfg.MinimizeLayerChanges = true
+	grouped, err := Derive("master", Options{Site: "example.com", KDF: KDFScrypt, Config: cfg})
+	if err != nil {
+		t.Fatalf("Derive() エラー = %v", err)
+	}
+
+	// 同じ文字をレイヤーごとに並べ替えたもの
+	sorted := func(s string) string {
+		b := []byte(s)
+		slices.Sort(b)
+		return string(b)
+	}
+	if sorted(plain) != sorted(grouped) {
+		t.Errorf("Derive() = %q, 並べ替え前 %q と文字が一致しません", grouped, plain)
+	}
+	changes := 0
+	for i := 1; i < len(grouped); i++ {
+		if config.Layer(rune(grouped[i])) != config.Layer(rune(grouped[i-1])) {
+			changes++
+		}
+	}
+	if changes > 2 {
+		t.Errorf("Derive() = %q, レイヤーの切り替え %d 回, want 2回以下", grouped, changes)
+	}
+}
+
+func TestDerive_Rules(t *testing.T) {
+	rules := config.Rules{MaxRepeat: 1, MaxSequence: 2, FirstClass: config.ClassUppercase, LastClass: config.ClassDigit}
+	cfg := fullConfig
+	cfg.Rules = rules
+	for _, login := range []string{"a", "b", "c"} {
+		opts := Options{Site: "example.com", Login: login, KDF: KDFScrypt, Config: cfg}
+		got, err := Derive("master", opts)
+		if err != nil {
+			t.Fatalf("Derive() エラー = %v", err)
+		}
+		if v := rules.Violation([]byte(got)); v != "" {
+			t.Errorf("Derive() = %q, 規則 %s を満たしていません", got, v)
+		}
+		// 生成し直しても同じ入力からは同じパスワードになる
+		if again, _ := Derive("master", opts); again != got {
+			t.Errorf("Derive() = %q, 2回目 %q", got, again)
+		}
+	}
+}
+
+func TestDerive_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		master string
+		opts   Options
+	}{
+		{name: "マスターパスワードなし", opts: Options{Site: "example.com", Config: fullConfig}},
+		{name: "サイトなし", master: "m", opts: Options{Site: " ", Config: fullConfig}},
+		{name: "文字種なし", master: "m", opts: Options{Site: "example.com", Config: config.PasswordConfig{Length: 8}}},
+		{name: "長さが文字種より短い", master: "m", opts: Options{Site: "example.com", Config: config.PasswordConfig{Length: 2, UseUppercase: true, UseLowercase: true, UseNumbers: true}}},
+		{name: "未対応のKDF", master: "m", opts: Options{Site: "example.com", KDF: "pbkdf2", Config: fullConfig}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Derive(tt.master, tt.opts); err == nil {
+				t.Error("Derive() エラーが返されませんでした")
+			}
+		})
+	}
+}
+
+func TestParseKDF(t *testing.T) {
+	if k, err := ParseKDF(""); err != nil || k != KDFArgon2id {
+		t.Errorf("ParseKDF(\"\") = %v, %v", k, err)
+	}
+	if k, err := ParseKDF("SCRYPT"); err != nil || k != KDFScrypt {
+		t.Errorf("ParseKDF(\"SCRYPT\") = %v, %v", k, err)
+	}
+	if _, err := ParseKDF("bcrypt"); err == nil {
+		t.Error("ParseKDF() エラーが返されませんでした")
+	}
+}
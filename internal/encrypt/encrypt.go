@@ -0,0 +1,143 @@
+package encrypt
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"filippo.io/age"
+	"filippo.io/age/agessh"
+	"filippo.io/age/armor"
+	"golang.org/x/crypto/ssh"
+)
+
+// 一度に指定できる受信者の上限
+const MaxRecipients = 20
+
+// 復号する暗号文の上限
+const MaxCiphertextSize = 16 << 20
+
+// 受信者の公開鍵を解析
+//
+// age X25519（age1...）とauthorized_keys形式のSSH Ed25519公開鍵に対応する。
+func ParseRecipient(s string) (age.Recipient, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case strings.HasPrefix(s, "age1"):
+		r, err := age.ParseX25519Recipient(s)
+		if err != nil {
+			return nil, fmt.Errorf("無効なage公開鍵: %s", s)
+		}
+		return r, nil
+	case strings.HasPrefix(s, ssh.KeyAlgoED25519+" "):
+		r, err := agessh.ParseRecipient(s)
+		if err != nil {
+			return nil, fmt.Errorf("無効なSSH公開鍵: %s", s)
+		}
+		return r, nil
+	default:
+		return nil, fmt.Errorf("未対応の受信者の公開鍵です（age1... または ssh-ed25519 ...）: %s", s)
+	}
+}
+
+// 改行区切りの公開鍵の一覧を解析（空行と#で始まる行は無視する）
+func ParseRecipients(values ...string) ([]age.Recipient, error) {
+	var recipients []age.Recipient
+	for _, v := range values {
+		for _, line := range strings.Split(v, "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			r, err := ParseRecipient(line)
+			if err != nil {
+				return nil, err
+			}
+			recipients = append(recipients, r)
+		}
+	}
+	if len(recipients) > MaxRecipients {
+		return nil, fmt.Errorf("受信者が多すぎます: %d (最大: %d)", len(recipients), MaxRecipients)
+	}
+	return recipients, nil
+}
+
+// 平文を受信者の公開鍵で暗号化し、ASCII armor形式で返す
+func Armor(plaintext []byte, recipients []age.Recipient) ([]byte, error) {
+	if len(recipients) == 0 {
+		return nil, errors.New("受信者が指定されていません")
+	}
+	var buf bytes.Buffer
+	aw := armor.NewWriter(&buf)
+	w, err := age.Encrypt(aw, recipients...)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(plaintext); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	if err := aw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// 秘密鍵を解析
+//
+// ageのidentityファイル（AGE-SECRET-KEY-1...）とOpenSSH形式のEd25519秘密鍵に対応する。
+// パスフレーズ付きのSSH秘密鍵には対応しない。
+func ParseIdentities(data []byte) ([]age.Identity, error) {
+	if bytes.Contains(data, []byte("-----BEGIN")) {
+		identity, err := agessh.ParseIdentity(data)
+		var missing *ssh.PassphraseMissingError
+		if errors.As(err, &missing) {
+			return nil, errors.New("パスフレーズ付きのSSH秘密鍵には対応していません")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("無効なSSH秘密鍵: %w", err)
+		}
+		if _, ok := identity.(*agessh.Ed25519Identity); !ok {
+			return nil, errors.New("未対応のSSH秘密鍵です（Ed25519のみ）")
+		}
+		return []age.Identity{identity}, nil
+	}
+
+	identities, err := age.ParseIdentities(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("無効な秘密鍵: %w", err)
+	}
+	return identities, nil
+}
+
+// 暗号文を復号（ASCII armor形式とバイナリ形式のどちらにも対応）
+//
+// 平文は秘密を含むため、呼び出し元で使用後にゼロ埋めする。
+func Decrypt(ciphertext []byte, identities []age.Identity) ([]byte, error) {
+	if len(ciphertext) > MaxCiphertextSize {
+		return nil, fmt.Errorf("暗号文が大きすぎます: %dバイト (最大: %dバイト)", len(ciphertext), MaxCiphertextSize)
+	}
+	var src io.Reader = bytes.NewReader(ciphertext)
+	if bytes.HasPrefix(bytes.TrimLeft(ciphertext, " \t\r\n"), []byte(armor.Header)) {
+		src = armor.NewReader(src)
+	}
+
+	r, err := age.Decrypt(src, identities...)
+	var noMatch *age.NoIdentityMatchError
+	if errors.As(err, &noMatch) {
+		return nil, errors.New("この秘密鍵で復号できる受信者が含まれていません")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("無効な暗号文: %w", err)
+	}
+	plaintext, err := io.ReadAll(r)
+	if err != nil {
+		clear(plaintext)
+		return nil, fmt.Errorf("復号に失敗しました: %w", err)
+	}
+	return plaintext, nil
+}
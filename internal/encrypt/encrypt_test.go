@@ -0,0 +1,164 @@
+package encrypt
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"golang.org/x/crypto/ssh"
+)
+
+// テスト用のSSH Ed25519鍵ペア（authorized_keys形式の公開鍵とOpenSSH形式の秘密鍵）
+func sshKeyPair(t *testing.T, passphrase string) (string, []byte) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sshPub, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var block *pem.Block
+	if passphrase != "" {
+		block, err = ssh.MarshalPrivateKeyWithPassphrase(priv, "", []byte(passphrase))
+	} else {
+		block, err = ssh.MarshalPrivateKey(priv, "")
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.TrimSpace(string(ssh.MarshalAuthorizedKey(sshPub))) + " alice@example", pem.EncodeToMemory(block)
+}
+
+func TestArmor_RoundTrip(t *testing.T) {
+	x25519, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sshPublic, sshPrivate := sshKeyPair(t, "")
+
+	tests := []struct {
+		name      string
+		recipient string
+		identity  []byte
+	}{
+		{name: "X25519", recipient: x25519.Recipient().String(), identity: []byte("# created: now\n" + x25519.String() + "\n")},
+		{name: "SSH Ed25519", recipient: sshPublic, identity: sshPrivate},
+	}
+
+	plaintext := []byte("W!T6CySHWX1JKji)")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recipients, err := ParseRecipients(tt.recipient)
+			if err != nil {
+				t.Fatalf("ParseRecipients() エラー = %v", err)
+			}
+			armored, err := Armor(plaintext, recipients)
+			if err != nil {
+				t.Fatalf("Armor() エラー = %v", err)
+			}
+			if !strings.HasPrefix(string(armored), "-----BEGIN AGE ENCRYPTED FILE-----\n") {
+				t.Errorf("Armor() がASCII armor形式ではありません: %q", armored)
+			}
+			if bytes.Contains(armored, plaintext) {
+				t.Error("Armor() の結果に平文が含まれています")
+			}
+
+			identities, err := ParseIdentities(tt.identity)
+			if err != nil {
+				t.Fatalf("ParseIdentities() エラー = %v", err)
+			}
+			got, err := Decrypt(append([]byte("\n"), armored...), identities)
+			if err != nil {
+				t.Fatalf("Decrypt() エラー = %v", err)
+			}
+			if !bytes.Equal(got, plaintext) {
+				t.Errorf("Decrypt() = %q, want %q", got, plaintext)
+			}
+		})
+	}
+}
+
+func TestArmor_MultipleRecipients(t *testing.T) {
+	alice, _ := age.GenerateX25519Identity()
+	bob, _ := age.GenerateX25519Identity()
+	recipients, err := ParseRecipients(alice.Recipient().String() + "\n\n" + bob.Recipient().String())
+	if err != nil {
+		t.Fatalf("ParseRecipients() エラー = %v", err)
+	}
+	armored, err := Armor([]byte("secret"), recipients)
+	if err != nil {
+		t.Fatalf("Armor() エラー = %v", err)
+	}
+	for _, id := range []*age.X25519Identity{alice, bob} {
+		if got, err := Decrypt(armored, []age.Identity{id}); err != nil || string(got) != "secret" {
+			t.Errorf("Decrypt() = %q, %v", got, err)
+		}
+	}
+}
+
+func TestDecrypt_WrongIdentity(t *testing.T) {
+	alice, _ := age.GenerateX25519Identity()
+	mallory, _ := age.GenerateX25519Identity()
+	armored, err := Armor([]byte("secret"), []age.Recipient{alice.Recipient()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Decrypt(armored, []age.Identity{mallory}); err == nil {
+		t.Error("Decrypt() 別の秘密鍵でエラーが返されませんでした")
+	}
+	if _, err := Decrypt([]byte("not age"), []age.Identity{alice}); err == nil {
+		t.Error("Decrypt() 無効な暗号文でエラーが返されませんでした")
+	}
+}
+
+func TestParseRecipients_Invalid(t *testing.T) {
+	rsaKey := "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQ user@host"
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{name: "不明な形式", value: "hello"},
+		{name: "壊れたage公開鍵", value: "age1invalid"},
+		{name: "RSA公開鍵", value: rsaKey},
+		{name: "壊れたSSH公開鍵", value: "ssh-ed25519 AAAA"},
+		{name: "受信者が多すぎる", value: strings.Repeat(mustRecipient(t)+"\n", MaxRecipients+1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseRecipients(tt.value); err == nil {
+				t.Errorf("ParseRecipients(%q) エラーが返されませんでした", tt.value)
+			}
+		})
+	}
+
+	if recipients, err := ParseRecipients("", "# comment"); err != nil || len(recipients) != 0 {
+		t.Errorf("ParseRecipients() 空の一覧 = %v, %v", recipients, err)
+	}
+	if _, err := Armor([]byte("secret"), nil); err == nil {
+		t.Error("Armor() 受信者なしでエラーが返されませんでした")
+	}
+}
+
+func TestParseIdentities_Invalid(t *testing.T) {
+	_, encrypted := sshKeyPair(t, "passphrase")
+	for _, data := range [][]byte{nil, []byte("AGE-SECRET-KEY-1INVALID"), encrypted} {
+		if _, err := ParseIdentities(data); err == nil {
+			t.Errorf("ParseIdentities(%q) エラーが返されませんでした", data)
+		}
+	}
+}
+
+func mustRecipient(t *testing.T) string {
+	t.Helper()
+	id, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id.Recipient().String()
+}
@@ -0,0 +1,61 @@
+package format
+
+import (
+	"archive/tar"
+	"bytes"
+	"strings"
+	"time"
+)
+
+// docker-composeのsecretsで参照するファイルを置くディレクトリ
+const dockerSecretsDir = "secrets"
+
+// docker-composeのsecretsセクションを含むファイル名
+const dockerComposeFile = "docker-compose.secrets.yml"
+
+// シークレットごとのファイルとcompose定義をtarアーカイブにまとめる
+//
+// 各ファイルには値だけを改行なしで書き込む（/run/secrets/<key>と同じ内容）。
+func DockerSecrets(secrets []Secret) ([]byte, error) {
+	if err := validateKeys(secrets, validSecretKey, "Docker secret"); err != nil {
+		return nil, err
+	}
+
+	var compose strings.Builder
+	compose.WriteString("secrets:\n")
+	for _, s := range secrets {
+		compose.WriteString(`  "` + s.Key + `":` + "\n")
+		compose.WriteString(`    file: "./` + dockerSecretsDir + "/" + s.Key + `"` + "\n")
+	}
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	now := time.Now().UTC()
+
+	writeFile := func(name string, content []byte) error {
+		hdr := &tar.Header{
+			Name:    name,
+			Mode:    0o600,
+			Size:    int64(len(content)),
+			ModTime: now,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		_, err := tw.Write(content)
+		return err
+	}
+
+	if err := writeFile(dockerComposeFile, []byte(compose.String())); err != nil {
+		return nil, err
+	}
+	for _, s := range secrets {
+		if err := writeFile(dockerSecretsDir+"/"+s.Key, []byte(s.Value)); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,61 @@
+package format
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestDockerSecrets(t *testing.T) {
+	secrets := []Secret{{Key: "db_password", Value: "one"}, {Key: "api.key", Value: "two"}}
+	archive, err := DockerSecrets(secrets)
+	if err != nil {
+		t.Fatalf("DockerSecrets() エラー = %v", err)
+	}
+
+	files := make(map[string]string)
+	tr := tar.NewReader(bytes.NewReader(archive))
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("tarの読み込みに失敗: %v", err)
+		}
+		if hdr.Mode != 0o600 {
+			t.Errorf("%s のパーミッション = %o, want 600", hdr.Name, hdr.Mode)
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatalf("tarの読み込みに失敗: %v", err)
+		}
+		files[hdr.Name] = string(content)
+	}
+
+	want := map[string]string{
+		dockerComposeFile: "secrets:\n" +
+			"  \"db_password\":\n    file: \"./secrets/db_password\"\n" +
+			"  \"api.key\":\n    file: \"./secrets/api.key\"\n",
+		"secrets/db_password": "one",
+		"secrets/api.key":     "two",
+	}
+	if len(files) != len(want) {
+		t.Fatalf("ファイル数 = %d, want %d", len(files), len(want))
+	}
+	for name, content := range want {
+		if files[name] != content {
+			t.Errorf("%s = %q, want %q", name, files[name], content)
+		}
+	}
+}
+
+func TestDockerSecrets_InvalidKey(t *testing.T) {
+	for _, key := range []string{"../escape", ".", ".."} {
+		if _, err := DockerSecrets([]Secret{{Key: key, Value: "x"}}); err == nil {
+			t.Errorf("DockerSecrets(%q) エラーが返されませんでした", key)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package format
+
+import (
+	"strings"
+)
+
+// シークレットを.envファイルとしてレンダリング
+//
+// 値はPOSIXシェルでそのままsourceできるよう単一引用符で囲む。
+func DotEnv(secrets []Secret) (string, error) {
+	if err := validateKeys(secrets, envKeyPattern.MatchString, ".env"); err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	for _, s := range secrets {
+		sb.WriteString(s.Key + "=" + shellQuote(s.Value) + "\n")
+	}
+	return sb.String(), nil
+}
+
+// POSIXシェルの単一引用符で文字列をクォート
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
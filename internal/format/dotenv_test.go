@@ -0,0 +1,48 @@
+package format
+
+import (
+	"os/exec"
+	"testing"
+)
+
+func TestDotEnv(t *testing.T) {
+	secrets := []Secret{
+		{Key: "SIMPLE", Value: "abc"},
+		{Key: "QUOTED", Value: `it's $HOME \n "x"`},
+	}
+	got, err := DotEnv(secrets)
+	if err != nil {
+		t.Fatalf("DotEnv() エラー = %v", err)
+	}
+	want := "SIMPLE='abc'\n" + `QUOTED='it'\''s $HOME \n "x"'` + "\n"
+	if got != want {
+		t.Errorf("DotEnv() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestDotEnv_ShellRoundTrip(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("shが見つかりません")
+	}
+	value := "a'b\"c$d`e\\f!g h"
+	env, err := DotEnv([]Secret{{Key: "VALUE", Value: value}})
+	if err != nil {
+		t.Fatalf("DotEnv() エラー = %v", err)
+	}
+	out, err := exec.Command(sh, "-c", env+`printf '%s' "$VALUE"`).Output()
+	if err != nil {
+		t.Fatalf("シェルの実行に失敗: %v", err)
+	}
+	if string(out) != value {
+		t.Errorf("シェルで読み込んだ値 = %q, want %q", out, value)
+	}
+}
+
+func TestDotEnv_InvalidKey(t *testing.T) {
+	for _, key := range []string{"1ABC", "A-B", "A B", ""} {
+		if _, err := DotEnv([]Secret{{Key: key, Value: "x"}}); err == nil {
+			t.Errorf("DotEnv(%q) エラーが返されませんでした", key)
+		}
+	}
+}
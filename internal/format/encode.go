@@ -0,0 +1,281 @@
+package format
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/okamyuji/PasswordGenerator/internal/config"
+)
+
+// 生成結果の出力フォーマット
+type Format string
+
+const (
+	FormatText          Format = "text"
+	FormatHtpasswd      Format = "htpasswd"
+	FormatLDIF          Format = "ldif"
+	FormatKubernetes    Format = "kubernetes"
+	FormatDotEnv        Format = "env"
+	FormatDockerSecrets Format = "docker-secrets"
+	FormatSealed        Format = "sealed"
+)
+
+// 一度に生成できるシークレット数の上限
+const MaxSecretCount = 100
+
+// htpasswdとLDIFで一度に生成できる認証情報数の上限
+//
+// ユーザーごとにbcryptやargon2id（64MiB）の低速なハッシュを計算するため、
+// 1回の生成がサーバーの書き込みタイムアウト（10秒）に収まり、匿名のリクエストで
+// CPUとメモリを占有できない数に抑える。
+const MaxCredentialCount = 10
+
+// Acceptヘッダーに一致するフォーマットが無い場合のエラー
+var ErrNotAcceptable = errors.New("対応していない出力フォーマットです")
+
+// フォーマットごとのContent-Type
+var contentTypes = map[Format]string{
+	FormatText:          "text/plain",
+	FormatHtpasswd:      "text/plain",
+	FormatLDIF:          "text/x-ldif",
+	FormatKubernetes:    "application/yaml",
+	FormatDotEnv:        "text/x-env",
+	FormatDockerSecrets: "application/x-tar",
+	FormatSealed:        "application/vnd.passwordgenerator.sealed+json",
+}
+
+// Acceptヘッダーで選択できるフォーマット
+//
+// htpasswdとLDIFはハッシュのみを含み平文の受け渡しが別途必要なため、
+// format パラメータで明示した場合にのみ選択する。
+var negotiableFormats = []Format{
+	FormatText,
+	FormatKubernetes,
+	FormatDotEnv,
+	FormatDockerSecrets,
+	FormatSealed,
+}
+
+// ASCII以外の文字を含むパスワードを扱えないことが分かっているフォーマットと、その理由
+var asciiOnlyFormats = map[Format]string{
+	FormatHtpasswd: "HTTP Basic認証ではクライアントによってASCII以外の文字のエンコードが異なるため、htpasswdのパスワードにはASCIIの文字種を推奨します",
+}
+
+// 設定と出力フォーマットの組み合わせに対する警告（問題がなければ空文字）
+func Warning(cfg config.PasswordConfig, f Format) string {
+	if w := cfg.ASCIIWarning(); w != "" {
+		return w
+	}
+	if reason, ok := asciiOnlyFormats[f]; ok && cfg.NonASCII() {
+		return reason
+	}
+	return ""
+}
+
+// エンコードのオプション
+type Options struct {
+	Count             int      // 生成するシークレット数（Usernames指定時はその数）
+	KeyTemplate       string   // キー名テンプレート（{index} / {username}）
+	Usernames         []string // htpasswd・LDIFでは必須
+	HtpasswdAlgorithm HtpasswdAlgorithm
+	LDIF              LDIFOptions
+	SecretName        string // KubernetesのSecret名
+	Namespace         string // KubernetesのNamespace
+	SealKey           []byte // 封印ドキュメントの32バイト鍵
+}
+
+// エンコード結果
+type Output struct {
+	ContentType string
+	Body        []byte
+	// htpasswdやLDIFのようにハッシュのみを出力する形式で、利用者に渡す平文の認証情報
+	Credentials []Credential
+	// ASCIIのみを前提とする用途にASCII以外の文字を選択した場合の警告
+	Warning string
+}
+
+// 本文をゼロ埋めする（レスポンスやファイルへの書き込み後に呼び出す）
+func (o *Output) Destroy() {
+	clear(o.Body)
+}
+
+// フォーマット名を解析
+func ParseFormat(name string) (Format, error) {
+	f := Format(strings.ToLower(strings.TrimSpace(name)))
+	if _, ok := contentTypes[f]; !ok {
+		return "", fmt.Errorf("未対応の出力フォーマット: %s", name)
+	}
+	return f, nil
+}
+
+// formatパラメータまたはAcceptヘッダーから出力フォーマットを決定
+//
+// formatパラメータが優先され、どちらも無い場合はテキストになる。
+func Negotiate(formatParam, accept string) (Format, error) {
+	if formatParam != "" {
+		return ParseFormat(formatParam)
+	}
+	if strings.TrimSpace(accept) == "" {
+		return FormatText, nil
+	}
+
+	type candidate struct {
+		mediaType string
+		q         float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				q = parsed
+			}
+		}
+		if q > 0 {
+			candidates = append(candidates, candidate{mediaType: mediaType, q: q})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+
+	for _, c := range candidates {
+		if c.mediaType == "*/*" || c.mediaType == "text/*" {
+			return FormatText, nil
+		}
+		for _, f := range negotiableFormats {
+			if contentTypes[f] == c.mediaType {
+				return f, nil
+			}
+		}
+	}
+	return "", ErrNotAcceptable
+}
+
+// パスワードを生成して指定されたフォーマットでエンコード
+func Encode(g PasswordGenerator, cfg config.PasswordConfig, f Format, opts Options) (*Output, error) {
+	out, err := encode(g, cfg, f, opts)
+	if err != nil {
+		return nil, err
+	}
+	out.Warning = Warning(cfg, f)
+	return out, nil
+}
+
+func encode(g PasswordGenerator, cfg config.PasswordConfig, f Format, opts Options) (*Output, error) {
+	count := opts.Count
+	if len(opts.Usernames) > 0 {
+		count = len(opts.Usernames)
+	}
+	if count <= 0 {
+		count = 1
+	}
+	if count > MaxSecretCount {
+		return nil, fmt.Errorf("シークレット数が最大値を超えています: %d (最大: %d)", count, MaxSecretCount)
+	}
+
+	switch f {
+	case FormatHtpasswd, FormatLDIF:
+		if count > MaxCredentialCount {
+			return nil, fmt.Errorf("%sのユーザー数が最大値を超えています: %d (最大: %d)", f, count, MaxCredentialCount)
+		}
+		return encodeCredentials(g, cfg, f, opts)
+	case FormatText:
+		// 文字列を経由せず、生成したバッファから直接本文を組み立てる
+		body := make([]byte, 0, count*(cfg.Length+1))
+		for i := range count {
+			password, err := g.Generate(cfg)
+			if err != nil {
+				clear(body)
+				return nil, err
+			}
+			if i > 0 {
+				body = append(body, '\n')
+			}
+			body = append(body, password.Bytes()...)
+			password.Destroy()
+		}
+		return &Output{ContentType: contentTypes[f], Body: body}, nil
+	}
+
+	keys, err := KeyNames(opts.KeyTemplate, count, opts.Usernames)
+	if err != nil {
+		return nil, err
+	}
+	secrets := make([]Secret, count)
+	for i, key := range keys {
+		value, err := g.Generate(cfg)
+		if err != nil {
+			return nil, err
+		}
+		secrets[i] = Secret{Key: key, Value: value.Reveal()}
+		value.Destroy()
+	}
+	return EncodeSecrets(secrets, f, opts)
+}
+
+// 生成済みのシークレットを指定されたフォーマットでエンコード
+func EncodeSecrets(secrets []Secret, f Format, opts Options) (*Output, error) {
+	var body []byte
+	switch f {
+	case FormatKubernetes:
+		s, err := KubernetesSecret(opts.SecretName, opts.Namespace, secrets)
+		if err != nil {
+			return nil, err
+		}
+		body = []byte(s)
+	case FormatDotEnv:
+		s, err := DotEnv(secrets)
+		if err != nil {
+			return nil, err
+		}
+		body = []byte(s)
+	case FormatDockerSecrets:
+		b, err := DockerSecrets(secrets)
+		if err != nil {
+			return nil, err
+		}
+		body = b
+	case FormatSealed:
+		b, err := Seal(opts.SealKey, secrets)
+		if err != nil {
+			return nil, err
+		}
+		body = b
+	default:
+		return nil, fmt.Errorf("シークレットをエンコードできないフォーマットです: %s", f)
+	}
+	return &Output{ContentType: contentTypes[f], Body: body}, nil
+}
+
+func encodeCredentials(g PasswordGenerator, cfg config.PasswordConfig, f Format, opts Options) (*Output, error) {
+	creds, err := GenerateCredentials(g, cfg, opts.Usernames)
+	if err != nil {
+		return nil, err
+	}
+
+	var body string
+	if f == FormatHtpasswd {
+		alg := opts.HtpasswdAlgorithm
+		if alg == "" {
+			alg = HtpasswdBcrypt
+		}
+		body, err = HtpasswdFile(creds, alg)
+	} else {
+		ldifOpts := opts.LDIF
+		if ldifOpts.Scheme == "" {
+			ldifOpts.Scheme = LDAPSSHA
+		}
+		body, err = LDIFFile(creds, ldifOpts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Output{ContentType: contentTypes[f], Body: []byte(body), Credentials: creds}, nil
+}
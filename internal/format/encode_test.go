@@ -0,0 +1,168 @@
+package format
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/okamyuji/PasswordGenerator/internal/config"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name    string
+		param   string
+		accept  string
+		want    Format
+		wantErr error
+	}{
+		{name: "指定なし", want: FormatText},
+		{name: "formatパラメータ", param: "kubernetes", accept: "text/plain", want: FormatKubernetes},
+		{name: "formatパラメータ - 大文字", param: "LDIF", want: FormatLDIF},
+		{name: "Accept - YAML", accept: "application/yaml", want: FormatKubernetes},
+		{name: "Accept - 品質値", accept: "text/x-env;q=0.5, application/x-tar", want: FormatDockerSecrets},
+		{name: "Accept - ワイルドカード", accept: "application/json, */*;q=0.1", want: FormatText},
+		{name: "Accept - q=0は除外", accept: "application/yaml;q=0, text/plain", want: FormatText},
+		{name: "Accept - 一致なし", accept: "application/json", wantErr: ErrNotAcceptable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Negotiate(tt.param, tt.accept)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Negotiate() エラー = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Negotiate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := Negotiate("xml", ""); err == nil {
+		t.Error("Negotiate() 未対応のフォーマットでエラーが返されませんでした")
+	}
+}
+
+func TestEncode(t *testing.T) {
+	cfg := config.PasswordConfig{Length: 12}
+
+	tests := []struct {
+		name     string
+		format   Format
+		opts     Options
+		wantErr  bool
+		validate func(out *Output) bool
+	}{
+		{
+			name:   "テキスト - 複数",
+			format: FormatText,
+			opts:   Options{Count: 2},
+			validate: func(out *Output) bool {
+				return string(out.Body) == "password-1\npassword-2"
+			},
+		},
+		{
+			name:   ".env - テンプレート",
+			format: FormatDotEnv,
+			opts:   Options{Count: 2, KeyTemplate: "SECRET_{index}"},
+			validate: func(out *Output) bool {
+				return string(out.Body) == "SECRET_1='password-1'\nSECRET_2='password-2'\n"
+			},
+		},
+		{
+			name:   "htpasswd - 平文の認証情報を含む",
+			format: FormatHtpasswd,
+			opts:   Options{Usernames: []string{"alice"}, HtpasswdAlgorithm: HtpasswdSHA},
+			validate: func(out *Output) bool {
+				return strings.HasPrefix(string(out.Body), "alice:{SHA}") &&
+					len(out.Credentials) == 1 && out.Credentials[0].Password == "password-1"
+			},
+		},
+		{
+			name:    "htpasswd - ユーザー名なし",
+			format:  FormatHtpasswd,
+			wantErr: true,
+		},
+		{
+			name:    "封印 - 鍵なし",
+			format:  FormatSealed,
+			wantErr: true,
+		},
+		{
+			name:    "件数の上限超過",
+			format:  FormatText,
+			opts:    Options{Count: MaxSecretCount + 1},
+			wantErr: true,
+		},
+		{
+			name:   "htpasswd - ユーザー数の上限",
+			format: FormatHtpasswd,
+			opts:   Options{Usernames: usernames(MaxCredentialCount), HtpasswdAlgorithm: HtpasswdSHA},
+			validate: func(out *Output) bool {
+				return len(out.Credentials) == MaxCredentialCount
+			},
+		},
+		{
+			name:    "htpasswd - ユーザー数の上限超過",
+			format:  FormatHtpasswd,
+			opts:    Options{Usernames: usernames(MaxCredentialCount + 1), HtpasswdAlgorithm: HtpasswdSHA},
+			wantErr: true,
+		},
+		{
+			name:    "LDIF - ユーザー数の上限超過",
+			format:  FormatLDIF,
+			opts:    Options{Usernames: usernames(MaxCredentialCount + 1), LDIF: LDIFOptions{BaseDN: "dc=example,dc=com"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := Encode(&sequenceGenerator{}, cfg, tt.format, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Encode() エラー = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if out.ContentType != contentTypes[tt.format] {
+				t.Errorf("Encode() ContentType = %v, want %v", out.ContentType, contentTypes[tt.format])
+			}
+			if !tt.validate(out) {
+				t.Errorf("Encode() = %s, 検証失敗", out.Body)
+			}
+		})
+	}
+}
+
+// n人分のユーザー名
+func usernames(n int) []string {
+	names := make([]string, n)
+	for i := range names {
+		names[i] = fmt.Sprintf("user%d", i+1)
+	}
+	return names
+}
+
+func TestWarning(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         config.PasswordConfig
+		format      Format
+		wantWarning bool
+	}{
+		{name: "ASCIIのみのhtpasswd", cfg: config.PasswordConfig{UseLowercase: true}, format: FormatHtpasswd},
+		{name: "かなのhtpasswd", cfg: config.PasswordConfig{UseHiragana: true}, format: FormatHtpasswd, wantWarning: true},
+		{name: "かなの.env", cfg: config.PasswordConfig{UseHiragana: true}, format: FormatDotEnv},
+		{name: "かなとURLの記号プロファイル", cfg: config.PasswordConfig{UseKatakana: true, SymbolProfile: config.SymbolProfileURL}, format: FormatText, wantWarning: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Warning(tt.cfg, tt.format); (got != "") != tt.wantWarning {
+				t.Errorf("Warning() = %q, wantWarning %v", got, tt.wantWarning)
+			}
+		})
+	}
+}
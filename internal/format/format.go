@@ -0,0 +1,74 @@
+package format
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/okamyuji/PasswordGenerator/internal/config"
+	"github.com/okamyuji/PasswordGenerator/internal/secret"
+)
+
+// 出力エンコーダーが使用するパスワード生成のコントラクト
+type PasswordGenerator interface {
+	Generate(cfg config.PasswordConfig) (*secret.Secret, error)
+}
+
+// ユーザー名と生成されたパスワードの組
+type Credential struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// ユーザー名ごとにパスワードを生成してCredentialの一覧を作成
+func GenerateCredentials(g PasswordGenerator, cfg config.PasswordConfig, usernames []string) ([]Credential, error) {
+	if len(usernames) == 0 {
+		return nil, fmt.Errorf("ユーザー名が指定されていません")
+	}
+
+	seen := make(map[string]bool, len(usernames))
+	creds := make([]Credential, 0, len(usernames))
+	for _, username := range usernames {
+		if username == "" {
+			return nil, fmt.Errorf("空のユーザー名は使用できません")
+		}
+		if seen[username] {
+			return nil, fmt.Errorf("ユーザー名が重複しています: %s", username)
+		}
+		seen[username] = true
+
+		password, err := g.Generate(cfg)
+		if err != nil {
+			return nil, err
+		}
+		// ハッシュ化と平文の認証情報の返却に文字列が必要なため、ここで取り出して破棄する
+		creds = append(creds, Credential{Username: username, Password: password.Reveal()})
+		password.Destroy()
+	}
+	return creds, nil
+}
+
+// crypt(3)系のハッシュで使用する64文字のアルファベット
+const cryptAlphabet = "./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// crypt(3)形式のソルト文字列を生成
+func cryptSalt(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	for i := range b {
+		// 64は256の約数なので剰余による偏りは生じない
+		b[i] = cryptAlphabet[int(b[i])%len(cryptAlphabet)]
+	}
+	return string(b), nil
+}
+
+// 3バイトをcrypt(3)形式の64進数でn文字にエンコード
+func cryptEncode24(dst []byte, b2, b1, b0 byte, n int) []byte {
+	v := uint(b2)<<16 | uint(b1)<<8 | uint(b0)
+	for i := 0; i < n; i++ {
+		dst = append(dst, cryptAlphabet[v&0x3f])
+		v >>= 6
+	}
+	return dst
+}
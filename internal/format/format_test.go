@@ -0,0 +1,52 @@
+package format
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/okamyuji/PasswordGenerator/internal/config"
+	"github.com/okamyuji/PasswordGenerator/internal/secret"
+)
+
+// 呼び出しごとに連番のパスワードを返すモック
+type sequenceGenerator struct {
+	n int
+}
+
+func (g *sequenceGenerator) Generate(cfg config.PasswordConfig) (*secret.Secret, error) {
+	g.n++
+	return secret.FromString(fmt.Sprintf("password-%d", g.n)), nil
+}
+
+func TestGenerateCredentials(t *testing.T) {
+	tests := []struct {
+		name      string
+		usernames []string
+		wantErr   bool
+	}{
+		{name: "複数ユーザー", usernames: []string{"alice", "bob", "carol"}},
+		{name: "ユーザーなし", usernames: nil, wantErr: true},
+		{name: "空のユーザー名", usernames: []string{"alice", ""}, wantErr: true},
+		{name: "重複したユーザー名", usernames: []string{"alice", "alice"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GenerateCredentials(&sequenceGenerator{}, config.PasswordConfig{Length: 12}, tt.usernames)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GenerateCredentials() エラー = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != len(tt.usernames) {
+				t.Fatalf("GenerateCredentials() 件数 = %d, want %d", len(got), len(tt.usernames))
+			}
+			for i, cred := range got {
+				if cred.Username != tt.usernames[i] || cred.Password != fmt.Sprintf("password-%d", i+1) {
+					t.Errorf("GenerateCredentials()[%d] = %+v", i, cred)
+				}
+			}
+		})
+	}
+}
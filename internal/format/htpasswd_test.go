@@ -0,0 +1,104 @@
+package format
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestApr1Crypt(t *testing.T) {
+	// openssl passwd -apr1 -salt abcdefgh 'P@ssw0rd!' の出力
+	want := "$apr1$abcdefgh$T9Ta7XuM2Yltpi/4uviFw0"
+	if got := apr1Crypt("P@ssw0rd!", "abcdefgh"); got != want {
+		t.Errorf("apr1Crypt() = %v, want %v", got, want)
+	}
+}
+
+func TestHtpasswdLine(t *testing.T) {
+	cred := Credential{Username: "alice", Password: "P@ssw0rd!"}
+
+	tests := []struct {
+		name     string
+		alg      HtpasswdAlgorithm
+		wantErr  bool
+		validate func(hash string) bool
+	}{
+		{
+			name: "bcrypt",
+			alg:  HtpasswdBcrypt,
+			validate: func(hash string) bool {
+				return strings.HasPrefix(hash, "$2y$") &&
+					bcrypt.CompareHashAndPassword([]byte(hash), []byte(cred.Password)) == nil
+			},
+		},
+		{
+			name: "APR1",
+			alg:  HtpasswdAPR1,
+			validate: func(hash string) bool {
+				parts := strings.Split(hash, "$")
+				return len(parts) == 4 && apr1Crypt(cred.Password, parts[2]) == hash
+			},
+		},
+		{
+			name: "SHA",
+			alg:  HtpasswdSHA,
+			validate: func(hash string) bool {
+				sum := sha1.Sum([]byte(cred.Password))
+				return hash == "{SHA}"+base64.StdEncoding.EncodeToString(sum[:])
+			},
+		},
+		{
+			name:    "未対応のアルゴリズム",
+			alg:     "md4",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := HtpasswdLine(cred, tt.alg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("HtpasswdLine() エラー = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			hash, ok := strings.CutPrefix(got, "alice:")
+			if !ok {
+				t.Fatalf("HtpasswdLine() = %v, ユーザー名のプレフィックスがありません", got)
+			}
+			if !tt.validate(hash) {
+				t.Errorf("HtpasswdLine() = %v, 検証失敗", got)
+			}
+		})
+	}
+}
+
+func TestHtpasswdLine_InvalidUsername(t *testing.T) {
+	for _, username := range []string{"", "a:b", "a\nb"} {
+		if _, err := HtpasswdLine(Credential{Username: username, Password: "x"}, HtpasswdSHA); err == nil {
+			t.Errorf("HtpasswdLine(%q) エラーが返されませんでした", username)
+		}
+	}
+}
+
+func TestHtpasswdFile(t *testing.T) {
+	creds := []Credential{
+		{Username: "alice", Password: "one"},
+		{Username: "bob", Password: "two"},
+	}
+	got, err := HtpasswdFile(creds, HtpasswdSHA)
+	if err != nil {
+		t.Fatalf("HtpasswdFile() エラー = %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("HtpasswdFile() 行数 = %d, want 2", len(lines))
+	}
+	if !strings.HasPrefix(lines[0], "alice:{SHA}") || !strings.HasPrefix(lines[1], "bob:{SHA}") {
+		t.Errorf("HtpasswdFile() = %v", got)
+	}
+}
@@ -0,0 +1,46 @@
+package format
+
+import (
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Kubernetesのオブジェクト名（DNS-1123サブドメイン）
+var kubernetesNamePattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+
+// Secretマニフェストの名前を省略した場合の既定値
+const defaultKubernetesSecretName = "generated-secrets"
+
+// シークレットをKubernetesのOpaque Secret（base64のdata）としてレンダリング
+func KubernetesSecret(name, namespace string, secrets []Secret) (string, error) {
+	if name == "" {
+		name = defaultKubernetesSecretName
+	}
+	if len(name) > 253 || !kubernetesNamePattern.MatchString(name) {
+		return "", fmt.Errorf("無効なSecret名: %q", name)
+	}
+	if namespace != "" && (len(namespace) > 63 || !kubernetesNamePattern.MatchString(namespace) || strings.Contains(namespace, ".")) {
+		return "", fmt.Errorf("無効なnamespace: %q", namespace)
+	}
+	if err := validateKeys(secrets, validSecretKey, "Kubernetes Secret"); err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("apiVersion: v1\n")
+	sb.WriteString("kind: Secret\n")
+	sb.WriteString("metadata:\n")
+	sb.WriteString("  name: " + name + "\n")
+	if namespace != "" {
+		sb.WriteString("  namespace: " + namespace + "\n")
+	}
+	sb.WriteString("type: Opaque\n")
+	sb.WriteString("data:\n")
+	for _, s := range secrets {
+		// キー名は数値や真偽値として解釈されないよう常に引用符で囲む
+		sb.WriteString(`  "` + s.Key + `": ` + base64.StdEncoding.EncodeToString([]byte(s.Value)) + "\n")
+	}
+	return sb.String(), nil
+}
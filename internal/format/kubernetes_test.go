@@ -0,0 +1,60 @@
+package format
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestKubernetesSecret(t *testing.T) {
+	secrets := []Secret{{Key: "db-password", Value: "p@ss'word"}, {Key: "API_KEY", Value: "xyz"}}
+	got, err := KubernetesSecret("app", "prod", secrets)
+	if err != nil {
+		t.Fatalf("KubernetesSecret() エラー = %v", err)
+	}
+
+	want := "apiVersion: v1\n" +
+		"kind: Secret\n" +
+		"metadata:\n" +
+		"  name: app\n" +
+		"  namespace: prod\n" +
+		"type: Opaque\n" +
+		"data:\n" +
+		`  "db-password": ` + base64.StdEncoding.EncodeToString([]byte("p@ss'word")) + "\n" +
+		`  "API_KEY": ` + base64.StdEncoding.EncodeToString([]byte("xyz")) + "\n"
+	if got != want {
+		t.Errorf("KubernetesSecret() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestKubernetesSecret_Invalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		secret    string
+		namespace string
+		secrets   []Secret
+	}{
+		{name: "大文字のSecret名", secret: "App", secrets: []Secret{{Key: "a", Value: "x"}}},
+		{name: "ドットを含むnamespace", secret: "app", namespace: "a.b", secrets: []Secret{{Key: "a", Value: "x"}}},
+		{name: "不正なキー名", secret: "app", secrets: []Secret{{Key: "a b", Value: "x"}}},
+		{name: "シークレットなし", secret: "app"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := KubernetesSecret(tt.secret, tt.namespace, tt.secrets); err == nil {
+				t.Error("KubernetesSecret() エラーが返されませんでした")
+			}
+		})
+	}
+}
+
+func TestKubernetesSecret_DefaultName(t *testing.T) {
+	got, err := KubernetesSecret("", "", []Secret{{Key: "a", Value: "x"}})
+	if err != nil {
+		t.Fatalf("KubernetesSecret() エラー = %v", err)
+	}
+	if !strings.Contains(got, "  name: "+defaultKubernetesSecretName+"\n") || strings.Contains(got, "namespace:") {
+		t.Errorf("KubernetesSecret() =\n%s", got)
+	}
+}
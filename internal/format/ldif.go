@@ -0,0 +1,267 @@
+package format
+
+import (
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// LDAPのuserPassword属性で使用するスキーム
+type LDAPScheme string
+
+const (
+	LDAPSSHA   LDAPScheme = "SSHA"
+	LDAPCrypt  LDAPScheme = "CRYPT"
+	LDAPArgon2 LDAPScheme = "ARGON2"
+)
+
+// RFC 9106で推奨されるメモリ制約環境向けのargon2idパラメータ
+const (
+	argon2Time    = 3
+	argon2Memory  = 64 * 1024
+	argon2Threads = 4
+	argon2SaltLen = 16
+	argon2KeyLen  = 32
+)
+
+// SHA-512 crypt（$6$）のデフォルトラウンド数（rounds=を省略した場合の値）
+const sha512CryptRounds = 5000
+
+// LDIFの出力オプション
+type LDIFOptions struct {
+	BaseDN       string     // 例: ou=people,dc=example,dc=com
+	RDNAttribute string     // 省略時は uid
+	Scheme       LDAPScheme // userPasswordのハッシュスキーム
+}
+
+// パスワードをuserPassword属性の値としてハッシュ化
+func UserPassword(password string, scheme LDAPScheme) (string, error) {
+	switch scheme {
+	case LDAPSSHA:
+		salt := make([]byte, 8)
+		if _, err := rand.Read(salt); err != nil {
+			return "", err
+		}
+		return sshaHash(password, salt), nil
+	case LDAPCrypt:
+		salt, err := cryptSalt(16)
+		if err != nil {
+			return "", err
+		}
+		return "{CRYPT}" + sha512Crypt(password, salt), nil
+	case LDAPArgon2:
+		salt := make([]byte, argon2SaltLen)
+		if _, err := rand.Read(salt); err != nil {
+			return "", err
+		}
+		return "{ARGON2}" + argon2idEncoded(password, salt), nil
+	default:
+		return "", fmt.Errorf("未対応のLDAPパスワードスキーム: %s", scheme)
+	}
+}
+
+// 1件の認証情報のuserPasswordを置き換えるLDIFエントリをレンダリング
+func LDIFEntry(cred Credential, opts LDIFOptions) (string, error) {
+	if cred.Username == "" {
+		return "", fmt.Errorf("空のユーザー名は使用できません")
+	}
+	if opts.BaseDN == "" {
+		return "", fmt.Errorf("ベースDNが指定されていません")
+	}
+	attr := opts.RDNAttribute
+	if attr == "" {
+		attr = "uid"
+	}
+
+	value, err := UserPassword(cred.Password, opts.Scheme)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	writeLDIFLine(&sb, "dn", attr+"="+escapeDNValue(cred.Username)+","+opts.BaseDN)
+	sb.WriteString("changetype: modify\n")
+	sb.WriteString("replace: userPassword\n")
+	writeLDIFLine(&sb, "userPassword", value)
+	sb.WriteString("-\n")
+	return sb.String(), nil
+}
+
+// 認証情報の一覧から完全なLDIFをレンダリング
+func LDIFFile(creds []Credential, opts LDIFOptions) (string, error) {
+	var sb strings.Builder
+	sb.WriteString("version: 1\n")
+	for _, cred := range creds {
+		entry, err := LDIFEntry(cred, opts)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteByte('\n')
+		sb.WriteString(entry)
+	}
+	return sb.String(), nil
+}
+
+// LDIFの安全な文字列でなければbase64（::）で属性を出力
+func writeLDIFLine(sb *strings.Builder, attr, value string) {
+	if isLDIFSafeString(value) {
+		sb.WriteString(attr + ": " + value + "\n")
+		return
+	}
+	sb.WriteString(attr + ":: " + base64.StdEncoding.EncodeToString([]byte(value)) + "\n")
+}
+
+// RFC 2849のSAFE-STRINGに該当するか判定
+func isLDIFSafeString(s string) bool {
+	if s == "" {
+		return true
+	}
+	switch s[0] {
+	case ' ', ':', '<':
+		return false
+	}
+	if s[len(s)-1] == ' ' {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == 0 || c == '\n' || c == '\r' || c > 0x7f {
+			return false
+		}
+	}
+	return true
+}
+
+// RFC 4514に従ってRDNの属性値をエスケープ
+func escapeDNValue(s string) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case strings.IndexByte(`,+"\<>;`, c) >= 0:
+			sb.WriteByte('\\')
+			sb.WriteByte(c)
+		case c == 0:
+			sb.WriteString(`\00`)
+		case (c == ' ' || c == '#') && i == 0, c == ' ' && i == len(s)-1:
+			sb.WriteByte('\\')
+			sb.WriteByte(c)
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
+
+// OpenLDAPの{SSHA}形式（SHA-1とソルトの連結をbase64化）
+func sshaHash(password string, salt []byte) string {
+	h := sha1.New()
+	h.Write([]byte(password))
+	h.Write(salt)
+	sum := h.Sum(nil)
+	return "{SSHA}" + base64.StdEncoding.EncodeToString(append(sum, salt...))
+}
+
+// PHC文字列形式のargon2idハッシュ
+func argon2idEncoded(password string, salt []byte) string {
+	key := argon2.IDKey([]byte(password), salt, argon2Time, argon2Memory, argon2Threads, argon2KeyLen)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, argon2Memory, argon2Time, argon2Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key))
+}
+
+// glibc互換のSHA-512 crypt（$6$）を計算
+func sha512Crypt(password, salt string) string {
+	pw := []byte(password)
+	s := []byte(salt)
+	if len(s) > 16 {
+		s = s[:16]
+	}
+
+	alt := sha512.New()
+	alt.Write(pw)
+	alt.Write(s)
+	alt.Write(pw)
+	altSum := alt.Sum(nil)
+
+	a := sha512.New()
+	a.Write(pw)
+	a.Write(s)
+	i := len(pw)
+	for ; i > 64; i -= 64 {
+		a.Write(altSum)
+	}
+	a.Write(altSum[:i])
+	for i := len(pw); i > 0; i >>= 1 {
+		if i&1 != 0 {
+			a.Write(altSum)
+		} else {
+			a.Write(pw)
+		}
+	}
+	aSum := a.Sum(nil)
+
+	dp := sha512.New()
+	for range pw {
+		dp.Write(pw)
+	}
+	pSeq := repeatTo(dp.Sum(nil), len(pw))
+
+	ds := sha512.New()
+	for j := 0; j < 16+int(aSum[0]); j++ {
+		ds.Write(s)
+	}
+	sSeq := repeatTo(ds.Sum(nil), len(s))
+
+	c := aSum
+	for r := 0; r < sha512CryptRounds; r++ {
+		h := sha512.New()
+		if r&1 != 0 {
+			h.Write(pSeq)
+		} else {
+			h.Write(c)
+		}
+		if r%3 != 0 {
+			h.Write(sSeq)
+		}
+		if r%7 != 0 {
+			h.Write(pSeq)
+		}
+		if r&1 != 0 {
+			h.Write(c)
+		} else {
+			h.Write(pSeq)
+		}
+		c = h.Sum(nil)
+	}
+
+	order := [][3]int{
+		{0, 21, 42}, {22, 43, 1}, {44, 2, 23}, {3, 24, 45}, {25, 46, 4},
+		{47, 5, 26}, {6, 27, 48}, {28, 49, 7}, {50, 8, 29}, {9, 30, 51},
+		{31, 52, 10}, {53, 11, 32}, {12, 33, 54}, {34, 55, 13}, {56, 14, 35},
+		{15, 36, 57}, {37, 58, 16}, {59, 17, 38}, {18, 39, 60}, {40, 61, 19},
+		{62, 20, 41},
+	}
+	out := make([]byte, 0, 86)
+	for _, o := range order {
+		out = cryptEncode24(out, c[o[0]], c[o[1]], c[o[2]], 4)
+	}
+	out = cryptEncode24(out, 0, 0, c[63], 2)
+
+	return "$6$" + string(s) + "$" + string(out)
+}
+
+// bをnバイトになるまで繰り返す
+func repeatTo(b []byte, n int) []byte {
+	out := make([]byte, 0, n)
+	for len(out) < n {
+		out = append(out, b[:min(len(b), n-len(out))]...)
+	}
+	return out
+}
@@ -0,0 +1,123 @@
+package format
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/argon2"
+)
+
+func TestSHA512Crypt(t *testing.T) {
+	// openssl passwd -6 -salt saltsaltsalt 'P@ssw0rd!' の出力
+	want := "$6$saltsaltsalt$Gmi76GPiHsKWS3bZctVxJKAbmrHFxW1QrXKVNPcUN3HSahTrUe6xQAVBLwkR1okWUv.UjmMpy/RkZ.d62K8K6/"
+	if got := sha512Crypt("P@ssw0rd!", "saltsaltsalt"); got != want {
+		t.Errorf("sha512Crypt() = %v, want %v", got, want)
+	}
+}
+
+func TestUserPassword(t *testing.T) {
+	const password = "P@ssw0rd!"
+
+	tests := []struct {
+		name     string
+		scheme   LDAPScheme
+		wantErr  bool
+		validate func(value string) bool
+	}{
+		{
+			name:   "SSHA",
+			scheme: LDAPSSHA,
+			validate: func(value string) bool {
+				raw, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, "{SSHA}"))
+				if err != nil || len(raw) != 28 {
+					return false
+				}
+				return sshaHash(password, raw[20:]) == value
+			},
+		},
+		{
+			name:   "CRYPT",
+			scheme: LDAPCrypt,
+			validate: func(value string) bool {
+				parts := strings.Split(strings.TrimPrefix(value, "{CRYPT}"), "$")
+				return len(parts) == 4 && "{CRYPT}"+sha512Crypt(password, parts[2]) == value
+			},
+		},
+		{
+			name:   "ARGON2",
+			scheme: LDAPArgon2,
+			validate: func(value string) bool {
+				parts := strings.Split(strings.TrimPrefix(value, "{ARGON2}"), "$")
+				if len(parts) != 6 || parts[1] != "argon2id" {
+					return false
+				}
+				salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+				if err != nil {
+					return false
+				}
+				key := argon2.IDKey([]byte(password), salt, argon2Time, argon2Memory, argon2Threads, argon2KeyLen)
+				return parts[5] == base64.RawStdEncoding.EncodeToString(key)
+			},
+		},
+		{
+			name:    "未対応のスキーム",
+			scheme:  "MD5",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UserPassword(password, tt.scheme)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UserPassword() エラー = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !tt.validate(got) {
+				t.Errorf("UserPassword() = %v, 検証失敗", got)
+			}
+		})
+	}
+}
+
+func TestLDIFFile(t *testing.T) {
+	creds := []Credential{
+		{Username: "alice", Password: "one"},
+		{Username: "Smith, John", Password: "two"},
+	}
+	got, err := LDIFFile(creds, LDIFOptions{BaseDN: "ou=people,dc=example,dc=com", Scheme: LDAPSSHA})
+	if err != nil {
+		t.Fatalf("LDIFFile() エラー = %v", err)
+	}
+
+	wantContains := []string{
+		"version: 1\n",
+		"dn: uid=alice,ou=people,dc=example,dc=com\nchangetype: modify\nreplace: userPassword\nuserPassword: {SSHA}",
+		`dn: uid=Smith\, John,ou=people,dc=example,dc=com`,
+	}
+	for _, want := range wantContains {
+		if !strings.Contains(got, want) {
+			t.Errorf("LDIFFile() に %q が含まれていません:\n%s", want, got)
+		}
+	}
+	if strings.Count(got, "\n-\n") != 2 {
+		t.Errorf("LDIFFile() エントリ数が不正:\n%s", got)
+	}
+}
+
+func TestLDIFEntry_Base64DN(t *testing.T) {
+	got, err := LDIFEntry(Credential{Username: "山田", Password: "x"}, LDIFOptions{BaseDN: "dc=example", Scheme: LDAPSSHA})
+	if err != nil {
+		t.Fatalf("LDIFEntry() エラー = %v", err)
+	}
+	want := "dn:: " + base64.StdEncoding.EncodeToString([]byte("uid=山田,dc=example")) + "\n"
+	if !strings.HasPrefix(got, want) {
+		t.Errorf("LDIFEntry() = %v, want prefix %v", got, want)
+	}
+}
+
+func TestLDIFEntry_MissingBaseDN(t *testing.T) {
+	if _, err := LDIFEntry(Credential{Username: "alice", Password: "x"}, LDIFOptions{Scheme: LDAPSSHA}); err == nil {
+		t.Error("LDIFEntry() ベースDNなしでエラーが返されませんでした")
+	}
+}
@@ -0,0 +1,122 @@
+package format
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// 封印されたJSONドキュメントで使用する暗号アルゴリズム
+const sealedAlgorithm = "A256GCM"
+
+// AES-256-GCMで暗号化されたシークレットのJSONドキュメント
+type SealedDocument struct {
+	Version    int       `json:"version"`
+	Algorithm  string    `json:"algorithm"`
+	KeyID      string    `json:"keyId"`
+	Nonce      string    `json:"nonce"`
+	Ciphertext string    `json:"ciphertext"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// シークレットをキー名から値へのJSONオブジェクトとして暗号化
+//
+// keyは32バイトのAES-256鍵。keyIdは鍵のSHA-256の先頭8バイトで、
+// どの鍵で封印したかを識別するためだけに使う。
+func Seal(key []byte, secrets []Secret) ([]byte, error) {
+	if err := validateKeys(secrets, validSecretKey, "封印ドキュメント"); err != nil {
+		return nil, err
+	}
+	aead, err := newSealAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(secrets))
+	for _, s := range secrets {
+		values[s.Key] = s.Value
+	}
+	plaintext, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	doc := SealedDocument{
+		Version:   1,
+		Algorithm: sealedAlgorithm,
+		KeyID:     sealKeyID(key),
+		Nonce:     base64.StdEncoding.EncodeToString(nonce),
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+	ciphertext := aead.Seal(nil, nonce, plaintext, sealedAdditionalData(doc))
+	doc.Ciphertext = base64.StdEncoding.EncodeToString(ciphertext)
+
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// 封印されたJSONドキュメントを復号してシークレットを取り出す
+func Unseal(key, document []byte) (map[string]string, error) {
+	var doc SealedDocument
+	if err := json.Unmarshal(document, &doc); err != nil {
+		return nil, fmt.Errorf("無効な封印ドキュメント: %w", err)
+	}
+	if doc.Version != 1 || doc.Algorithm != sealedAlgorithm {
+		return nil, fmt.Errorf("未対応の封印ドキュメント: version=%d algorithm=%s", doc.Version, doc.Algorithm)
+	}
+	if doc.KeyID != sealKeyID(key) {
+		return nil, fmt.Errorf("鍵IDが一致しません: %s", doc.KeyID)
+	}
+	aead, err := newSealAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := base64.StdEncoding.DecodeString(doc.Nonce)
+	if err != nil || len(nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("無効なnonce")
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(doc.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("無効な暗号文: %w", err)
+	}
+	plaintext, err := aead.Open(nil, nonce, ciphertext, sealedAdditionalData(doc))
+	if err != nil {
+		return nil, fmt.Errorf("復号に失敗しました")
+	}
+
+	var values map[string]string
+	if err := json.Unmarshal(plaintext, &values); err != nil {
+		return nil, fmt.Errorf("無効な平文: %w", err)
+	}
+	return values, nil
+}
+
+func newSealAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("封印鍵は32バイトである必要があります: %dバイト", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func sealKeyID(key []byte) string {
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:8])
+}
+
+// メタデータの改ざんを検出するため、暗号文以外のフィールドを追加データとして認証する
+func sealedAdditionalData(doc SealedDocument) []byte {
+	return []byte(fmt.Sprintf("%d|%s|%s|%s", doc.Version, doc.Algorithm, doc.KeyID, doc.CreatedAt.Format(time.RFC3339)))
+}
@@ -0,0 +1,61 @@
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestSealUnseal(t *testing.T) {
+	key := bytes.Repeat([]byte{0x42}, 32)
+	secrets := []Secret{{Key: "DB_PASSWORD", Value: "one"}, {Key: "API_KEY", Value: "two"}}
+
+	doc, err := Seal(key, secrets)
+	if err != nil {
+		t.Fatalf("Seal() エラー = %v", err)
+	}
+	if bytes.Contains(doc, []byte("one")) || bytes.Contains(doc, []byte("DB_PASSWORD")) {
+		t.Errorf("封印ドキュメントに平文が含まれています: %s", doc)
+	}
+
+	got, err := Unseal(key, doc)
+	if err != nil {
+		t.Fatalf("Unseal() エラー = %v", err)
+	}
+	if got["DB_PASSWORD"] != "one" || got["API_KEY"] != "two" || len(got) != 2 {
+		t.Errorf("Unseal() = %v", got)
+	}
+}
+
+func TestUnseal_Tampered(t *testing.T) {
+	key := bytes.Repeat([]byte{0x42}, 32)
+	doc, err := Seal(key, []Secret{{Key: "A", Value: "x"}})
+	if err != nil {
+		t.Fatalf("Seal() エラー = %v", err)
+	}
+
+	// 別の鍵では復号できない
+	if _, err := Unseal(bytes.Repeat([]byte{0x43}, 32), doc); err == nil {
+		t.Error("Unseal() 別の鍵でエラーが返されませんでした")
+	}
+
+	// メタデータの改ざんを検出する
+	var sealed SealedDocument
+	if err := json.Unmarshal(doc, &sealed); err != nil {
+		t.Fatal(err)
+	}
+	sealed.CreatedAt = sealed.CreatedAt.AddDate(0, 0, 1)
+	tampered, err := json.Marshal(sealed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Unseal(key, tampered); err == nil {
+		t.Error("Unseal() 改ざんされたドキュメントでエラーが返されませんでした")
+	}
+}
+
+func TestSeal_InvalidKey(t *testing.T) {
+	if _, err := Seal([]byte("short"), []Secret{{Key: "A", Value: "x"}}); err == nil {
+		t.Error("Seal() 短い鍵でエラーが返されませんでした")
+	}
+}
@@ -0,0 +1,101 @@
+package format
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// キー名と値の組で表される1つのシークレット
+type Secret struct {
+	Key   string
+	Value string
+}
+
+// キー名テンプレートのプレースホルダー
+const (
+	indexPlaceholder    = "{index}"    // 1始まりの連番
+	usernamePlaceholder = "{username}" // ユーザー名が指定された場合のみ
+)
+
+// キー名テンプレートを省略した場合の既定値
+const (
+	defaultKeyTemplate         = "PASSWORD"
+	defaultIndexedKeyTemplate  = "PASSWORD_" + indexPlaceholder
+	defaultUsernameKeyTemplate = usernamePlaceholder + "_PASSWORD"
+)
+
+var (
+	envKeyPattern    = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	secretKeyPattern = regexp.MustCompile(`^[-._a-zA-Z0-9]+$`)
+)
+
+// Kubernetes SecretのデータキーとDocker secretのファイル名として使えるか
+//
+// apimachineryのIsConfigMapKeyと同じく、「.」「..」と「..」で始まるキーは
+// パスとして親ディレクトリなどを指すため使用できない。
+func validSecretKey(key string) bool {
+	return secretKeyPattern.MatchString(key) && key != "." && !strings.HasPrefix(key, "..")
+}
+
+// テンプレートからn個のキー名を生成
+//
+// テンプレートでは {index}（1始まり）と {username} のプレースホルダーが使用できる。
+// クライアントから渡されるため、処理量が入力の長さに比例する単純な置換のみを行う。
+func KeyNames(tmpl string, n int, usernames []string) ([]string, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("無効なシークレット数: %d", n)
+	}
+	if len(usernames) > 0 && len(usernames) != n {
+		return nil, fmt.Errorf("ユーザー名の数がシークレット数と一致しません: %d != %d", len(usernames), n)
+	}
+	if tmpl == "" {
+		switch {
+		case len(usernames) > 0:
+			tmpl = defaultUsernameKeyTemplate
+		case n == 1:
+			tmpl = defaultKeyTemplate
+		default:
+			tmpl = defaultIndexedKeyTemplate
+		}
+	}
+	if len(usernames) == 0 && strings.Contains(tmpl, usernamePlaceholder) {
+		return nil, fmt.Errorf("キー名テンプレートに %s がありますがユーザー名が指定されていません", usernamePlaceholder)
+	}
+
+	keys := make([]string, n)
+	seen := make(map[string]bool, n)
+	for i := range keys {
+		username := ""
+		if len(usernames) > 0 {
+			username = usernames[i]
+		}
+		key := strings.NewReplacer(
+			indexPlaceholder, strconv.Itoa(i+1),
+			usernamePlaceholder, username,
+		).Replace(tmpl)
+		if key == "" {
+			return nil, fmt.Errorf("キー名が空です")
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("キー名が重複しています: %s", key)
+		}
+		seen[key] = true
+		keys[i] = key
+	}
+	return keys, nil
+}
+
+// キー名がすべて使用できるものか検証
+func validateKeys(secrets []Secret, valid func(string) bool, target string) error {
+	if len(secrets) == 0 {
+		return fmt.Errorf("シークレットがありません")
+	}
+	for _, s := range secrets {
+		if !valid(s.Key) {
+			return fmt.Errorf("%sで使用できないキー名です: %q", target, s.Key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package format
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKeyNames(t *testing.T) {
+	tests := []struct {
+		name      string
+		tmpl      string
+		n         int
+		usernames []string
+		want      []string
+		wantErr   bool
+	}{
+		{name: "既定値 - 1件", n: 1, want: []string{"PASSWORD"}},
+		{name: "既定値 - 複数", n: 3, want: []string{"PASSWORD_1", "PASSWORD_2", "PASSWORD_3"}},
+		{name: "既定値 - ユーザー名", n: 2, usernames: []string{"alice", "bob"}, want: []string{"alice_PASSWORD", "bob_PASSWORD"}},
+		{name: "テンプレート", tmpl: "DB_{index}_SECRET", n: 2, want: []string{"DB_1_SECRET", "DB_2_SECRET"}},
+		{name: "テンプレート - ユーザー名と連番", tmpl: "{username}_{index}", n: 2, usernames: []string{"alice", "bob"}, want: []string{"alice_1", "bob_2"}},
+		{name: "テンプレートの構文は解釈しない", tmpl: "{{range 1000000000}}x{{end}}", n: 1, want: []string{"{{range 1000000000}}x{{end}}"}},
+		{name: "重複するキー名", tmpl: "SAME", n: 2, wantErr: true},
+		{name: "ユーザー名なしで{username}", tmpl: "{username}_PASSWORD", n: 1, wantErr: true},
+		{name: "ユーザー名の数が不一致", n: 2, usernames: []string{"alice"}, wantErr: true},
+		{name: "無効な件数", n: 0, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := KeyNames(tt.tmpl, tt.n, tt.usernames)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("KeyNames() エラー = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("KeyNames() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidSecretKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{key: "PASSWORD", want: true},
+		{key: "db-password.txt", want: true},
+		{key: ".env", want: true},
+		{key: "a..b", want: true},
+		{key: "", want: false},
+		{key: ".", want: false},
+		{key: "..", want: false},
+		{key: "..data", want: false},
+		{key: "../escape", want: false},
+		{key: "a/b", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := validSecretKey(tt.key); got != tt.want {
+				t.Errorf("validSecretKey(%q) = %v, want %v", tt.key, got, tt.want)
+			}
+		})
+	}
+}
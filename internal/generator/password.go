@@ -3,94 +3,283 @@ package generator
 import (
 	"crypto/rand"
 	"fmt"
-	"strings"
+	"io"
+	"unicode/utf8"
 
 	"github.com/okamyuji/PasswordGenerator/internal/config"
+	"github.com/okamyuji/PasswordGenerator/internal/random"
+	"github.com/okamyuji/PasswordGenerator/internal/secret"
 )
 
-type Generator struct{}
+// 一度に生成できるパスワード数の上限
+const MaxBatchSize = 100000
 
+type Generator struct {
+	rand       io.Reader
+	lockMemory bool
+}
+
+// crypto/randを乱数源とするGeneratorを作成
 func New() *Generator {
-	return &Generator{}
+	return NewWithReader(rand.Reader)
+}
+
+// 指定された乱数源を使うGeneratorを作成（テストでは決定的な乱数源を渡す）
+func NewWithReader(r io.Reader) *Generator {
+	return &Generator{rand: r}
+}
+
+// 生成したパスワードをロックしたメモリ（mlock）に置くGeneratorを返す
+func (g *Generator) WithMemoryLock() *Generator {
+	return &Generator{rand: g.rand, lockMemory: true}
+}
+
+// パスワードを生成してSecretとして返す（使用後は呼び出し元でDestroyする）
+func (g *Generator) Generate(cfg config.PasswordConfig) (*secret.Secret, error) {
+	// 最初にバリデーションを実行し、選択された文字セットを準備
+	charsets, err := cfg.Charsets()
+	if err != nil {
+		return nil, err
+	}
+
+	src := random.NewBuffered(g.rand, bufferSize(cfg.Length))
+	defer src.Release()
+	return g.generateValid(src, cfg, charsets, runeCharsets(charsets))
 }
 
-func (g *Generator) Generate(cfg config.PasswordConfig) (string, error) {
-	// 最初にバリデーションを実行
-	const MaxPasswordLength = 1000 // パスワードの最大長を設定
-	
-	if cfg.Length <= 0 {
-		return "", fmt.Errorf("無効な長さ: %d", cfg.Length)
+// 同じ設定のパスワードをn個まとめて生成
+//
+// 文字セットの準備と乱数のバッファを全体で共有するため、Generateを繰り返すより高速。
+func (g *Generator) GenerateBatch(cfg config.PasswordConfig, n int) ([]*secret.Secret, error) {
+	if n < 1 || n > MaxBatchSize {
+		return nil, fmt.Errorf("無効な生成数: %d (1〜%d)", n, MaxBatchSize)
 	}
-	
-	if cfg.Length > MaxPasswordLength {
-		return "", fmt.Errorf("パスワード長が最大値を超えています: %d (最大: %d)", cfg.Length, MaxPasswordLength)
+	charsets, err := cfg.Charsets()
+	if err != nil {
+		return nil, err
 	}
 
-	// 選択された文字セットの準備
-	var charsets []string
-	if cfg.UseUppercase {
-		charsets = append(charsets, config.Uppercase)
+	src := random.NewBuffered(g.rand, bufferSize(cfg.Length)*n)
+	defer src.Release()
+	runesets := runeCharsets(charsets)
+	passwords := make([]*secret.Secret, n)
+	for i := range passwords {
+		if passwords[i], err = g.generateValid(src, cfg, charsets, runesets); err != nil {
+			secret.DestroyAll(passwords)
+			return nil, err
+		}
 	}
-	if cfg.UseLowercase {
-		charsets = append(charsets, config.Lowercase)
+	return passwords, nil
+}
+
+// パスワード1つあたりに消費する乱数のおおよそのバイト数
+//
+// 文字の選択とシャッフルにそれぞれ1文字あたり約1バイトを使い、棄却による引き直しの分を上乗せする。
+func bufferSize(length int) int {
+	return length*5/2 + 16
+}
+
+// ASCII以外の文字を含む場合に、文字セットをルーン単位に変換したもの（すべてASCIIならnil）
+func runeCharsets(charsets []string) [][]rune {
+	ascii := true
+	for _, charset := range charsets {
+		for i := 0; i < len(charset) && ascii; i++ {
+			ascii = charset[i] < utf8.RuneSelf
+		}
 	}
-	if cfg.UseNumbers {
-		charsets = append(charsets, config.Numbers)
+	if ascii {
+		return nil
 	}
-	if cfg.UseSymbols {
-		if cfg.CustomSymbols != "" {
-			charsets = append(charsets, cfg.CustomSymbols)
-		} else {
-			charsets = append(charsets, config.Symbols)
+	runesets := make([][]rune, len(charsets))
+	for i, charset := range charsets {
+		runesets[i] = []rune(charset)
+	}
+	return runesets
+}
+
+// 構造の規則を満たすパスワードを生成
+//
+// 規則を破ったパスワードは破棄して全体を生成し直すため、規則を満たすパスワードの中で一様に選ばれる。
+func (g *Generator) generateValid(src *random.Buffered, cfg config.PasswordConfig, charsets []string, runesets [][]rune) (*secret.Secret, error) {
+	if cfg.Rules.Empty() {
+		return g.generate(src, cfg.Length, charsets, runesets, cfg.MinimizeLayerChanges)
+	}
+	var violation string
+	for range config.MaxRuleAttempts {
+		password, err := g.generate(src, cfg.Length, charsets, runesets, cfg.MinimizeLayerChanges)
+		if err != nil {
+			return nil, err
+		}
+		if violation = cfg.Rules.Violation(password.Bytes()); violation == "" {
+			return password, nil
 		}
+		password.Destroy()
 	}
+	return nil, fmt.Errorf("%w（%d回試行、最後の違反: %s）", config.ErrRulesUnsatisfiable, config.MaxRuleAttempts, violation)
+}
 
-	if len(charsets) == 0 {
-		return "", fmt.Errorf("文字タイプが選択されていません")
+// 文字セットごとに1文字以上を含むパスワードを生成
+//
+// 結果はSecretのバッファ上で直接組み立て、文字セットの連結やマップを使わずに割り当てを抑える。
+// ASCII以外の文字を含む場合はルーン単位で選び、長さは文字数として扱う。
+// groupLayersが真なら、モバイルキーボードのレイヤーごとに文字をまとめる。
+func (g *Generator) generate(src *random.Buffered, length int, charsets []string, runesets [][]rune, groupLayers bool) (*secret.Secret, error) {
+	if runesets != nil {
+		return g.generateRunes(src, length, runesets, groupLayers)
+	}
+	password, err := g.newSecret(length)
+	if err != nil {
+		return nil, err
 	}
+	err = fill(src, password.Bytes(), charsets)
+	if err == nil && groupLayers {
+		err = groupByLayer(src, password.Bytes())
+	}
+	if err != nil {
+		password.Destroy()
+		return nil, err
+	}
+	return password, nil
+}
 
-	// セキュアなメモリ割り当て（既に上限チェック済み）
-	result := make([]byte, cfg.Length)
-	used := make(map[int]bool, cfg.Length)
+// ルーン単位で選んだ文字をUTF-8でSecretに書き込む
+func (g *Generator) generateRunes(src *random.Buffered, length int, runesets [][]rune, groupLayers bool) (*secret.Secret, error) {
+	result := make([]rune, length)
+	defer clear(result)
+	if err := fillRunes(src, result, runesets); err != nil {
+		return nil, err
+	}
+	if groupLayers {
+		if err := groupByLayer(src, result); err != nil {
+			return nil, err
+		}
+	}
 
-	// 各文字セットから1文字ずつ必ず選択
-	for i, charset := range charsets {
-		if i >= cfg.Length {
-			break
+	size := 0
+	for _, r := range result {
+		size += utf8.RuneLen(r)
+	}
+	password, err := g.newSecret(size)
+	if err != nil {
+		return nil, err
+	}
+	buf := password.Bytes()[:0]
+	for _, r := range result {
+		buf = utf8.AppendRune(buf, r)
+	}
+	return password, nil
+}
+
+func (g *Generator) newSecret(n int) (*secret.Secret, error) {
+	if g.lockMemory {
+		return secret.NewLocked(n)
+	}
+	return secret.New(n), nil
+}
+
+// resultを文字セットから選んだ文字で埋める
+func fill(src *random.Buffered, result []byte, charsets []string) error {
+	// 先頭から各文字セットの1文字を置き、残りを全文字セットから選ぶ
+	total := 0
+	for _, charset := range charsets {
+		total += len(charset)
+	}
+	for i := range result {
+		if i < len(charsets) {
+			idx, err := src.Intn(len(charsets[i]))
+			if err != nil {
+				return err
+			}
+			result[i] = charsets[i][idx]
+			continue
 		}
-		randomByte := make([]byte, 1)
-		if _, err := rand.Read(randomByte); err != nil {
-			return "", err
+		idx, err := src.Intn(total)
+		if err != nil {
+			return err
 		}
-		pos := int(randomByte[0]) % cfg.Length
-		for used[pos] {
-			pos = (pos + 1) % cfg.Length
+		result[i] = charAt(charsets, idx)
+	}
+
+	// 必須の文字の位置が偏らないようFisher-Yatesでシャッフル
+	for i := len(result) - 1; i > 0; i-- {
+		j, err := src.Intn(i + 1)
+		if err != nil {
+			return err
 		}
-		result[pos] = charset[int(randomByte[0])%len(charset)]
-		used[pos] = true
+		result[i], result[j] = result[j], result[i]
 	}
 
-	// 残りの文字を全文字セットからランダムに選択
-	allChars := strings.Join(charsets, "")
-	for i := 0; i < cfg.Length; i++ {
-		if !used[i] {
-			randomByte := make([]byte, 1)
-			if _, err := rand.Read(randomByte); err != nil {
-				return "", err
+	return nil
+}
+
+// 文字セットを連結した場合のidx番目の文字
+func charAt(charsets []string, idx int) byte {
+	for _, charset := range charsets {
+		if idx < len(charset) {
+			return charset[idx]
+		}
+		idx -= len(charset)
+	}
+	panic("generator: 文字セットの範囲外")
+}
+
+// fillのルーン版
+func fillRunes(src *random.Buffered, result []rune, runesets [][]rune) error {
+	total := 0
+	for _, runeset := range runesets {
+		total += len(runeset)
+	}
+	for i := range result {
+		if i < len(runesets) {
+			idx, err := src.Intn(len(runesets[i]))
+			if err != nil {
+				return err
 			}
-			result[i] = allChars[int(randomByte[0])%len(allChars)]
+			result[i] = runesets[i][idx]
+			continue
+		}
+		idx, err := src.Intn(total)
+		if err != nil {
+			return err
 		}
+		result[i] = runeAt(runesets, idx)
 	}
 
-	// 生成されたパスワードをシャッフル
 	for i := len(result) - 1; i > 0; i-- {
-		randomByte := make([]byte, 1)
-		if _, err := rand.Read(randomByte); err != nil {
-			return "", err
+		j, err := src.Intn(i + 1)
+		if err != nil {
+			return err
 		}
-		j := int(randomByte[0]) % (i + 1)
 		result[i], result[j] = result[j], result[i]
 	}
 
-	return string(result), nil
+	return nil
+}
+
+// charAtのルーン版
+func runeAt(runesets [][]rune, idx int) rune {
+	for _, runeset := range runesets {
+		if idx < len(runeset) {
+			return runeset[idx]
+		}
+		idx -= len(runeset)
+	}
+	panic("generator: 文字セットの範囲外")
+}
+
+// 文字をレイヤーごとにまとめる（レイヤーの順序は乱数で選ぶ）
+func groupByLayer[T byte | rune](src *random.Buffered, chars []T) error {
+	var rank [config.NumLayers]int
+	for i := range rank {
+		rank[i] = i
+	}
+	for i := len(rank) - 1; i > 0; i-- {
+		j, err := src.Intn(i + 1)
+		if err != nil {
+			return err
+		}
+		rank[i], rank[j] = rank[j], rank[i]
+	}
+	config.GroupByLayer(chars, rank)
+	return nil
 }
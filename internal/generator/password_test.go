@@ -1,10 +1,14 @@
 package generator
 
 import (
+	"errors"
 	"strings"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/okamyuji/PasswordGenerator/internal/config"
+	"github.com/okamyuji/PasswordGenerator/internal/random"
+	"github.com/okamyuji/PasswordGenerator/internal/secret"
 )
 
 func TestGenerator_Generate(t *testing.T) {
@@ -52,6 +56,35 @@ func TestGenerator_Generate(t *testing.T) {
 				return hasUpper && hasLower && hasSymbol
 			},
 		},
+		{
+			name: "記号プロファイル - シェル",
+			config: config.PasswordConfig{
+				Length:        64,
+				UseLowercase:  true,
+				UseSymbols:    true,
+				SymbolProfile: config.SymbolProfileShell,
+			},
+			wantLen: 64,
+			wantErr: false,
+			validate: func(s string) bool {
+				return !strings.ContainsAny(s, "$`'\"\\!&;|<>(){}[]*?#~^") &&
+					strings.ContainsAny(s, "@%_+-=:,.")
+			},
+		},
+		{
+			name: "記号プロファイルで使用できる記号がない",
+			config: config.PasswordConfig{
+				Length:        8,
+				UseSymbols:    true,
+				CustomSymbols: "$`",
+				SymbolProfile: config.SymbolProfileShell,
+			},
+			wantLen: 0,
+			wantErr: true,
+			validate: func(s string) bool {
+				return true
+			},
+		},
 		{
 			name: "数字のみ",
 			config: config.PasswordConfig{
@@ -64,6 +97,36 @@ func TestGenerator_Generate(t *testing.T) {
 				return strings.Trim(s, config.Numbers) == ""
 			},
 		},
+		{
+			name: "ひらがな・カタカナ・全角数字",
+			config: config.PasswordConfig{
+				Length:             12,
+				UseHiragana:        true,
+				UseKatakana:        true,
+				UseFullWidthDigits: true,
+			},
+			wantLen: 12,
+			wantErr: false,
+			validate: func(s string) bool {
+				return strings.ContainsAny(s, config.Hiragana) && strings.ContainsAny(s, config.Katakana) &&
+					strings.ContainsAny(s, config.FullWidthDigits) && utf8.ValidString(s)
+			},
+		},
+		{
+			name: "ASCIIとUnicodeブロックの混在",
+			config: config.PasswordConfig{
+				Length:        10,
+				UseLowercase:  true,
+				UnicodeBlocks: []config.UnicodeBlock{config.BlockGreek, config.BlockHangul},
+			},
+			wantLen: 10,
+			wantErr: false,
+			validate: func(s string) bool {
+				greek, _ := config.BlockGreek.Charset()
+				hangul, _ := config.BlockHangul.Charset()
+				return strings.ContainsAny(s, config.Lowercase) && strings.ContainsAny(s, greek) && strings.ContainsAny(s, hangul)
+			},
+		},
 		{
 			name: "無効な長さ",
 			config: config.PasswordConfig{
@@ -76,6 +139,50 @@ func TestGenerator_Generate(t *testing.T) {
 				return true
 			},
 		},
+		{
+			name: "キーボード配列 - USとJIS",
+			config: config.PasswordConfig{
+				Length:          32,
+				UseLowercase:    true,
+				UseSymbols:      true,
+				KeyboardLayouts: []config.KeyboardLayout{config.KeyboardUS, config.KeyboardJIS},
+			},
+			wantLen: 32,
+			wantErr: false,
+			validate: func(s string) bool {
+				return !strings.ContainsAny(s, "@^~\\`&*()_+=[]{}|:")
+			},
+		},
+		{
+			name: "レイヤーの切り替えを減らす",
+			config: config.PasswordConfig{
+				Length:               24,
+				UseUppercase:         true,
+				UseLowercase:         true,
+				UseNumbers:           true,
+				UseSymbols:           true,
+				MinimizeLayerChanges: true,
+			},
+			wantLen: 24,
+			wantErr: false,
+			validate: func(s string) bool {
+				return layerChanges(s) <= config.NumLayers-1
+			},
+		},
+		{
+			name: "レイヤーの切り替えを減らす - ひらがな",
+			config: config.PasswordConfig{
+				Length:               12,
+				UseLowercase:         true,
+				UseHiragana:          true,
+				MinimizeLayerChanges: true,
+			},
+			wantLen: 12,
+			wantErr: false,
+			validate: func(s string) bool {
+				return layerChanges(s) == 1
+			},
+		},
 		{
 			name: "文字種が選択されていない",
 			config: config.PasswordConfig{
@@ -92,13 +199,17 @@ func TestGenerator_Generate(t *testing.T) {
 	g := New()
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := g.Generate(tt.config)
+			password, err := g.Generate(tt.config)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Generator.Generate() エラー = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if len(got) != tt.wantLen {
-				t.Errorf("Generator.Generate() 長さ = %v, want %v", len(got), tt.wantLen)
+			got := ""
+			if password != nil {
+				got = password.Reveal()
+			}
+			if n := utf8.RuneCountInString(got); n != tt.wantLen {
+				t.Errorf("Generator.Generate() 長さ = %v, want %v", n, tt.wantLen)
 			}
 			if !tt.wantErr && !tt.validate(got) {
 				t.Errorf("Generator.Generate() = %v, 検証失敗", got)
@@ -107,6 +218,18 @@ func TestGenerator_Generate(t *testing.T) {
 	}
 }
 
+// 隣り合う文字でモバイルキーボードのレイヤーが変わる回数
+func layerChanges(s string) int {
+	changes, prev := 0, -1
+	for _, r := range s {
+		if l := config.Layer(r); prev >= 0 && l != prev {
+			changes++
+		}
+		prev = config.Layer(r)
+	}
+	return changes
+}
+
 func TestGenerator_GenerateMultiple(t *testing.T) {
 	g := New()
 	config := config.PasswordConfig{
@@ -120,14 +243,193 @@ func TestGenerator_GenerateMultiple(t *testing.T) {
 	// 重複がないことを確認するために複数のパスワードを生成
 	passwords := make(map[string]bool)
 	for i := 0; i < 100; i++ {
-		pass, err := g.Generate(config)
+		password, err := g.Generate(config)
 		if err != nil {
 			t.Errorf("Generator.Generate() エラー = %v", err)
 			continue
 		}
+		pass := password.Reveal()
 		if passwords[pass] {
 			t.Errorf("重複したパスワードを生成: %v", pass)
 		}
 		passwords[pass] = true
 	}
 }
+
+// 決定的な乱数源では出力が固定される
+func TestGenerator_GenerateDeterministic(t *testing.T) {
+	tests := []struct {
+		name   string
+		config config.PasswordConfig
+		want   string
+	}{
+		{
+			name:   "全ての文字種",
+			config: config.PasswordConfig{Length: 16, UseUppercase: true, UseLowercase: true, UseNumbers: true, UseSymbols: true},
+			want:   "W!T6CySHWX1JKji)",
+		},
+		{
+			name:   "数字のみ",
+			config: config.PasswordConfig{Length: 8, UseNumbers: true},
+			want:   "32436657",
+		},
+		{
+			name:   "ひらがなと数字",
+			config: config.PasswordConfig{Length: 8, UseNumbers: true, UseHiragana: true},
+			want:   "ひね3そぼきぜぬ",
+		},
+		{
+			name:   "記号プロファイル - URL",
+			config: config.PasswordConfig{Length: 20, UseLowercase: true, UseSymbols: true, SymbolProfile: config.SymbolProfileURL},
+			want:   "t&a+mtye)x=_hi(-$qd!",
+		},
+		{
+			name:   "レイヤーの切り替えを減らす",
+			config: config.PasswordConfig{Length: 16, UseUppercase: true, UseLowercase: true, UseNumbers: true, UseSymbols: true, MinimizeLayerChanges: true},
+			want:   "!61)yjiWTCSHWXJK",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewWithReader(random.NewDeterministic("golden")).Generate(tt.config)
+			if err != nil {
+				t.Fatalf("Generate() エラー = %v", err)
+			}
+			if !got.Equal([]byte(tt.want)) {
+				t.Errorf("Generate() = %q, want %q", got.Reveal(), tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerator_GenerateBatch(t *testing.T) {
+	cfg := config.PasswordConfig{Length: 12, UseUppercase: true, UseLowercase: true, UseNumbers: true, UseSymbols: true}
+
+	passwords, err := New().GenerateBatch(cfg, 1000)
+	if err != nil {
+		t.Fatalf("GenerateBatch() エラー = %v", err)
+	}
+	if len(passwords) != 1000 {
+		t.Fatalf("GenerateBatch() 件数 = %d, want 1000", len(passwords))
+	}
+	seen := make(map[string]bool, len(passwords))
+	for _, password := range passwords {
+		p := password.Reveal()
+		if len(p) != cfg.Length {
+			t.Errorf("長さ = %d, want %d", len(p), cfg.Length)
+		}
+		for _, charset := range []string{config.Uppercase, config.Lowercase, config.Numbers, config.Symbols} {
+			if !strings.ContainsAny(p, charset) {
+				t.Errorf("%q に %q の文字が含まれていません", p, charset)
+			}
+		}
+		if seen[p] {
+			t.Errorf("重複したパスワードを生成: %v", p)
+		}
+		seen[p] = true
+	}
+
+	for _, n := range []int{0, MaxBatchSize + 1} {
+		if _, err := New().GenerateBatch(cfg, n); err == nil {
+			t.Errorf("GenerateBatch(%d) エラーが返されませんでした", n)
+		}
+	}
+	if _, err := New().GenerateBatch(config.PasswordConfig{Length: 12}, 10); err == nil {
+		t.Error("GenerateBatch() 文字タイプ未選択でエラーが返されませんでした")
+	}
+}
+
+// 1万件のパスワードを生成するスループット
+const benchmarkBatch = 10000
+
+var benchmarkConfig = config.PasswordConfig{Length: 16, UseUppercase: true, UseLowercase: true, UseNumbers: true, UseSymbols: true}
+
+func reportThroughput(b *testing.B) {
+	b.ReportMetric(float64(b.N*benchmarkBatch)/b.Elapsed().Seconds(), "passwords/s")
+}
+
+func BenchmarkGenerator_Generate10k(b *testing.B) {
+	g := New()
+	b.ReportAllocs()
+	for b.Loop() {
+		for range benchmarkBatch {
+			if _, err := g.Generate(benchmarkConfig); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+	reportThroughput(b)
+}
+
+func BenchmarkGenerator_GenerateBatch10k(b *testing.B) {
+	g := New()
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := g.GenerateBatch(benchmarkConfig, benchmarkBatch); err != nil {
+			b.Fatal(err)
+		}
+	}
+	reportThroughput(b)
+}
+
+// HTTPハンドラーのように複数のゴルーチンから同時に生成する場合
+func BenchmarkGenerator_Generate10kParallel(b *testing.B) {
+	g := New()
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			for range benchmarkBatch {
+				if _, err := g.Generate(benchmarkConfig); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+	reportThroughput(b)
+}
+
+func TestGenerator_GenerateRules(t *testing.T) {
+	rules := config.Rules{
+		MaxRepeat:   1,
+		MaxSequence: 2,
+		FirstClass:  config.ClassLetter,
+		LastClass:   config.ClassAlphanumeric,
+		Forbidden:   []string{"a"},
+	}
+	cfg := config.PasswordConfig{Length: 8, UseLowercase: true, UseNumbers: true, UseSymbols: true, Rules: rules}
+
+	passwords, err := New().GenerateBatch(cfg, 200)
+	if err != nil {
+		t.Fatalf("GenerateBatch() エラー = %v", err)
+	}
+	defer secret.DestroyAll(passwords)
+	for _, p := range passwords {
+		if v := rules.Violation(p.Bytes()); v != "" {
+			t.Errorf("規則 %s を満たしていません: %q", v, p.Reveal())
+		}
+	}
+
+	// 文字が1種類しかなく、同じ文字の連続を禁止すると満たせない
+	_, err = New().Generate(config.PasswordConfig{
+		Length: 4, UseSymbols: true, CustomSymbols: "!", Rules: config.Rules{MaxRepeat: 1},
+	})
+	if !errors.Is(err, config.ErrRulesUnsatisfiable) {
+		t.Errorf("Generate() エラー = %v, want %v", err, config.ErrRulesUnsatisfiable)
+	}
+}
+
+func TestGenerator_WithMemoryLock(t *testing.T) {
+	cfg := config.PasswordConfig{Length: 24, UseLowercase: true, UseNumbers: true}
+	password, err := New().WithMemoryLock().Generate(cfg)
+	if err != nil {
+		t.Skipf("メモリをロックできない環境です: %v", err)
+	}
+	defer password.Destroy()
+	if password.Len() != cfg.Length {
+		t.Errorf("長さ = %d, want %d", password.Len(), cfg.Length)
+	}
+	if got := password.String(); got != secret.Redacted {
+		t.Errorf("String() = %q", got)
+	}
+}
@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"filippo.io/age"
+
+	"github.com/okamyuji/PasswordGenerator/internal/encrypt"
+	"github.com/okamyuji/PasswordGenerator/internal/format"
+)
+
+// htpasswdやLDIFのように平文を別途返す必要がある形式のJSONレスポンス
+type credentialsResponse struct {
+	Format      format.Format       `json:"format"`
+	File        string              `json:"file"`
+	Credentials []format.Credential `json:"credentials"`
+	Warning     string              `json:"warning,omitempty"`
+}
+
+// フォームの値から出力フォーマットのオプションを作成
+func formatOptionsFromForm(r *http.Request, f format.Format) (format.Options, error) {
+	opts := format.Options{
+		KeyTemplate:       strings.TrimSpace(r.Form.Get("keyTemplate")),
+		Usernames:         splitList(r.Form.Get("usernames")),
+		HtpasswdAlgorithm: format.HtpasswdAlgorithm(r.Form.Get("htpasswdAlgorithm")),
+		LDIF: format.LDIFOptions{
+			BaseDN:       strings.TrimSpace(r.Form.Get("baseDN")),
+			RDNAttribute: strings.TrimSpace(r.Form.Get("rdnAttribute")),
+			Scheme:       format.LDAPScheme(strings.ToUpper(r.Form.Get("ldapScheme"))),
+		},
+		SecretName: strings.TrimSpace(r.Form.Get("secretName")),
+		Namespace:  strings.TrimSpace(r.Form.Get("namespace")),
+	}
+
+	count, err := formInt(r, "count")
+	if err != nil {
+		return opts, err
+	}
+	opts.Count = count
+
+	if f == format.FormatSealed {
+		key, err := base64.StdEncoding.DecodeString(os.Getenv("SEAL_KEY"))
+		if err != nil || len(key) != 32 {
+			return opts, errSealKeyNotConfigured
+		}
+		opts.SealKey = key
+	}
+	return opts, nil
+}
+
+var errSealKeyNotConfigured = errors.New("封印鍵（SEAL_KEY）が設定されていません")
+
+// 正の整数のフォーム値を取得（未指定は0）
+func formInt(r *http.Request, name string) (int, error) {
+	v := r.Form.Get(name)
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("無効な%s: %s", name, v)
+	}
+	return n, nil
+}
+
+// カンマまたは改行で区切られた一覧を分割
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == '\n' || r == '\r' }) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// エンコード結果をレスポンスとして書き込む
+func writeFormatOutput(w http.ResponseWriter, f format.Format, out *format.Output) {
+	w.Header().Set("Cache-Control", "no-store")
+	if len(out.Credentials) > 0 {
+		writeJSON(w, credentialsResponse{
+			Format:      f,
+			File:        string(out.Body),
+			Credentials: out.Credentials,
+			Warning:     out.Warning,
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", out.ContentType)
+	if f == format.FormatDockerSecrets {
+		w.Header().Set("Content-Disposition", `attachment; filename="secrets.tar"`)
+	}
+	if _, err := w.Write(out.Body); err != nil {
+		slog.Error("レスポンスの書き込みに失敗", "error", err)
+	}
+}
+
+// エンコード結果を受信者の公開鍵で暗号化し、ASCII armor形式の暗号文のみを書き込む
+//
+// 平文の認証情報を伴う形式では、通常のレスポンスと同じJSONを暗号化する。
+func writeEncryptedOutput(w http.ResponseWriter, f format.Format, out *format.Output, recipients []age.Recipient) {
+	plaintext := out.Body
+	if len(out.Credentials) > 0 {
+		body, err := json.Marshal(credentialsResponse{
+			Format:      f,
+			File:        string(out.Body),
+			Credentials: out.Credentials,
+			Warning:     out.Warning,
+		})
+		if err != nil {
+			slog.Error("JSONエンコードに失敗", "error", err)
+			http.Error(w, "内部サーバーエラー", http.StatusInternalServerError)
+			return
+		}
+		defer clear(body)
+		plaintext = body
+	}
+
+	armored, err := encrypt.Armor(plaintext, recipients)
+	if err != nil {
+		slog.Error("暗号化に失敗", "error", err)
+		http.Error(w, "内部サーバーエラー", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", encryptedContentType)
+	w.Header().Set("Cache-Control", "no-store")
+	if _, err := w.Write(armored); err != nil {
+		slog.Error("レスポンスの書き込みに失敗", "error", err)
+	}
+}
+
+// ASCII armor形式のage暗号文のContent-Type
+const encryptedContentType = "application/x-age-encrypted"
+
+// 生成時の警告を返すレスポンスヘッダー
+const warningHeader = "X-Password-Warning"
+
+// 警告があればすべてのフォーマットで共通のヘッダーに設定（日本語を含むためパーセントエンコードする）
+func setWarningHeader(w http.ResponseWriter, warning string) {
+	if warning != "" {
+		w.Header().Set(warningHeader, url.PathEscape(warning))
+	}
+}
+
+// 値をJSONとしてレスポンスに書き込む
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		slog.Error("JSONエンコードに失敗", "error", err)
+		http.Error(w, "内部サーバーエラー", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(body); err != nil {
+		slog.Error("レスポンスの書き込みに失敗", "error", err)
+	}
+}
@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/okamyuji/PasswordGenerator/internal/health"
+)
+
+// 乱数源の状態を確認するコントラクトを定義するインターフェース
+type HealthCheckerInterface interface {
+	// ヘルステストに失敗していればそのエラーを返す
+	Err() error
+}
+
+// ヘルスチェックと、乱数源が異常な間の生成の停止を担うハンドラー
+type HealthHandler struct {
+	checker HealthCheckerInterface
+}
+
+// 依存性注入を使用して新しいHealthHandlerを作成
+func NewHealthHandler(checker HealthCheckerInterface) *HealthHandler {
+	return &HealthHandler{checker: checker}
+}
+
+// 乱数源が正常なら200 OK、ヘルステストに失敗していれば503を返す
+func (h *HealthHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-store")
+	if err := h.checker.Err(); err != nil {
+		http.Error(w, "UNHEALTHY: "+err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte("OK")); err != nil {
+		slog.Error("ヘルスチェックレスポンスの書き込みに失敗", "error", err)
+	}
+}
+
+// 乱数源がヘルステストに失敗している間は、生成を行うハンドラーを実行せずに503を返す
+func (h *HealthHandler) Guard(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := h.checker.Err(); err != nil {
+			slog.Error("乱数源が異常なため生成を拒否しました", "error", err, "path", r.URL.Path)
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// 生成時のエラーを書き込む（乱数源の異常は503、それ以外は入力の誤りとして400）
+func writeGenerationError(w http.ResponseWriter, err error) {
+	if errors.Is(err, health.ErrUnhealthy) {
+		slog.Error("乱数源のヘルステストに失敗しました", "error", err)
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	http.Error(w, err.Error(), http.StatusBadRequest)
+}
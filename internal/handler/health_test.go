@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/okamyuji/PasswordGenerator/internal/health"
+	"github.com/okamyuji/PasswordGenerator/internal/token"
+)
+
+// 指定されたエラーを返すモックHealthChecker
+type MockHealthChecker struct {
+	err error
+}
+
+func (m *MockHealthChecker) Err() error {
+	return m.err
+}
+
+// 常に乱数源の異常を返すモックTokenGenerator
+type UnhealthyTokenGenerator struct{}
+
+func (UnhealthyTokenGenerator) Generate(opts token.Options) (string, error) {
+	return "", fmt.Errorf("%w (テスト)", health.ErrUnhealthy)
+}
+
+func TestHealthHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantBody   string
+		wantNext   bool
+	}{
+		{
+			name:       "正常",
+			wantStatus: http.StatusOK,
+			wantBody:   "OK",
+			wantNext:   true,
+		},
+		{
+			name:       "ヘルステスト失敗",
+			err:        health.ErrUnhealthy,
+			wantStatus: http.StatusServiceUnavailable,
+			wantBody:   "UNHEALTHY",
+			wantNext:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHealthHandler(&MockHealthChecker{err: tt.err})
+
+			rr := httptest.NewRecorder()
+			h.Handle(rr, httptest.NewRequest(http.MethodGet, "/health", nil))
+			if rr.Code != tt.wantStatus {
+				t.Errorf("/health ステータスコード = %v, want %v", rr.Code, tt.wantStatus)
+			}
+			if !strings.HasPrefix(rr.Body.String(), tt.wantBody) {
+				t.Errorf("/health ボディ = %q, want %q", rr.Body.String(), tt.wantBody)
+			}
+
+			called := false
+			rr = httptest.NewRecorder()
+			h.Guard(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+			})(rr, httptest.NewRequest(http.MethodPost, "/api/token", nil))
+			if called != tt.wantNext {
+				t.Errorf("Guard() ハンドラー実行 = %v, want %v", called, tt.wantNext)
+			}
+			if !tt.wantNext && rr.Code != http.StatusServiceUnavailable {
+				t.Errorf("Guard() ステータスコード = %v, want %v", rr.Code, http.StatusServiceUnavailable)
+			}
+		})
+	}
+}
+
+func TestWriteGenerationError(t *testing.T) {
+	// 生成中に乱数源が異常になった場合は入力エラーではなく503を返す
+	req := httptest.NewRequest(http.MethodPost, "/api/token", strings.NewReader(""))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rr := httptest.NewRecorder()
+	NewTokenHandler(UnhealthyTokenGenerator{}).Handle(rr, req)
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("ステータスコード = %v, want %v", rr.Code, http.StatusServiceUnavailable)
+	}
+}
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/okamyuji/PasswordGenerator/internal/identifier"
+)
+
+// 識別子生成のコントラクトを定義するインターフェース
+type IdentifierGeneratorInterface interface {
+	Generate(opts identifier.Options) (*identifier.Result, error)
+}
+
+// UUIDやULID、NanoIDを生成するハンドラー
+type IdentifierHandler struct {
+	generator IdentifierGeneratorInterface
+}
+
+// 依存性注入を使用して新しいIdentifierHandlerを作成
+func NewIdentifierHandler(generator IdentifierGeneratorInterface) *IdentifierHandler {
+	return &IdentifierHandler{generator: generator}
+}
+
+func (h *IdentifierHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if !parsePostForm(w, r) {
+		return
+	}
+
+	kind, err := identifier.ParseKind(r.Form.Get("kind"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	opts := identifier.Options{Kind: kind, Alphabet: r.Form.Get("alphabet")}
+	if opts.Count, err = formInt(r, "count"); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if opts.Size, err = formInt(r, "size"); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.generator.Generate(opts)
+	if err != nil {
+		writeGenerationError(w, err)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, result)
+}
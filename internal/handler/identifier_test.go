@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/okamyuji/PasswordGenerator/internal/identifier"
+)
+
+// 受け取ったオプションを記録するモックIdentifierGenerator
+type MockIdentifierGenerator struct {
+	opts identifier.Options
+}
+
+func (m *MockIdentifierGenerator) Generate(opts identifier.Options) (*identifier.Result, error) {
+	m.opts = opts
+	return &identifier.Result{Kind: opts.Kind, IDs: []string{"ID"}, Warning: "警告"}, nil
+}
+
+func TestIdentifierHandler_Handle(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		formData   url.Values
+		wantStatus int
+		wantOpts   identifier.Options
+	}{
+		{
+			name:       "UUIDv7",
+			method:     http.MethodPost,
+			formData:   url.Values{"kind": {"uuidv7"}, "count": {"10"}},
+			wantStatus: http.StatusOK,
+			wantOpts:   identifier.Options{Kind: identifier.KindUUIDv7, Count: 10},
+		},
+		{
+			name:       "NanoID",
+			method:     http.MethodPost,
+			formData:   url.Values{"kind": {"nanoid"}, "alphabet": {"abc123"}, "size": {"30"}},
+			wantStatus: http.StatusOK,
+			wantOpts:   identifier.Options{Kind: identifier.KindNanoID, Alphabet: "abc123", Size: 30},
+		},
+		{
+			name:       "種類未指定",
+			method:     http.MethodPost,
+			formData:   url.Values{},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "無効な識別子数",
+			method:     http.MethodPost,
+			formData:   url.Values{"kind": {"ulid"}, "count": {"x"}},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "無効な長さ",
+			method:     http.MethodPost,
+			formData:   url.Values{"kind": {"nanoid"}, "size": {"0"}},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "GETは許可されない",
+			method:     http.MethodGet,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generator := &MockIdentifierGenerator{}
+			h := NewIdentifierHandler(generator)
+
+			req := httptest.NewRequest(tt.method, "/api/identifiers", strings.NewReader(tt.formData.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			rr := httptest.NewRecorder()
+			h.Handle(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("ステータスコード = %d, want %d: %s", rr.Code, tt.wantStatus, rr.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if generator.opts != tt.wantOpts {
+				t.Errorf("オプション = %+v, want %+v", generator.opts, tt.wantOpts)
+			}
+
+			var result identifier.Result
+			if err := json.Unmarshal(rr.Body.Bytes(), &result); err != nil {
+				t.Fatalf("JSONの解析に失敗: %v", err)
+			}
+			if len(result.IDs) != 1 || result.Warning != "警告" || result.Kind != tt.wantOpts.Kind {
+				t.Errorf("レスポンス = %s", rr.Body.String())
+			}
+			if rr.Header().Get("Cache-Control") != "no-store" {
+				t.Errorf("Cache-Control = %q, want no-store", rr.Header().Get("Cache-Control"))
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/okamyuji/PasswordGenerator/internal/health"
+	"github.com/okamyuji/PasswordGenerator/internal/keys"
+)
+
+// 鍵素材生成のコントラクトを定義するインターフェース
+type KeyGeneratorInterface interface {
+	GenerateSSH(opts keys.SSHOptions) (*keys.SSHKeyPair, error)
+	GenerateWireGuard(presharedKey bool) (*keys.WireGuardKeys, error)
+	GenerateJWK(opts keys.JWKOptions) (*keys.JWKResult, error)
+}
+
+// SSH鍵やWireGuard鍵、JWKを生成するハンドラー
+//
+// 秘密鍵はレスポンスで一度だけ返し、ログには出力しない。
+type KeyHandler struct {
+	generator KeyGeneratorInterface
+}
+
+// 依存性注入を使用して新しいKeyHandlerを作成
+func NewKeyHandler(generator KeyGeneratorInterface) *KeyHandler {
+	return &KeyHandler{generator: generator}
+}
+
+// OpenSSH形式のSSH鍵ペアを生成
+func (h *KeyHandler) HandleSSH(w http.ResponseWriter, r *http.Request) {
+	if !parsePostForm(w, r) {
+		return
+	}
+
+	keyType, err := keys.ParseSSHKeyType(r.Form.Get("type"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	bits, err := formInt(r, "bits")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	pair, err := h.generator.GenerateSSH(keys.SSHOptions{
+		Type:       keyType,
+		Bits:       bits,
+		Comment:    strings.TrimSpace(r.Form.Get("comment")),
+		Passphrase: r.Form.Get("passphrase") == "true",
+	})
+	if err != nil {
+		writeGenerationError(w, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, pair)
+}
+
+// WireGuardの鍵ペアと事前共有鍵を生成
+func (h *KeyHandler) HandleWireGuard(w http.ResponseWriter, r *http.Request) {
+	if !parsePostForm(w, r) {
+		return
+	}
+
+	wgKeys, err := h.generator.GenerateWireGuard(r.Form.Get("presharedKey") == "true")
+	if err != nil {
+		// 入力の誤りはなく、失敗するのは乱数源の異常（503）か読み込みエラーのみ
+		if errors.Is(err, health.ErrUnhealthy) {
+			writeGenerationError(w, err)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, wgKeys)
+}
+
+// JWT署名用のJWKと、公開鍵のJWKSを生成
+func (h *KeyHandler) HandleJWK(w http.ResponseWriter, r *http.Request) {
+	if !parsePostForm(w, r) {
+		return
+	}
+
+	alg, err := keys.ParseJWKAlgorithm(r.Form.Get("alg"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	bits, err := formInt(r, "bits")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.generator.GenerateJWK(keys.JWKOptions{Algorithm: alg, Bits: bits})
+	if err != nil {
+		writeGenerationError(w, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, result)
+}
@@ -0,0 +1,220 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/okamyuji/PasswordGenerator/internal/health"
+	"github.com/okamyuji/PasswordGenerator/internal/keys"
+)
+
+// 受け取ったオプションを記録するモックKeyGenerator
+type MockKeyGenerator struct {
+	sshOpts      keys.SSHOptions
+	presharedKey bool
+	jwkOpts      keys.JWKOptions
+}
+
+func (m *MockKeyGenerator) GenerateSSH(opts keys.SSHOptions) (*keys.SSHKeyPair, error) {
+	m.sshOpts = opts
+	return &keys.SSHKeyPair{Type: opts.Type, PublicKey: "ssh-ed25519 AAAA", PrivateKey: "PRIVATE"}, nil
+}
+
+func (m *MockKeyGenerator) GenerateWireGuard(presharedKey bool) (*keys.WireGuardKeys, error) {
+	m.presharedKey = presharedKey
+	return &keys.WireGuardKeys{PrivateKey: "PRIVATE", PublicKey: "PUBLIC"}, nil
+}
+
+func (m *MockKeyGenerator) GenerateJWK(opts keys.JWKOptions) (*keys.JWKResult, error) {
+	m.jwkOpts = opts
+	public := keys.JWK{Kty: "EC", Kid: "KID", X: "X", Y: "Y"}
+	private := public
+	private.D = "D"
+	return &keys.JWKResult{Key: private, Public: &public, JWKS: &keys.JWKSet{Keys: []keys.JWK{public}}}, nil
+}
+
+func TestKeyHandler_HandleSSH(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		formData   url.Values
+		wantStatus int
+		wantOpts   keys.SSHOptions
+	}{
+		{
+			name:       "既定値",
+			method:     http.MethodPost,
+			formData:   url.Values{},
+			wantStatus: http.StatusOK,
+			wantOpts:   keys.SSHOptions{Type: keys.SSHKeyEd25519},
+		},
+		{
+			name:   "パスフレーズ付きRSA",
+			method: http.MethodPost,
+			formData: url.Values{
+				"type":       {"rsa"},
+				"bits":       {"4096"},
+				"comment":    {" deploy@example.com "},
+				"passphrase": {"true"},
+			},
+			wantStatus: http.StatusOK,
+			wantOpts:   keys.SSHOptions{Type: keys.SSHKeyRSA, Bits: 4096, Comment: "deploy@example.com", Passphrase: true},
+		},
+		{
+			name:       "未対応の種類",
+			method:     http.MethodPost,
+			formData:   url.Values{"type": {"dsa"}},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "無効なビット数",
+			method:     http.MethodPost,
+			formData:   url.Values{"type": {"rsa"}, "bits": {"abc"}},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "GETは許可されない",
+			method:     http.MethodGet,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generator := &MockKeyGenerator{}
+			h := NewKeyHandler(generator)
+
+			req := httptest.NewRequest(tt.method, "/api/keys/ssh", strings.NewReader(tt.formData.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			rr := httptest.NewRecorder()
+			h.HandleSSH(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("ステータスコード = %d, want %d: %s", rr.Code, tt.wantStatus, rr.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if generator.sshOpts != tt.wantOpts {
+				t.Errorf("オプション = %+v, want %+v", generator.sshOpts, tt.wantOpts)
+			}
+			if rr.Header().Get("Cache-Control") != "no-store" {
+				t.Errorf("Cache-Control = %q, want no-store", rr.Header().Get("Cache-Control"))
+			}
+			var pair keys.SSHKeyPair
+			if err := json.Unmarshal(rr.Body.Bytes(), &pair); err != nil || pair.PrivateKey != "PRIVATE" {
+				t.Errorf("レスポンス = %s, %v", rr.Body.String(), err)
+			}
+		})
+	}
+}
+
+func TestKeyHandler_HandleWireGuard(t *testing.T) {
+	for _, psk := range []string{"", "true"} {
+		generator := &MockKeyGenerator{}
+		h := NewKeyHandler(generator)
+
+		form := url.Values{"presharedKey": {psk}}
+		req := httptest.NewRequest(http.MethodPost, "/api/keys/wireguard", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rr := httptest.NewRecorder()
+		h.HandleWireGuard(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("ステータスコード = %d: %s", rr.Code, rr.Body.String())
+		}
+		if generator.presharedKey != (psk == "true") {
+			t.Errorf("presharedKey = %v, want %v", generator.presharedKey, psk == "true")
+		}
+		var wgKeys keys.WireGuardKeys
+		if err := json.Unmarshal(rr.Body.Bytes(), &wgKeys); err != nil || wgKeys.PublicKey != "PUBLIC" {
+			t.Errorf("レスポンス = %s, %v", rr.Body.String(), err)
+		}
+	}
+}
+
+// 鍵素材の乱数源がヘルステストに失敗した場合は503を返す
+func TestKeyHandler_HandleWireGuardUnhealthy(t *testing.T) {
+	stuck := health.NewMonitor(bytes.NewReader(make([]byte, 1024)))
+	h := NewKeyHandler(keys.NewWithReader(&MockPasswordGenerator{}, stuck))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/keys/wireguard", strings.NewReader(""))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rr := httptest.NewRecorder()
+	h.HandleWireGuard(rr, req)
+
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("ステータスコード = %d, want %d: %s", rr.Code, http.StatusServiceUnavailable, rr.Body.String())
+	}
+}
+
+func TestKeyHandler_HandleJWK(t *testing.T) {
+	tests := []struct {
+		name       string
+		formData   url.Values
+		wantStatus int
+		wantOpts   keys.JWKOptions
+	}{
+		{
+			name:       "ES256",
+			formData:   url.Values{"alg": {"es256"}},
+			wantStatus: http.StatusOK,
+			wantOpts:   keys.JWKOptions{Algorithm: keys.JWKES256},
+		},
+		{
+			name:       "RSAのビット数指定",
+			formData:   url.Values{"alg": {"RS256"}, "bits": {"4096"}},
+			wantStatus: http.StatusOK,
+			wantOpts:   keys.JWKOptions{Algorithm: keys.JWKRS256, Bits: 4096},
+		},
+		{
+			name:       "アルゴリズム未指定",
+			formData:   url.Values{},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "無効なビット数",
+			formData:   url.Values{"alg": {"RS256"}, "bits": {"-1"}},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generator := &MockKeyGenerator{}
+			h := NewKeyHandler(generator)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/jwk", strings.NewReader(tt.formData.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			rr := httptest.NewRecorder()
+			h.HandleJWK(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("ステータスコード = %d, want %d: %s", rr.Code, tt.wantStatus, rr.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if generator.jwkOpts != tt.wantOpts {
+				t.Errorf("オプション = %+v, want %+v", generator.jwkOpts, tt.wantOpts)
+			}
+
+			var resp struct {
+				JWK       keys.JWK    `json:"jwk"`
+				PublicJWK keys.JWK    `json:"publicJwk"`
+				JWKS      keys.JWKSet `json:"jwks"`
+			}
+			if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("JSONの解析に失敗: %v", err)
+			}
+			if resp.JWK.D != "D" || resp.PublicJWK.D != "" || len(resp.JWKS.Keys) != 1 {
+				t.Errorf("レスポンス = %s", rr.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/okamyuji/PasswordGenerator/internal/otp"
+	"github.com/okamyuji/PasswordGenerator/internal/qrcode"
+)
+
+// OTPシード生成のコントラクトを定義するインターフェース
+type OTPGeneratorInterface interface {
+	Generate(opts otp.Options) (*otp.Key, error)
+}
+
+// OTPシード生成のレスポンス
+type otpResponse struct {
+	Type      otp.Type      `json:"type"`
+	Secret    string        `json:"secret"`
+	URI       string        `json:"uri"`
+	Algorithm otp.Algorithm `json:"algorithm"`
+	Digits    int           `json:"digits"`
+	Period    int           `json:"period,omitempty"`
+	Counter   *uint64       `json:"counter,omitempty"`
+	QRCodePNG string        `json:"qrCodePng"` // data URI
+	QRCodeSVG string        `json:"qrCodeSvg"`
+}
+
+// OTP検証のレスポンス
+type otpVerifyResponse struct {
+	Valid       bool    `json:"valid"`
+	NextCounter *uint64 `json:"nextCounter,omitempty"` // HOTPで一致した場合の次のカウンター
+}
+
+// MFA登録用のTOTP/HOTPシードを生成・検証するハンドラー
+type OTPHandler struct {
+	generator OTPGeneratorInterface
+	now       func() time.Time
+}
+
+// 依存性注入を使用して新しいOTPHandlerを作成
+func NewOTPHandler(generator OTPGeneratorInterface) *OTPHandler {
+	return &OTPHandler{generator: generator, now: time.Now}
+}
+
+// シードとotpauth:// URI、QRコードを生成
+func (h *OTPHandler) HandleGenerate(w http.ResponseWriter, r *http.Request) {
+	if !parsePostForm(w, r) {
+		return
+	}
+
+	opts, err := otpOptionsFromForm(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	opts.Issuer = strings.TrimSpace(r.Form.Get("issuer"))
+	opts.Account = strings.TrimSpace(r.Form.Get("account"))
+
+	key, err := h.generator.Generate(opts)
+	if err != nil {
+		writeGenerationError(w, err)
+		return
+	}
+
+	code, err := qrcode.Encode(key.URI)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp := otpResponse{
+		Type:      key.Type,
+		Secret:    key.Secret,
+		URI:       key.URI,
+		Algorithm: key.Algorithm,
+		Digits:    key.Digits,
+		QRCodePNG: "data:image/png;base64," + base64.StdEncoding.EncodeToString(code.PNG(qrcode.DefaultScale)),
+		QRCodeSVG: code.SVG(),
+	}
+	if key.Type == otp.TypeHOTP {
+		resp.Counter = &key.Counter
+	} else {
+		resp.Period = key.Period
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, resp)
+}
+
+// 認証アプリに登録されたシードから算出されたコードを検証
+func (h *OTPHandler) HandleVerify(w http.ResponseWriter, r *http.Request) {
+	if !parsePostForm(w, r) {
+		return
+	}
+
+	opts, err := otpOptionsFromForm(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	valid, next, err := otp.Verify(r.Form.Get("secret"), r.Form.Get("code"), opts, h.now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp := otpVerifyResponse{Valid: valid}
+	if valid && opts.Type == otp.TypeHOTP {
+		resp.NextCounter = &next
+	}
+	writeJSON(w, resp)
+}
+
+// 生成と検証で共通のOTPパラメータをフォームから取得
+func otpOptionsFromForm(r *http.Request) (otp.Options, error) {
+	opts := otp.Options{
+		Type:      otp.Type(strings.ToLower(strings.TrimSpace(r.Form.Get("type")))),
+		Algorithm: otp.Algorithm(strings.ToUpper(strings.TrimSpace(r.Form.Get("algorithm")))),
+	}
+	var err error
+	if opts.Digits, err = formInt(r, "digits"); err != nil {
+		return opts, err
+	}
+	if opts.Period, err = formInt(r, "period"); err != nil {
+		return opts, err
+	}
+	if v := r.Form.Get("counter"); v != "" {
+		if opts.Counter, err = strconv.ParseUint(v, 10, 64); err != nil {
+			return opts, fmt.Errorf("無効なcounter: %s", v)
+		}
+	}
+	return opts, nil
+}
+
+// POSTメソッドとフォームを検証（失敗時はレスポンスを書き込んでfalseを返す）
+func parsePostForm(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method != http.MethodPost {
+		http.Error(w, "メソッドは許可されていません", http.StatusMethodNotAllowed)
+		return false
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "無効なフォームデータ", http.StatusBadRequest)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/okamyuji/PasswordGenerator/internal/otp"
+)
+
+func TestOTPHandler_HandleGenerate(t *testing.T) {
+	h := NewOTPHandler(otp.New())
+
+	tests := []struct {
+		name       string
+		method     string
+		formData   url.Values
+		wantStatus int
+	}{
+		{
+			name:       "TOTP",
+			method:     http.MethodPost,
+			formData:   url.Values{"issuer": {"Acme"}, "account": {"alice"}},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "HOTP",
+			method:     http.MethodPost,
+			formData:   url.Values{"type": {"hotp"}, "account": {"alice"}, "counter": {"3"}},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "アカウントなし",
+			method:     http.MethodPost,
+			formData:   url.Values{},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "不正な桁数",
+			method:     http.MethodPost,
+			formData:   url.Values{"account": {"alice"}, "digits": {"x"}},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "GETは許可されない",
+			method:     http.MethodGet,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/api/totp", strings.NewReader(tt.formData.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			rec := httptest.NewRecorder()
+
+			h.HandleGenerate(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("OTPHandler.HandleGenerate() status = %v, want %v: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			if rec.Header().Get("Cache-Control") != "no-store" {
+				t.Error("OTPHandler.HandleGenerate() Cache-Control: no-store が設定されていません")
+			}
+
+			var got otpResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatalf("レスポンスのデコードに失敗: %v", err)
+			}
+			if got.Secret == "" || !strings.Contains(got.URI, "secret="+got.Secret) {
+				t.Errorf("シークレットとURIが一致しません: %+v", got)
+			}
+			if !strings.HasPrefix(got.QRCodePNG, "data:image/png;base64,") || !strings.HasPrefix(got.QRCodeSVG, "<svg") {
+				t.Errorf("QRコードがありません: %+v", got)
+			}
+			if (got.Type == otp.TypeHOTP) != (got.Counter != nil) {
+				t.Errorf("HOTPのカウンターが不正: %+v", got)
+			}
+		})
+	}
+}
+
+func TestOTPHandler_HandleVerify(t *testing.T) {
+	const secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ" // "12345678901234567890"
+	h := NewOTPHandler(otp.New())
+	h.now = func() time.Time { return time.Unix(59, 0) }
+
+	tests := []struct {
+		name        string
+		formData    url.Values
+		wantStatus  int
+		wantValid   bool
+		wantCounter uint64
+	}{
+		{
+			name:       "TOTP - 一致",
+			formData:   url.Values{"secret": {secret}, "code": {"94287082"}, "digits": {"8"}},
+			wantStatus: http.StatusOK,
+			wantValid:  true,
+		},
+		{
+			name:       "TOTP - 不一致",
+			formData:   url.Values{"secret": {secret}, "code": {"00000000"}, "digits": {"8"}},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:        "HOTP - 一致",
+			formData:    url.Values{"secret": {secret}, "code": {"287082"}, "type": {"hotp"}},
+			wantStatus:  http.StatusOK,
+			wantValid:   true,
+			wantCounter: 2,
+		},
+		{
+			name:       "不正なシークレット",
+			formData:   url.Values{"secret": {"!!!"}, "code": {"123456"}},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "HOTP - 上限を超えるカウンター",
+			formData:   url.Values{"secret": {secret}, "code": {"287082"}, "type": {"hotp"}, "counter": {"18446744073709551615"}},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/totp/verify", strings.NewReader(tt.formData.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			rec := httptest.NewRecorder()
+
+			h.HandleVerify(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("OTPHandler.HandleVerify() status = %v, want %v: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var got otpVerifyResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatalf("レスポンスのデコードに失敗: %v", err)
+			}
+			if got.Valid != tt.wantValid {
+				t.Errorf("valid = %v, want %v", got.Valid, tt.wantValid)
+			}
+			if tt.wantCounter != 0 && (got.NextCounter == nil || *got.NextCounter != tt.wantCounter) {
+				t.Errorf("nextCounter = %v, want %d", got.NextCounter, tt.wantCounter)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"embed"
+	"errors"
 	"html/template"
 	"log/slog"
 	"net/http"
@@ -10,11 +11,14 @@ import (
 	"strings"
 
 	"github.com/okamyuji/PasswordGenerator/internal/config"
+	"github.com/okamyuji/PasswordGenerator/internal/encrypt"
+	"github.com/okamyuji/PasswordGenerator/internal/format"
+	"github.com/okamyuji/PasswordGenerator/internal/secret"
 )
 
 // パスワード生成のコントラクトを定義するインターフェース
 type PasswordGeneratorInterface interface {
-	Generate(cfg config.PasswordConfig) (string, error)
+	Generate(cfg config.PasswordConfig) (*secret.Secret, error)
 }
 
 // テンプレートレンダリングを抽象化するインターフェース
@@ -93,31 +97,130 @@ func (h *PasswordHandler) handlePost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	pwdConfig, err := passwordConfigFromForm(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// formatパラメータまたはAcceptヘッダーで出力フォーマットを選択
+	w.Header().Add("Vary", "Accept")
+	outFormat, err := format.Negotiate(r.Form.Get("format"), r.Header.Get("Accept"))
+	if errors.Is(err, format.ErrNotAcceptable) {
+		http.Error(w, err.Error(), http.StatusNotAcceptable)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	opts, err := formatOptionsFromForm(r, outFormat)
+	if errors.Is(err, errSealKeyNotConfigured) {
+		slog.Error("封印ドキュメントを生成できません", "error", err)
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// 受信者が指定された場合は平文を返さず、暗号文のみを返す
+	recipients, err := encrypt.ParseRecipients(r.Form["recipient"]...)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	out, err := format.Encode(h.generator, pwdConfig, outFormat, opts)
+	if err != nil {
+		writeGenerationError(w, err)
+		return
+	}
+	defer out.Destroy()
+	setWarningHeader(w, out.Warning)
+
+	if len(recipients) > 0 {
+		writeEncryptedOutput(w, outFormat, out, recipients)
+		return
+	}
+	writeFormatOutput(w, outFormat, out)
+}
+
+// フォームの値からパスワード設定を作成
+func passwordConfigFromForm(r *http.Request) (config.PasswordConfig, error) {
 	length, _ := strconv.Atoi(r.FormValue("length"))
 	// 長さのバリデーション
 	if length <= 0 {
-		http.Error(w, "無効な長さ", http.StatusBadRequest)
-		return
+		return config.PasswordConfig{}, errors.New("無効な長さ")
+	}
+
+	cfg, err := passwordOptionsFromForm(r)
+	if err != nil {
+		return config.PasswordConfig{}, err
 	}
+	cfg.Length = length
+	return cfg, nil
+}
 
-	pwdConfig := config.PasswordConfig{
-		Length:        length,
+// フォームから長さ以外の生成オプションを読み取る
+func passwordOptionsFromForm(r *http.Request) (config.PasswordConfig, error) {
+	symbolProfile, err := config.ParseSymbolProfile(r.Form.Get("symbolProfile"))
+	if err != nil {
+		return config.PasswordConfig{}, err
+	}
+	unicodeBlocks, err := config.ParseUnicodeBlocks(r.Form["unicodeBlocks"]...)
+	if err != nil {
+		return config.PasswordConfig{}, err
+	}
+	keyboardLayouts, err := config.ParseKeyboardLayouts(r.Form["keyboardLayouts"]...)
+	if err != nil {
+		return config.PasswordConfig{}, err
+	}
+	rules, err := rulesFromForm(r)
+	if err != nil {
+		return config.PasswordConfig{}, err
+	}
+
+	return config.PasswordConfig{
 		UseUppercase:  r.Form.Get("uppercase") == "true",
 		UseLowercase:  r.Form.Get("lowercase") == "true",
 		UseNumbers:    r.Form.Get("numbers") == "true",
 		UseSymbols:    r.Form.Get("symbols") == "true",
 		CustomSymbols: strings.TrimSpace(r.Form.Get("customSymbols")),
-	}
+		SymbolProfile: symbolProfile,
 
-	password, err := h.generator.Generate(pwdConfig)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
-	}
+		UseHiragana:        r.Form.Get("hiragana") == "true",
+		UseKatakana:        r.Form.Get("katakana") == "true",
+		UseFullWidthDigits: r.Form.Get("fullWidthDigits") == "true",
+		UnicodeBlocks:      unicodeBlocks,
 
-	w.Header().Set("Content-Type", "text/plain")
-	if _, err := w.Write([]byte(password)); err != nil {
-		slog.Error("パスワードの書き込みに失敗", "error", err)
-		http.Error(w, "内部サーバーエラー", http.StatusInternalServerError)
+		KeyboardLayouts:      keyboardLayouts,
+		MinimizeLayerChanges: r.Form.Get("minimizeLayerChanges") == "true",
+
+		Rules: rules,
+	}, nil
+}
+
+// フォームから構造の規則を読み取る（禁止する文字列は繰り返し・カンマ・改行区切りで指定）
+func rulesFromForm(r *http.Request) (config.Rules, error) {
+	var rules config.Rules
+	var err error
+	if rules.MaxRepeat, err = formInt(r, "maxRepeat"); err != nil {
+		return config.Rules{}, err
+	}
+	if rules.MaxSequence, err = formInt(r, "maxSequence"); err != nil {
+		return config.Rules{}, err
+	}
+	if rules.FirstClass, err = config.ParseCharClass(r.Form.Get("firstClass")); err != nil {
+		return config.Rules{}, err
+	}
+	if rules.LastClass, err = config.ParseCharClass(r.Form.Get("lastClass")); err != nil {
+		return config.Rules{}, err
+	}
+	for _, v := range r.Form["forbidden"] {
+		rules.Forbidden = append(rules.Forbidden, splitList(v)...)
 	}
+	return rules, nil
 }
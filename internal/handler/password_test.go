@@ -10,18 +10,23 @@ import (
 	"strings"
 	"testing"
 
+	"filippo.io/age"
+
 	"github.com/okamyuji/PasswordGenerator/internal/config"
+	"github.com/okamyuji/PasswordGenerator/internal/encrypt"
+	"github.com/okamyuji/PasswordGenerator/internal/format"
+	"github.com/okamyuji/PasswordGenerator/internal/secret"
 )
 
 // モックPasswordGeneratorの作成
 type MockPasswordGenerator struct{}
 
-func (m *MockPasswordGenerator) Generate(cfg config.PasswordConfig) (string, error) {
+func (m *MockPasswordGenerator) Generate(cfg config.PasswordConfig) (*secret.Secret, error) {
 	// テスト用のパスワード生成ロジック
 	if cfg.Length <= 0 {
-		return "", fmt.Errorf("invalid length")
+		return nil, fmt.Errorf("invalid length")
 	}
-	return strings.Repeat("A", cfg.Length), nil
+	return secret.FromString(strings.Repeat("A", cfg.Length)), nil
 }
 
 // モックTemplateRendererの作成
@@ -91,6 +96,46 @@ func TestPasswordHandler_Handle(t *testing.T) {
 			},
 			wantStatus: http.StatusBadRequest,
 		},
+		{
+			name:   "POST request - invalid symbol profile",
+			method: http.MethodPost,
+			formData: url.Values{
+				"length":        {"12"},
+				"symbols":       {"true"},
+				"symbolProfile": {"csv"},
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "POST request - invalid keyboard layout",
+			method: http.MethodPost,
+			formData: url.Values{
+				"length":          {"12"},
+				"lowercase":       {"true"},
+				"keyboardLayouts": {"us", "dvorak"},
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "POST request - invalid first class",
+			method: http.MethodPost,
+			formData: url.Values{
+				"length":     {"12"},
+				"lowercase":  {"true"},
+				"firstClass": {"kanji"},
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "POST request - invalid max repeat",
+			method: http.MethodPost,
+			formData: url.Values{
+				"length":    {"12"},
+				"lowercase": {"true"},
+				"maxRepeat": {"-1"},
+			},
+			wantStatus: http.StatusBadRequest,
+		},
 		{
 			name:       "Invalid method",
 			method:     http.MethodPut,
@@ -130,3 +175,235 @@ func TestPasswordHandler_Handle(t *testing.T) {
 		})
 	}
 }
+
+func TestPasswordHandler_Format(t *testing.T) {
+	h := NewPasswordHandler(&MockTemplateRenderer{}, &MockPasswordGenerator{})
+
+	tests := []struct {
+		name            string
+		formData        url.Values
+		accept          string
+		wantStatus      int
+		wantContentType string
+		wantContains    string
+	}{
+		{
+			name:            "既定はテキスト",
+			formData:        url.Values{"length": {"4"}},
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/plain",
+			wantContains:    "AAAA",
+		},
+		{
+			name:            "formatパラメータ - .env",
+			formData:        url.Values{"length": {"4"}, "format": {"env"}, "count": {"2"}, "keyTemplate": {"KEY_{index}"}},
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/x-env",
+			wantContains:    "KEY_2='AAAA'",
+		},
+		{
+			name:            "Acceptヘッダー - Kubernetes",
+			formData:        url.Values{"length": {"4"}, "secretName": {"app"}},
+			accept:          "application/yaml",
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/yaml",
+			wantContains:    "kind: Secret",
+		},
+		{
+			name:            "htpasswdは平文の認証情報とともにJSONで返す",
+			formData:        url.Values{"length": {"4"}, "format": {"htpasswd"}, "usernames": {"alice, bob"}, "htpasswdAlgorithm": {"sha"}},
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json",
+			wantContains:    `{"username":"bob","password":"AAAA"}`,
+		},
+		{
+			name:            "htpasswd - ユーザー数の上限",
+			formData:        url.Values{"length": {"4"}, "format": {"htpasswd"}, "usernames": {testUsernames(format.MaxCredentialCount)}, "htpasswdAlgorithm": {"sha"}},
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json",
+		},
+		{
+			name:       "htpasswd - ユーザー数の上限超過",
+			formData:   url.Values{"length": {"4"}, "format": {"htpasswd"}, "usernames": {testUsernames(format.MaxCredentialCount + 1)}, "htpasswdAlgorithm": {"sha"}},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "LDIF - ユーザー数の上限超過",
+			formData:   url.Values{"length": {"4"}, "format": {"ldif"}, "usernames": {testUsernames(format.MaxCredentialCount + 1)}, "baseDN": {"dc=example,dc=com"}},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Acceptヘッダー - 一致なし",
+			formData:   url.Values{"length": {"4"}},
+			accept:     "application/pdf",
+			wantStatus: http.StatusNotAcceptable,
+		},
+		{
+			name:       "未対応のformat",
+			formData:   url.Values{"length": {"4"}, "format": {"xml"}},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "封印鍵が未設定",
+			formData:   url.Values{"length": {"4"}, "format": {"sealed"}},
+			wantStatus: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("SEAL_KEY", "")
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.formData.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+
+			h.Handle(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("PasswordHandler.Handle() status = %v, want %v: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if rec.Code == http.StatusOK && rec.Header().Get("Cache-Control") != "no-store" {
+				t.Error("PasswordHandler.Handle() Cache-Control: no-store が設定されていません")
+			}
+			if tt.wantContentType != "" && rec.Header().Get("Content-Type") != tt.wantContentType {
+				t.Errorf("PasswordHandler.Handle() Content-Type = %v, want %v", rec.Header().Get("Content-Type"), tt.wantContentType)
+			}
+			if tt.wantContains != "" && !strings.Contains(rec.Body.String(), tt.wantContains) {
+				t.Errorf("PasswordHandler.Handle() response does not contain %q: %s", tt.wantContains, rec.Body.String())
+			}
+		})
+	}
+}
+
+// カンマ区切りのn人分のユーザー名
+func testUsernames(n int) string {
+	names := make([]string, n)
+	for i := range names {
+		names[i] = fmt.Sprintf("user%d", i+1)
+	}
+	return strings.Join(names, ",")
+}
+
+// ASCIIのみを前提とする用途での警告は、JSON以外のフォーマットでもヘッダーで返す
+func TestPasswordHandler_Warning(t *testing.T) {
+	h := NewPasswordHandler(&MockTemplateRenderer{}, &MockPasswordGenerator{})
+
+	tests := []struct {
+		name        string
+		formData    url.Values
+		wantWarning bool
+	}{
+		{
+			name:        ".env - URLプロファイルとひらがな",
+			formData:    url.Values{"length": {"4"}, "symbolProfile": {"url"}, "hiragana": {"true"}, "format": {"env"}},
+			wantWarning: true,
+		},
+		{
+			name:        "テキスト - URLプロファイルとひらがな",
+			formData:    url.Values{"length": {"4"}, "symbolProfile": {"url"}, "hiragana": {"true"}},
+			wantWarning: true,
+		},
+		{
+			name:     ".env - ASCIIのみ",
+			formData: url.Values{"length": {"4"}, "symbolProfile": {"url"}, "lowercase": {"true"}, "format": {"env"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.formData.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			rec := httptest.NewRecorder()
+
+			h.Handle(rec, req)
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("PasswordHandler.Handle() status = %v: %s", rec.Code, rec.Body.String())
+			}
+			header := rec.Header().Get(warningHeader)
+			if !tt.wantWarning {
+				if header != "" {
+					t.Errorf("%s = %q, want 空", warningHeader, header)
+				}
+				return
+			}
+			warning, err := url.PathUnescape(header)
+			if err != nil {
+				t.Fatalf("%s のデコードに失敗: %v", warningHeader, err)
+			}
+			if !strings.Contains(warning, "記号プロファイル url") {
+				t.Errorf("%s = %q, 警告が含まれていません", warningHeader, warning)
+			}
+		})
+	}
+}
+
+func TestPasswordHandler_Recipient(t *testing.T) {
+	h := NewPasswordHandler(&MockTemplateRenderer{}, &MockPasswordGenerator{})
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		formData   url.Values
+		wantStatus int
+		wantPlain  string
+	}{
+		{
+			name:       "テキスト",
+			formData:   url.Values{"length": {"4"}, "recipient": {identity.Recipient().String()}},
+			wantStatus: http.StatusOK,
+			wantPlain:  "AAAA",
+		},
+		{
+			name:       "htpasswdは認証情報を含むJSONを暗号化",
+			formData:   url.Values{"length": {"4"}, "format": {"htpasswd"}, "usernames": {"alice"}, "htpasswdAlgorithm": {"sha"}, "recipient": {identity.Recipient().String()}},
+			wantStatus: http.StatusOK,
+			wantPlain:  `{"username":"alice","password":"AAAA"}`,
+		},
+		{
+			name:       "無効な受信者",
+			formData:   url.Values{"length": {"4"}, "recipient": {"ssh-rsa AAAA"}},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.formData.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			rec := httptest.NewRecorder()
+
+			h.Handle(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("PasswordHandler.Handle() status = %v, want %v: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if got := rec.Header().Get("Content-Type"); got != encryptedContentType {
+				t.Errorf("PasswordHandler.Handle() Content-Type = %v, want %v", got, encryptedContentType)
+			}
+			if rec.Header().Get("Cache-Control") != "no-store" {
+				t.Error("PasswordHandler.Handle() Cache-Control: no-store が設定されていません")
+			}
+			if strings.Contains(rec.Body.String(), "AAAA") {
+				t.Errorf("PasswordHandler.Handle() レスポンスに平文が含まれています: %s", rec.Body.String())
+			}
+
+			plaintext, err := encrypt.Decrypt(rec.Body.Bytes(), []age.Identity{identity})
+			if err != nil {
+				t.Fatalf("Decrypt() エラー = %v", err)
+			}
+			if !strings.Contains(string(plaintext), tt.wantPlain) {
+				t.Errorf("復号結果 = %s, want %q", plaintext, tt.wantPlain)
+			}
+		})
+	}
+}
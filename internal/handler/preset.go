@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/okamyuji/PasswordGenerator/internal/preset"
+)
+
+// フレームワークのシークレットキー生成のコントラクトを定義するインターフェース
+type FrameworkSecretGeneratorInterface interface {
+	Generate(f preset.Framework) (*preset.Secret, error)
+}
+
+// フレームワークのシークレットキー生成のレスポンス
+type frameworkSecretResponse struct {
+	*preset.Secret
+	EnvLine string `json:"envLine"`
+}
+
+// Django・Rails・Laravelなどのフレームワーク用シークレットキーを生成するハンドラー
+type PresetHandler struct {
+	generator FrameworkSecretGeneratorInterface
+}
+
+// 依存性注入を使用して新しいPresetHandlerを作成
+func NewPresetHandler(generator FrameworkSecretGeneratorInterface) *PresetHandler {
+	return &PresetHandler{generator: generator}
+}
+
+func (h *PresetHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if !parsePostForm(w, r) {
+		return
+	}
+
+	f, err := preset.ParseFramework(r.Form.Get("framework"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	secret, err := h.generator.Generate(f)
+	if err != nil {
+		writeGenerationError(w, err)
+		return
+	}
+	line, err := secret.EnvLine()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, frameworkSecretResponse{Secret: secret, EnvLine: line})
+}
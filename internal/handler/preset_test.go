@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/okamyuji/PasswordGenerator/internal/preset"
+)
+
+// 受け取ったフレームワークを記録するモックFrameworkSecretGenerator
+type MockFrameworkSecretGenerator struct {
+	framework preset.Framework
+}
+
+func (m *MockFrameworkSecretGenerator) Generate(f preset.Framework) (*preset.Secret, error) {
+	m.framework = f
+	return &preset.Secret{Framework: f, EnvName: "APP_KEY", Value: "base64:KEY"}, nil
+}
+
+func TestPresetHandler_Handle(t *testing.T) {
+	tests := []struct {
+		name          string
+		method        string
+		formData      url.Values
+		wantStatus    int
+		wantFramework preset.Framework
+	}{
+		{
+			name:          "Laravel",
+			method:        http.MethodPost,
+			formData:      url.Values{"framework": {"Laravel"}},
+			wantStatus:    http.StatusOK,
+			wantFramework: preset.FrameworkLaravel,
+		},
+		{
+			name:       "フレームワーク未指定",
+			method:     http.MethodPost,
+			formData:   url.Values{},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "未対応のフレームワーク",
+			method:     http.MethodPost,
+			formData:   url.Values{"framework": {"spring"}},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "GETは許可されない",
+			method:     http.MethodGet,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generator := &MockFrameworkSecretGenerator{}
+			h := NewPresetHandler(generator)
+
+			req := httptest.NewRequest(tt.method, "/api/framework-secret", strings.NewReader(tt.formData.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			rr := httptest.NewRecorder()
+			h.Handle(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("ステータスコード = %d, want %d: %s", rr.Code, tt.wantStatus, rr.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if generator.framework != tt.wantFramework {
+				t.Errorf("framework = %v, want %v", generator.framework, tt.wantFramework)
+			}
+
+			var resp map[string]string
+			if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("JSONの解析に失敗: %v", err)
+			}
+			want := map[string]string{
+				"framework":   "laravel",
+				"envName":     "APP_KEY",
+				"secret":      "base64:KEY",
+				"description": "",
+				"envLine":     "APP_KEY='base64:KEY'\n",
+			}
+			for k, v := range want {
+				if resp[k] != v {
+					t.Errorf("%s = %q, want %q", k, resp[k], v)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/okamyuji/PasswordGenerator/internal/config"
+	"github.com/okamyuji/PasswordGenerator/internal/qrcode"
+	"github.com/okamyuji/PasswordGenerator/internal/wifi"
+)
+
+// PNGの1モジュールあたりの最大ピクセル数
+const maxQRCodeScale = 32
+
+// QRコードのJSONレスポンス
+type qrCodeResponse struct {
+	Password  string `json:"password"`
+	Payload   string `json:"payload"`   // QRコードに埋め込んだ文字列
+	QRCodePNG string `json:"qrCodePng"` // data URI
+	QRCodeSVG string `json:"qrCodeSvg"`
+}
+
+// 生成したパスワードやWi-Fiの接続情報をQRコードとして返すハンドラー
+type QRCodeHandler struct {
+	generator PasswordGeneratorInterface
+}
+
+// 依存性注入を使用して新しいQRCodeHandlerを作成
+func NewQRCodeHandler(generator PasswordGeneratorInterface) *QRCodeHandler {
+	return &QRCodeHandler{generator: generator}
+}
+
+// パスワードを生成し、そのまま（mode=secret）またはWIFI:形式（mode=wifi）でQRコードにする
+func (h *QRCodeHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if !parsePostForm(w, r) {
+		return
+	}
+
+	mode := strings.ToLower(strings.TrimSpace(r.Form.Get("mode")))
+	var cfg config.PasswordConfig
+	var err error
+	switch mode {
+	case "", "secret":
+		cfg, err = passwordConfigFromForm(r)
+	case "wifi":
+		// 長さを省略した場合はwifi.PassphraseConfigの既定値を使う
+		if cfg, err = passwordOptionsFromForm(r); err == nil {
+			cfg.Length, err = formInt(r, "length")
+		}
+	default:
+		http.Error(w, fmt.Sprintf("未対応のモード: %s (secret / wifi)", mode), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	outFormat := strings.ToLower(strings.TrimSpace(r.Form.Get("format")))
+	switch outFormat {
+	case "":
+		outFormat = "png"
+	case "png", "svg", "json":
+	default:
+		http.Error(w, fmt.Sprintf("未対応のフォーマット: %s (png / svg / json)", r.Form.Get("format")), http.StatusBadRequest)
+		return
+	}
+	scale, err := formInt(r, "scale")
+	if err != nil || scale > maxQRCodeScale {
+		http.Error(w, fmt.Sprintf("無効なscale: %s (1〜%d)", r.Form.Get("scale"), maxQRCodeScale), http.StatusBadRequest)
+		return
+	}
+
+	var network *wifi.Network
+	if mode == "wifi" {
+		// WPA2パスフレーズとして有効な長さと文字だけで生成する
+		if cfg, err = wifi.PassphraseConfig(cfg); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		network = &wifi.Network{SSID: r.Form.Get("ssid"), Hidden: r.Form.Get("hidden") == "true"}
+		if err := wifi.ValidateSSID(network.SSID); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	password, err := h.generator.Generate(cfg)
+	if err != nil {
+		writeGenerationError(w, err)
+		return
+	}
+	defer password.Destroy()
+
+	// パスワードはQRコードとレスポンスのためにここでのみ文字列に変換する
+	value := password.Reveal()
+	payload := value
+	if network != nil {
+		network.Passphrase = value
+		if payload, err = network.Payload(); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	code, err := qrcode.Encode(payload)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	switch outFormat {
+	case "svg":
+		w.Header().Set("Content-Type", "image/svg+xml")
+		if _, err := io.WriteString(w, code.SVG()); err != nil {
+			slog.Error("レスポンスの書き込みに失敗", "error", err)
+		}
+	case "json":
+		writeJSON(w, qrCodeResponse{
+			Password:  value,
+			Payload:   payload,
+			QRCodePNG: "data:image/png;base64," + base64.StdEncoding.EncodeToString(code.PNG(scale)),
+			QRCodeSVG: code.SVG(),
+		})
+	default:
+		w.Header().Set("Content-Type", "image/png")
+		if _, err := w.Write(code.PNG(scale)); err != nil {
+			slog.Error("レスポンスの書き込みに失敗", "error", err)
+		}
+	}
+}
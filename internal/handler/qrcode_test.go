@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/okamyuji/PasswordGenerator/internal/wifi"
+)
+
+func TestQRCodeHandler_Handle(t *testing.T) {
+	h := NewQRCodeHandler(&MockPasswordGenerator{})
+
+	tests := []struct {
+		name            string
+		form            url.Values
+		wantStatus      int
+		wantContentType string
+		wantContains    string
+	}{
+		{
+			name:            "既定はPNG",
+			form:            url.Values{"length": {"12"}, "uppercase": {"true"}},
+			wantStatus:      http.StatusOK,
+			wantContentType: "image/png",
+			wantContains:    "\x89PNG",
+		},
+		{
+			name:            "SVG",
+			form:            url.Values{"length": {"12"}, "uppercase": {"true"}, "format": {"svg"}},
+			wantStatus:      http.StatusOK,
+			wantContentType: "image/svg+xml",
+			wantContains:    "<svg",
+		},
+		{
+			name:            "Wi-Fi",
+			form:            url.Values{"length": {"12"}, "uppercase": {"true"}, "format": {"json"}, "mode": {"wifi"}, "ssid": {"Guest;1"}, "hidden": {"true"}},
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json",
+			wantContains:    `"payload":"WIFI:T:WPA;S:Guest\\;1;P:AAAAAAAAAAAA;H:true;;"`,
+		},
+		{
+			name:            "Wi-Fi - 長さを省略すると既定の長さ",
+			form:            url.Values{"uppercase": {"true"}, "format": {"json"}, "mode": {"wifi"}, "ssid": {"Guest"}},
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json",
+			wantContains:    `"password":"` + strings.Repeat("A", wifi.DefaultPassphraseLength) + `"`,
+		},
+		{name: "Wi-Fiで不正な長さ", form: url.Values{"length": {"abc"}, "mode": {"wifi"}, "ssid": {"Guest"}}, wantStatus: http.StatusBadRequest},
+		{name: "長さなし", form: url.Values{"uppercase": {"true"}}, wantStatus: http.StatusBadRequest},
+		{name: "Wi-FiでSSIDなし", form: url.Values{"length": {"12"}, "mode": {"wifi"}}, wantStatus: http.StatusBadRequest},
+		{name: "WPA2には長すぎる", form: url.Values{"length": {"64"}, "mode": {"wifi"}, "ssid": {"Guest"}}, wantStatus: http.StatusBadRequest},
+		{name: "WPA2には短すぎる", form: url.Values{"length": {"7"}, "mode": {"wifi"}, "ssid": {"Guest"}}, wantStatus: http.StatusBadRequest},
+		{name: "未対応のモード", form: url.Values{"length": {"12"}, "mode": {"vcard"}}, wantStatus: http.StatusBadRequest},
+		{name: "未対応のフォーマット", form: url.Values{"length": {"12"}, "format": {"gif"}}, wantStatus: http.StatusBadRequest},
+		{name: "scaleが大きすぎる", form: url.Values{"length": {"12"}, "scale": {"33"}}, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := postForm(h.Handle, "/api/qrcode", tt.form)
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rr.Code, tt.wantStatus, rr.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if got := rr.Header().Get("Content-Type"); got != tt.wantContentType {
+				t.Errorf("Content-Type = %q, want %q", got, tt.wantContentType)
+			}
+			if rr.Header().Get("Cache-Control") != "no-store" {
+				t.Error("Cache-Control: no-store が設定されていません")
+			}
+			if !bytes.Contains(rr.Body.Bytes(), []byte(tt.wantContains)) {
+				t.Errorf("レスポンスに %q が含まれていません: %.200s", tt.wantContains, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestQRCodeHandler_JSON(t *testing.T) {
+	h := NewQRCodeHandler(&MockPasswordGenerator{})
+	rr := postForm(h.Handle, "/api/qrcode", url.Values{"length": {"8"}, "format": {"json"}})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var resp qrCodeResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("JSONの解析に失敗: %v", err)
+	}
+	if resp.Password != "AAAAAAAA" || resp.Payload != resp.Password {
+		t.Errorf("password = %q, payload = %q", resp.Password, resp.Payload)
+	}
+	if !strings.HasPrefix(resp.QRCodePNG, "data:image/png;base64,") || !strings.HasPrefix(resp.QRCodeSVG, "<svg") {
+		t.Error("QRコードの画像が含まれていません")
+	}
+}
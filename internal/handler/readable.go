@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"math"
+	"net/http"
+
+	"github.com/okamyuji/PasswordGenerator/internal/phonetic"
+)
+
+// 読み上げ用の表現を含むパスワードのレスポンス
+type readableResponse struct {
+	Password string `json:"password"`
+	*phonetic.Rendering
+	EntropyBits float64 `json:"entropyBits"`
+	Warning     string  `json:"warning,omitempty"` // ASCIIのみを前提とする用途にASCII以外の文字を選択した場合
+}
+
+// パスワードを区切り表記・フォネティックコードとともにJSONで返すハンドラー
+type ReadableHandler struct {
+	generator PasswordGeneratorInterface
+}
+
+// 依存性注入を使用して新しいReadableHandlerを作成
+func NewReadableHandler(generator PasswordGeneratorInterface) *ReadableHandler {
+	return &ReadableHandler{generator: generator}
+}
+
+// パスワードを生成し、区切り表記と1文字ずつの種類・英語と日本語の読みを返す
+func (h *ReadableHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if !parsePostForm(w, r) {
+		return
+	}
+
+	cfg, err := passwordConfigFromForm(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var opts phonetic.Options
+	if opts.ChunkSize, err = formInt(r, "chunkSize"); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	opts.Separator = r.Form.Get("separator")
+	entropy, err := cfg.EntropyBits()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if opts, err = opts.Normalize(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	password, err := h.generator.Generate(cfg)
+	if err != nil {
+		writeGenerationError(w, err)
+		return
+	}
+	defer password.Destroy()
+
+	// パスワードはレスポンスで一度だけ返すため、ここでのみ文字列に変換する
+	value := password.Reveal()
+	rendering, err := phonetic.Render(value, opts)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, readableResponse{
+		Password:    value,
+		Rendering:   rendering,
+		EntropyBits: math.Round(entropy*10) / 10,
+		Warning:     cfg.ASCIIWarning(),
+	})
+}
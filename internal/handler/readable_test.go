@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestReadableHandler_Handle(t *testing.T) {
+	h := NewReadableHandler(&MockPasswordGenerator{})
+
+	tests := []struct {
+		name        string
+		form        url.Values
+		wantStatus  int
+		wantChunked string
+		wantWarning bool
+	}{
+		{name: "既定の区切り", form: url.Values{"length": {"6"}, "uppercase": {"true"}}, wantStatus: http.StatusOK, wantChunked: "AAAA-AA"},
+		{name: "区切りを指定", form: url.Values{"length": {"6"}, "uppercase": {"true"}, "chunkSize": {"3"}, "separator": {" "}}, wantStatus: http.StatusOK, wantChunked: "AAA AAA"},
+		{name: "かなとURLの記号プロファイル", form: url.Values{"length": {"6"}, "hiragana": {"true"}, "symbolProfile": {"url"}}, wantStatus: http.StatusOK, wantChunked: "AAAA-AA", wantWarning: true},
+		{name: "未対応のUnicodeブロック", form: url.Values{"length": {"6"}, "unicodeBlocks": {"klingon"}}, wantStatus: http.StatusBadRequest},
+		{name: "無効な長さ", form: url.Values{"length": {"0"}}, wantStatus: http.StatusBadRequest},
+		{name: "無効な区切りの文字数", form: url.Values{"length": {"6"}, "chunkSize": {"x"}}, wantStatus: http.StatusBadRequest},
+		{name: "区切りの文字数が大きすぎる", form: url.Values{"length": {"6"}, "chunkSize": {"17"}}, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := postForm(h.Handle, "/api/password", tt.form)
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rr.Code, tt.wantStatus, rr.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if rr.Header().Get("Cache-Control") != "no-store" {
+				t.Error("Cache-Control: no-store が設定されていません")
+			}
+
+			var resp struct {
+				Password string   `json:"password"`
+				Chunks   []string `json:"chunks"`
+				Chunked  string   `json:"chunked"`
+				Letters  []struct {
+					Char, Class, En, Ja string
+				} `json:"letters"`
+				EntropyBits float64 `json:"entropyBits"`
+				Warning     string  `json:"warning"`
+			}
+			if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("JSONの解析に失敗: %v", err)
+			}
+			if resp.Password != "AAAAAA" || resp.Chunked != tt.wantChunked {
+				t.Errorf("password = %q, chunked = %q, want AAAAAA, %q", resp.Password, resp.Chunked, tt.wantChunked)
+			}
+			if (resp.Warning != "") != tt.wantWarning {
+				t.Errorf("warning = %q, wantWarning %v", resp.Warning, tt.wantWarning)
+			}
+			if resp.EntropyBits <= 0 {
+				t.Errorf("entropyBits = %v", resp.EntropyBits)
+			}
+			if len(resp.Letters) != 6 {
+				t.Fatalf("letters の数 = %d, want 6", len(resp.Letters))
+			}
+			if l := resp.Letters[0]; l.Class != "uppercase" || l.En != "ALFA" || l.Ja != "大文字 アルファ" {
+				t.Errorf("letters[0] = %+v", l)
+			}
+		})
+	}
+}
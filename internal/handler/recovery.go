@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/okamyuji/PasswordGenerator/internal/recovery"
+)
+
+// リカバリーコード生成のコントラクトを定義するインターフェース
+type RecoveryCodeGeneratorInterface interface {
+	Generate(opts recovery.Options) (*recovery.Set, error)
+}
+
+// リカバリーコード生成のレスポンス
+type recoveryResponse struct {
+	*recovery.Set
+	SheetText string `json:"sheetText"`
+	SheetHTML string `json:"sheetHtml"`
+}
+
+// MFA登録時に渡すリカバリーコードのセットを生成するハンドラー
+type RecoveryHandler struct {
+	renderer  TemplateRendererInterface
+	generator RecoveryCodeGeneratorInterface
+	now       func() time.Time
+}
+
+// 依存性注入を使用して新しいRecoveryHandlerを作成
+func NewRecoveryHandler(
+	renderer TemplateRendererInterface,
+	generator RecoveryCodeGeneratorInterface,
+) *RecoveryHandler {
+	return &RecoveryHandler{
+		renderer:  renderer,
+		generator: generator,
+		now:       time.Now,
+	}
+}
+
+// リカバリーコードと保存用ハッシュ、印刷用シートを生成
+func (h *RecoveryHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if !parsePostForm(w, r) {
+		return
+	}
+
+	count, err := formInt(r, "count")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var hash recovery.HashAlgorithm
+	if v := r.Form.Get("hash"); v != "" {
+		if hash, err = recovery.ParseHashAlgorithm(v); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	opts := recovery.Options{
+		Count:    count,
+		Format:   r.Form.Get("format"),
+		Alphabet: recovery.Alphabet(strings.ToLower(strings.TrimSpace(r.Form.Get("alphabet")))),
+		Hash:     hash,
+	}
+
+	set, err := h.generator.Generate(opts)
+	if err != nil {
+		writeGenerationError(w, err)
+		return
+	}
+
+	sheet := recovery.NewSheet(set, strings.TrimSpace(r.Form.Get("title")), strings.TrimSpace(r.Form.Get("account")), h.now())
+	html := newBufferedResponseWriter()
+	if err := h.renderer.ExecuteTemplate(html, "recovery.html", sheet); err != nil {
+		slog.Error("テンプレート実行エラー", "error", err)
+		http.Error(w, "内部サーバーエラー", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, recoveryResponse{
+		Set:       set,
+		SheetText: sheet.Text(),
+		SheetHTML: html.body.String(),
+	})
+}
+
+// テンプレートの出力をレスポンスに直接書き込まずに受け取るためのResponseWriter
+type bufferedResponseWriter struct {
+	header http.Header
+	body   bytes.Buffer
+}
+
+func newBufferedResponseWriter() *bufferedResponseWriter {
+	return &bufferedResponseWriter{header: make(http.Header)}
+}
+
+func (b *bufferedResponseWriter) Header() http.Header {
+	return b.header
+}
+
+func (b *bufferedResponseWriter) Write(p []byte) (int, error) {
+	return b.body.Write(p)
+}
+
+func (b *bufferedResponseWriter) WriteHeader(int) {}
@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/okamyuji/PasswordGenerator/internal/recovery"
+)
+
+// 受け取ったオプションを記録するモックRecoveryCodeGenerator
+type MockRecoveryCodeGenerator struct {
+	opts recovery.Options
+}
+
+func (m *MockRecoveryCodeGenerator) Generate(opts recovery.Options) (*recovery.Set, error) {
+	m.opts = opts
+	if opts.Format == "bad" {
+		return nil, errors.New("無効な書式")
+	}
+	return &recovery.Set{
+		Codes:         []recovery.Code{{Code: "AAAA-BBBB", Hash: "$argon2id$hash"}},
+		Format:        recovery.DefaultFormat,
+		Alphabet:      recovery.AlphabetCrockford,
+		HashAlgorithm: recovery.HashArgon2id,
+		EntropyBits:   40,
+	}, nil
+}
+
+func TestRecoveryHandler_Handle(t *testing.T) {
+	tmpl := template.Must(template.New("recovery.html").Parse(
+		`<h1>{{ .Title }}</h1>{{ range .Codes }}<li>{{ . }}</li>{{ end }}`))
+
+	tests := []struct {
+		name       string
+		method     string
+		formData   url.Values
+		wantStatus int
+		wantOpts   recovery.Options
+	}{
+		{
+			name:       "既定値",
+			method:     http.MethodPost,
+			formData:   url.Values{},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "すべてのオプション",
+			method: http.MethodPost,
+			formData: url.Values{
+				"count":    {"5"},
+				"format":   {"xxxxx-xxxxx"},
+				"alphabet": {"Numeric"},
+				"hash":     {"bcrypt"},
+				"title":    {"Acme"},
+			},
+			wantStatus: http.StatusOK,
+			wantOpts:   recovery.Options{Count: 5, Format: "xxxxx-xxxxx", Alphabet: recovery.AlphabetNumeric, Hash: recovery.HashBcrypt},
+		},
+		{
+			name:       "無効なコード数",
+			method:     http.MethodPost,
+			formData:   url.Values{"count": {"0"}},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "未対応のハッシュ",
+			method:     http.MethodPost,
+			formData:   url.Values{"hash": {"md5"}},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "生成エラー",
+			method:     http.MethodPost,
+			formData:   url.Values{"format": {"bad"}},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "GETは許可されない",
+			method:     http.MethodGet,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generator := &MockRecoveryCodeGenerator{}
+			h := NewRecoveryHandler(&MockTemplateRenderer{tmpl: tmpl}, generator)
+			h.now = func() time.Time { return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+			req := httptest.NewRequest(tt.method, "/api/recovery-codes", strings.NewReader(tt.formData.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			rr := httptest.NewRecorder()
+			h.Handle(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("ステータスコード = %d, want %d: %s", rr.Code, tt.wantStatus, rr.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if generator.opts != tt.wantOpts {
+				t.Errorf("オプション = %+v, want %+v", generator.opts, tt.wantOpts)
+			}
+
+			var resp recoveryResponse
+			if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("JSONの解析に失敗: %v", err)
+			}
+			if len(resp.Codes) != 1 || resp.Codes[0].Hash != "$argon2id$hash" {
+				t.Errorf("codes = %+v", resp.Codes)
+			}
+			if !strings.Contains(resp.SheetText, " 1. AAAA-BBBB\n") {
+				t.Errorf("sheetText = %q", resp.SheetText)
+			}
+			if !strings.Contains(resp.SheetHTML, "<li>AAAA-BBBB</li>") {
+				t.Errorf("sheetHtml = %q", resp.SheetHTML)
+			}
+			if rr.Header().Get("Content-Type") != "application/json" {
+				t.Errorf("Content-Type = %q", rr.Header().Get("Content-Type"))
+			}
+			if rr.Header().Get("Cache-Control") != "no-store" {
+				t.Errorf("Cache-Control = %q, want no-store", rr.Header().Get("Cache-Control"))
+			}
+		})
+	}
+}
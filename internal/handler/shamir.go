@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/okamyuji/PasswordGenerator/internal/shamir"
+)
+
+// 秘密分散のコントラクトを定義するインターフェース
+type SecretSplitterInterface interface {
+	Split(s []byte, opts shamir.Options) ([]shamir.Share, error)
+}
+
+// 分割されたシェアの1つ
+type shamirShare struct {
+	Index int    `json:"index"`
+	Share string `json:"share"`
+}
+
+// 秘密分散のレスポンス
+type shamirResponse struct {
+	Secret    string          `json:"secret"`
+	Threshold int             `json:"threshold"`
+	Encoding  shamir.Encoding `json:"encoding"`
+	Shares    []shamirShare   `json:"shares"`
+}
+
+// 緊急用の管理者パスワードなどを生成し、複数の保管者向けのシェアに分割するハンドラー
+type ShamirHandler struct {
+	splitter  SecretSplitterInterface
+	passwords PasswordGeneratorInterface
+}
+
+// 依存性注入を使用して新しいShamirHandlerを作成
+func NewShamirHandler(splitter SecretSplitterInterface, passwords PasswordGeneratorInterface) *ShamirHandler {
+	return &ShamirHandler{splitter: splitter, passwords: passwords}
+}
+
+// パスワードを生成し、shares個のうちthreshold個で復元できるシェアに分割
+func (h *ShamirHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if !parsePostForm(w, r) {
+		return
+	}
+
+	cfg, err := passwordConfigFromForm(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	encoding, err := shamir.ParseEncoding(r.Form.Get("encoding"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var opts shamir.Options
+	if opts.Shares, err = formInt(r, "shares"); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if opts.Threshold, err = formInt(r, "threshold"); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	password, err := h.passwords.Generate(cfg)
+	if err != nil {
+		writeGenerationError(w, err)
+		return
+	}
+	defer password.Destroy()
+
+	shares, err := h.splitter.Split(password.Bytes(), opts)
+	if err != nil {
+		writeGenerationError(w, err)
+		return
+	}
+
+	resp := shamirResponse{Encoding: encoding, Shares: make([]shamirShare, len(shares))}
+	for i, s := range shares {
+		encoded, err := s.Encode(encoding)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		resp.Threshold = s.Threshold
+		resp.Shares[i] = shamirShare{Index: s.Index, Share: encoded}
+	}
+	// 管理者が設定するため、生成したパスワードはレスポンスで一度だけ返す
+	resp.Secret = password.Reveal()
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, resp)
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/okamyuji/PasswordGenerator/internal/shamir"
+)
+
+func TestShamirHandler(t *testing.T) {
+	h := NewShamirHandler(shamir.New(), &MockPasswordGenerator{})
+
+	tests := []struct {
+		name          string
+		form          url.Values
+		wantStatus    int
+		wantShares    int
+		wantThreshold int
+	}{
+		{name: "既定値", form: url.Values{"length": {"16"}}, wantStatus: http.StatusOK, wantShares: 5, wantThreshold: 3},
+		{name: "base32", form: url.Values{"length": {"8"}, "shares": {"3"}, "threshold": {"2"}, "encoding": {"base32"}}, wantStatus: http.StatusOK, wantShares: 3, wantThreshold: 2},
+		{name: "しきい値がシェア数を超える", form: url.Values{"length": {"8"}, "shares": {"2"}, "threshold": {"3"}}, wantStatus: http.StatusBadRequest},
+		{name: "未対応のエンコーディング", form: url.Values{"length": {"8"}, "encoding": {"hex"}}, wantStatus: http.StatusBadRequest},
+		{name: "無効な長さ", form: url.Values{"length": {"0"}}, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := postForm(h.Handle, "/api/shamir", tt.form)
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rr.Code, tt.wantStatus, rr.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if rr.Header().Get("Cache-Control") != "no-store" {
+				t.Error("Cache-Control: no-store が設定されていません")
+			}
+
+			var resp shamirResponse
+			if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("JSONの解析に失敗: %v", err)
+			}
+			if len(resp.Shares) != tt.wantShares || resp.Threshold != tt.wantThreshold {
+				t.Fatalf("シェア数 = %d, しきい値 = %d", len(resp.Shares), resp.Threshold)
+			}
+
+			// しきい値ちょうどの末尾のシェアから秘密を復元できる
+			var shares []shamir.Share
+			for _, s := range resp.Shares[len(resp.Shares)-resp.Threshold:] {
+				share, err := shamir.ParseShare(s.Share)
+				if err != nil {
+					t.Fatalf("ParseShare() エラー = %v", err)
+				}
+				shares = append(shares, share)
+			}
+			restored, err := shamir.Combine(shares)
+			if err != nil {
+				t.Fatalf("Combine() エラー = %v", err)
+			}
+			if restored.Reveal() != resp.Secret {
+				t.Errorf("復元された秘密 = %q, want %q", restored.Reveal(), resp.Secret)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/okamyuji/PasswordGenerator/internal/share"
+)
+
+// 共有リンクの作成と開封のコントラクトを定義するインターフェース
+type ShareServiceInterface interface {
+	Create(ctx context.Context, plaintext []byte, ttl time.Duration) (*share.Link, error)
+	Open(ctx context.Context, id string) (*share.Sealed, error)
+}
+
+// 共有リンク作成のレスポンス
+type shareResponse struct {
+	*share.Link
+	URL string `json:"url"` // フラグメントに復号鍵を含む閲覧ページのパス
+}
+
+// 共有リンク開封のレスポンス
+type shareRevealResponse struct {
+	*share.Sealed
+	ID string `json:"id"`
+}
+
+// 生成した（または入力された）秘密を1回だけ閲覧できるリンクで共有するハンドラー
+type ShareHandler struct {
+	renderer  TemplateRendererInterface
+	service   ShareServiceInterface
+	passwords PasswordGeneratorInterface
+}
+
+// 依存性注入を使用して新しいShareHandlerを作成
+func NewShareHandler(
+	renderer TemplateRendererInterface,
+	service ShareServiceInterface,
+	passwords PasswordGeneratorInterface,
+) *ShareHandler {
+	return &ShareHandler{
+		renderer:  renderer,
+		service:   service,
+		passwords: passwords,
+	}
+}
+
+// 共有リンクを作成
+//
+// secretが指定されていればその値を、無ければパスワード設定に従って生成した値を共有する。
+// レスポンスには秘密そのものは含めない。
+func (h *ShareHandler) HandleCreate(w http.ResponseWriter, r *http.Request) {
+	if !parsePostForm(w, r) {
+		return
+	}
+
+	var ttl time.Duration
+	if v := strings.TrimSpace(r.Form.Get("ttl")); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			http.Error(w, "無効な有効期限: "+v, http.StatusBadRequest)
+			return
+		}
+		ttl = d
+	}
+
+	var plaintext []byte
+	if v := r.Form.Get("secret"); v != "" {
+		plaintext = []byte(v)
+	} else {
+		cfg, err := passwordConfigFromForm(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		password, err := h.passwords.Generate(cfg)
+		if err != nil {
+			writeGenerationError(w, err)
+			return
+		}
+		defer password.Destroy()
+		plaintext = password.Bytes()
+	}
+	defer clear(plaintext)
+
+	link, err := h.service.Create(r.Context(), plaintext, ttl)
+	if err != nil {
+		writeGenerationError(w, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, shareResponse{Link: link, URL: link.Path()})
+}
+
+// 閲覧ページを表示
+//
+// リンクのプレビューなどで消費されないよう、秘密の取り出しはページ上の操作で行う。
+func (h *ShareHandler) HandleView(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "メソッドは許可されていません", http.StatusMethodNotAllowed)
+		return
+	}
+	id := strings.TrimPrefix(r.URL.Path, "/share/")
+	if !share.ValidID(id) {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	data := map[string]string{
+		"ID":        id,
+		"CSRFToken": os.Getenv("CSRF_TOKEN"),
+	}
+	if err := h.renderer.ExecuteTemplate(w, "share.html", data); err != nil {
+		slog.Error("テンプレート実行エラー", "error", err)
+		http.Error(w, "内部サーバーエラー", http.StatusInternalServerError)
+	}
+}
+
+// 暗号化された秘密を返し、リンクを消費する（復号はブラウザでフラグメントの鍵を使って行う）
+func (h *ShareHandler) HandleReveal(w http.ResponseWriter, r *http.Request) {
+	if !parsePostForm(w, r) {
+		return
+	}
+
+	id := r.Form.Get("id")
+	sealed, err := h.service.Open(r.Context(), id)
+	if errors.Is(err, share.ErrNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		slog.Error("共有リンクを開封できません", "error", err)
+		http.Error(w, "内部サーバーエラー", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, shareRevealResponse{Sealed: sealed, ID: id})
+}
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/okamyuji/PasswordGenerator/internal/share"
+)
+
+func postForm(h http.HandlerFunc, path string, form url.Values) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rr := httptest.NewRecorder()
+	h(rr, req)
+	return rr
+}
+
+func TestShareHandler(t *testing.T) {
+	tmpl := template.Must(template.New("share.html").Parse(`<main data-id="{{ .ID }}"></main>`))
+
+	tests := []struct {
+		name       string
+		formData   url.Values
+		wantStatus int
+		wantSecret string
+	}{
+		{
+			name:       "入力された秘密",
+			formData:   url.Values{"secret": {"hunter2"}, "ttl": {"30m"}},
+			wantStatus: http.StatusOK,
+			wantSecret: "hunter2",
+		},
+		{
+			name:       "生成したパスワード",
+			formData:   url.Values{"length": {"12"}, "uppercase": {"true"}},
+			wantStatus: http.StatusOK,
+			wantSecret: "AAAAAAAAAAAA",
+		},
+		{
+			name:       "無効な有効期限",
+			formData:   url.Values{"secret": {"hunter2"}, "ttl": {"forever"}},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "範囲外の有効期限",
+			formData:   url.Values{"secret": {"hunter2"}, "ttl": {"720h"}},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "パスワード設定なし",
+			formData:   url.Values{},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewShareHandler(&MockTemplateRenderer{tmpl: tmpl}, share.New(share.NewMemoryStore()), &MockPasswordGenerator{})
+
+			rr := postForm(h.HandleCreate, "/api/share", tt.formData)
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("ステータスコード = %d, want %d: %s", rr.Code, tt.wantStatus, rr.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if strings.Contains(rr.Body.String(), tt.wantSecret) {
+				t.Error("作成のレスポンスに秘密が含まれています")
+			}
+			var created struct {
+				ID  string `json:"id"`
+				URL string `json:"url"`
+			}
+			if err := json.Unmarshal(rr.Body.Bytes(), &created); err != nil {
+				t.Fatalf("JSONの解析に失敗: %v", err)
+			}
+			path, key, ok := strings.Cut(created.URL, "#")
+			if !ok || path != "/share/"+created.ID {
+				t.Fatalf("url = %q", created.URL)
+			}
+
+			// 閲覧ページの表示ではリンクを消費しない
+			for i := 0; i < 2; i++ {
+				rr = httptest.NewRecorder()
+				h.HandleView(rr, httptest.NewRequest(http.MethodGet, path, nil))
+				if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), created.ID) {
+					t.Fatalf("閲覧ページ = %d: %s", rr.Code, rr.Body.String())
+				}
+			}
+
+			rr = postForm(h.HandleReveal, "/api/share/reveal", url.Values{"id": {created.ID}})
+			if rr.Code != http.StatusOK {
+				t.Fatalf("開封 = %d: %s", rr.Code, rr.Body.String())
+			}
+			if rr.Header().Get("Cache-Control") != "no-store" {
+				t.Errorf("Cache-Control = %q", rr.Header().Get("Cache-Control"))
+			}
+			var sealed share.Sealed
+			if err := json.Unmarshal(rr.Body.Bytes(), &sealed); err != nil {
+				t.Fatalf("JSONの解析に失敗: %v", err)
+			}
+			plaintext, err := share.Decrypt(created.ID, &sealed, key)
+			if err != nil || string(plaintext) != tt.wantSecret {
+				t.Errorf("復号結果 = %q, %v, want %q", plaintext, err, tt.wantSecret)
+			}
+
+			// 2回目の開封は失敗する
+			rr = postForm(h.HandleReveal, "/api/share/reveal", url.Values{"id": {created.ID}})
+			if rr.Code != http.StatusNotFound {
+				t.Errorf("2回目の開封 = %d, want %d", rr.Code, http.StatusNotFound)
+			}
+		})
+	}
+}
+
+func TestShareHandler_HandleViewInvalidID(t *testing.T) {
+	h := NewShareHandler(&MockTemplateRenderer{}, share.New(share.NewMemoryStore()), &MockPasswordGenerator{})
+	rr := httptest.NewRecorder()
+	h.HandleView(rr, httptest.NewRequest(http.MethodGet, "/share/invalid", nil))
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("ステータスコード = %d, want %d", rr.Code, http.StatusNotFound)
+	}
+}
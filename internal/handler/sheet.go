@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/okamyuji/PasswordGenerator/internal/format"
+	"github.com/okamyuji/PasswordGenerator/internal/sheet"
+)
+
+// 印刷用の認証情報シートを生成するハンドラー
+//
+// 封筒に入れて配布する初期パスワードを、1人につき1ページのHTMLまたはPDFで出力する。
+type SheetHandler struct {
+	renderer  TemplateRendererInterface
+	generator PasswordGeneratorInterface
+	now       func() time.Time
+}
+
+// 依存性注入を使用して新しいSheetHandlerを作成
+func NewSheetHandler(renderer TemplateRendererInterface, generator PasswordGeneratorInterface) *SheetHandler {
+	return &SheetHandler{
+		renderer:  renderer,
+		generator: generator,
+		now:       time.Now,
+	}
+}
+
+// ユーザーごとにパスワードを生成し、印刷用シートをHTML（既定）またはPDFで返す
+func (h *SheetHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if !parsePostForm(w, r) {
+		return
+	}
+
+	cfg, err := passwordConfigFromForm(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	output := strings.ToLower(strings.TrimSpace(r.Form.Get("format")))
+	if output != "" && output != "html" && output != "pdf" {
+		http.Error(w, fmt.Sprintf("未対応の出力フォーマット: %s (html, pdf)", output), http.StatusBadRequest)
+		return
+	}
+	usernames := splitList(r.Form.Get("usernames"))
+	if len(usernames) > sheet.MaxUsers {
+		http.Error(w, fmt.Sprintf("ユーザー数が最大値を超えています: %d (最大: %d)", len(usernames), sheet.MaxUsers), http.StatusBadRequest)
+		return
+	}
+
+	creds, err := format.GenerateCredentials(h.generator, cfg, usernames)
+	if err != nil {
+		writeGenerationError(w, err)
+		return
+	}
+	s, err := sheet.New(creds, cfg, strings.TrimSpace(r.Form.Get("title")), h.now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	if output == "pdf" {
+		body, err := s.PDF()
+		if err != nil {
+			slog.Error("PDFの生成に失敗", "error", err)
+			http.Error(w, "内部サーバーエラー", http.StatusInternalServerError)
+			return
+		}
+		defer clear(body)
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Header().Set("Content-Disposition", `attachment; filename="credentials.pdf"`)
+		if _, err := w.Write(body); err != nil {
+			slog.Error("レスポンスの書き込みに失敗", "error", err)
+		}
+		return
+	}
+
+	html := newBufferedResponseWriter()
+	defer func() { clear(html.body.Bytes()) }()
+	if err := h.renderer.ExecuteTemplate(html, "credentials.html", s); err != nil {
+		slog.Error("テンプレート実行エラー", "error", err)
+		http.Error(w, "内部サーバーエラー", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if _, err := w.Write(html.body.Bytes()); err != nil {
+		slog.Error("レスポンスの書き込みに失敗", "error", err)
+	}
+}
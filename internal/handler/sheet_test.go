@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"bytes"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/okamyuji/PasswordGenerator/internal/sheet"
+)
+
+func TestSheetHandler_Handle(t *testing.T) {
+	tmpl := template.Must(template.New("credentials.html").Parse(
+		`{{ range .Pages }}<section>{{ .Username }}:{{ range .Groups }}<span>{{ . }}</span>{{ end }}{{ .QRCode }}</section>{{ end }}`))
+	h := NewSheetHandler(&MockTemplateRenderer{tmpl: tmpl}, &MockPasswordGenerator{})
+
+	tests := []struct {
+		name            string
+		form            url.Values
+		wantStatus      int
+		wantContentType string
+		wantContains    string
+	}{
+		{
+			name:            "HTML",
+			form:            url.Values{"length": {"6"}, "uppercase": {"true"}, "usernames": {"alice, bob"}},
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/html; charset=utf-8",
+			wantContains:    "<section>bob:<span>AAAA</span><span>AA</span><svg",
+		},
+		{
+			name:            "PDF",
+			form:            url.Values{"length": {"6"}, "uppercase": {"true"}, "usernames": {"alice"}, "format": {"PDF"}},
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/pdf",
+			wantContains:    "%PDF-1.4",
+		},
+		{name: "ユーザー名なし", form: url.Values{"length": {"6"}}, wantStatus: http.StatusBadRequest},
+		{name: "未対応のフォーマット", form: url.Values{"length": {"6"}, "usernames": {"alice"}, "format": {"docx"}}, wantStatus: http.StatusBadRequest},
+		{name: "長すぎるパスワード", form: url.Values{"length": {"65"}, "usernames": {"alice"}}, wantStatus: http.StatusBadRequest},
+		{
+			name:            "ユーザー数の上限",
+			form:            url.Values{"length": {"6"}, "uppercase": {"true"}, "usernames": {testUsernames(sheet.MaxUsers)}},
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/html; charset=utf-8",
+			wantContains:    "<section>user" + strconv.Itoa(sheet.MaxUsers) + ":",
+		},
+		{name: "ユーザー数が多すぎる", form: url.Values{"length": {"6"}, "usernames": {testUsernames(sheet.MaxUsers + 1)}}, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := postForm(h.Handle, "/api/credential-sheet", tt.form)
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rr.Code, tt.wantStatus, rr.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if got := rr.Header().Get("Content-Type"); got != tt.wantContentType {
+				t.Errorf("Content-Type = %q, want %q", got, tt.wantContentType)
+			}
+			if rr.Header().Get("Cache-Control") != "no-store" {
+				t.Error("Cache-Control: no-store が設定されていません")
+			}
+			if !bytes.Contains(rr.Body.Bytes(), []byte(tt.wantContains)) {
+				t.Errorf("レスポンスに %q が含まれていません", tt.wantContains)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/okamyuji/PasswordGenerator/internal/token"
+)
+
+// トークン生成のコントラクトを定義するインターフェース
+type TokenGeneratorInterface interface {
+	Generate(opts token.Options) (string, error)
+}
+
+// トークン生成のレスポンス
+type tokenResponse struct {
+	Token       string         `json:"token"`
+	Encoding    token.Encoding `json:"encoding"`
+	EntropyBits int            `json:"entropyBits"`
+}
+
+// APIキーやWebhook署名鍵などのトークンを生成するハンドラー
+type TokenHandler struct {
+	generator TokenGeneratorInterface
+}
+
+// 依存性注入を使用して新しいTokenHandlerを作成
+func NewTokenHandler(generator TokenGeneratorInterface) *TokenHandler {
+	return &TokenHandler{generator: generator}
+}
+
+func (h *TokenHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if !parsePostForm(w, r) {
+		return
+	}
+
+	opts, err := tokenOptionsFromForm(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tok, err := h.generator.Generate(opts)
+	if err != nil {
+		writeGenerationError(w, err)
+		return
+	}
+
+	encoding := opts.Encoding
+	if encoding == "" {
+		encoding = token.EncodingBase64URL
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, tokenResponse{Token: tok, Encoding: encoding, EntropyBits: opts.ByteLength() * 8})
+}
+
+// フォームの値からトークン生成のオプションを作成
+func tokenOptionsFromForm(r *http.Request) (token.Options, error) {
+	opts := token.Options{
+		Prefix:   strings.TrimSpace(r.Form.Get("prefix")),
+		Checksum: token.Checksum(strings.ToLower(strings.TrimSpace(r.Form.Get("checksum")))),
+	}
+	if v := r.Form.Get("encoding"); v != "" {
+		encoding, err := token.ParseEncoding(v)
+		if err != nil {
+			return opts, err
+		}
+		opts.Encoding = encoding
+	}
+	var err error
+	if opts.Bytes, err = formInt(r, "bytes"); err != nil {
+		return opts, err
+	}
+	if opts.EntropyBits, err = formInt(r, "bits"); err != nil {
+		return opts, err
+	}
+	return opts, nil
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/okamyuji/PasswordGenerator/internal/token"
+)
+
+// 受け取ったオプションを記録するモックTokenGenerator
+type MockTokenGenerator struct {
+	opts token.Options
+}
+
+func (m *MockTokenGenerator) Generate(opts token.Options) (string, error) {
+	m.opts = opts
+	return opts.Prefix + "TOKEN", nil
+}
+
+func TestTokenHandler_Handle(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		formData   url.Values
+		wantStatus int
+		wantOpts   token.Options
+		wantBody   tokenResponse
+	}{
+		{
+			name:       "既定値",
+			method:     http.MethodPost,
+			formData:   url.Values{},
+			wantStatus: http.StatusOK,
+			wantBody:   tokenResponse{Token: "TOKEN", Encoding: token.EncodingBase64URL, EntropyBits: 256},
+		},
+		{
+			name:   "すべてのオプション",
+			method: http.MethodPost,
+			formData: url.Values{
+				"bytes":    {"24"},
+				"encoding": {"HEX"},
+				"prefix":   {"ghp_"},
+				"checksum": {"crc32"},
+			},
+			wantStatus: http.StatusOK,
+			wantOpts:   token.Options{Bytes: 24, Encoding: token.EncodingHex, Prefix: "ghp_", Checksum: token.ChecksumCRC32},
+			wantBody:   tokenResponse{Token: "ghp_TOKEN", Encoding: token.EncodingHex, EntropyBits: 192},
+		},
+		{
+			name:       "エントロピー指定",
+			method:     http.MethodPost,
+			formData:   url.Values{"bits": {"130"}},
+			wantStatus: http.StatusOK,
+			wantOpts:   token.Options{EntropyBits: 130},
+			wantBody:   tokenResponse{Token: "TOKEN", Encoding: token.EncodingBase64URL, EntropyBits: 136},
+		},
+		{
+			name:       "不正なバイト数",
+			method:     http.MethodPost,
+			formData:   url.Values{"bytes": {"abc"}},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "未対応のエンコーディング",
+			method:     http.MethodPost,
+			formData:   url.Values{"encoding": {"base58"}},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "GETは許可されない",
+			method:     http.MethodGet,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generator := &MockTokenGenerator{}
+			h := NewTokenHandler(generator)
+
+			req := httptest.NewRequest(tt.method, "/api/token", strings.NewReader(tt.formData.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			rec := httptest.NewRecorder()
+
+			h.Handle(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("TokenHandler.Handle() status = %v, want %v: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if rec.Header().Get("Cache-Control") != "no-store" {
+				t.Error("TokenHandler.Handle() Cache-Control: no-store が設定されていません")
+			}
+			if generator.opts != tt.wantOpts {
+				t.Errorf("TokenHandler.Handle() options = %+v, want %+v", generator.opts, tt.wantOpts)
+			}
+			var got tokenResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatalf("レスポンスのデコードに失敗: %v", err)
+			}
+			if got != tt.wantBody {
+				t.Errorf("TokenHandler.Handle() = %+v, want %+v", got, tt.wantBody)
+			}
+		})
+	}
+}
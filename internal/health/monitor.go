@@ -0,0 +1,98 @@
+package health
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// 乱数源のヘルステストに失敗し、生成を停止している状態を表すエラー
+var ErrUnhealthy = errors.New("乱数源のヘルステストに失敗したため生成を停止しています")
+
+// SP 800-90B 4.4節の連続ヘルステストのパラメーター
+//
+// 1バイトを1サンプルとし、最小エントロピー H=8ビット、誤検知率 α=2^-40 で算出した。
+const (
+	// 反復回数テスト: C = 1 + ceil(-log2(α) / H)
+	repetitionCutoff = 1 + (40+7)/8
+	// 適応比率テストのウィンドウサイズ（非2値のサンプル）
+	proportionWindow = 512
+	// 適応比率テスト: C = 1 + CRITBINOM(W, 2^-H, 1-α)
+	proportionCutoff = 19
+)
+
+// 乱数源から読み込んだバイトを連続ヘルステストで監視するリーダー
+//
+// 反復回数テスト（同じ値の連続）と適応比率テスト（ウィンドウ内での偏り）のどちらかに
+// 失敗すると、以降の読み込みは常にErrUnhealthyを返す（フェイルクローズ）。
+type Monitor struct {
+	r io.Reader
+
+	mu sync.Mutex
+	// 反復回数テスト
+	last byte
+	run  int
+	// 適応比率テスト
+	ref     byte
+	seen    int
+	matches int
+
+	err error
+}
+
+// rを監視するMonitorを作成
+func NewMonitor(r io.Reader) *Monitor {
+	return &Monitor{r: r}
+}
+
+func (m *Monitor) Read(p []byte) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.err != nil {
+		return 0, m.err
+	}
+	n, err := m.r.Read(p)
+	for _, b := range p[:n] {
+		if reason := m.check(b); reason != "" {
+			// 検査に失敗したバイトは呼び出し元に渡さない
+			clear(p[:n])
+			m.err = fmt.Errorf("%w (%s)", ErrUnhealthy, reason)
+			return 0, m.err
+		}
+	}
+	return n, err
+}
+
+// 1サンプルを両方のテストに通し、失敗した場合はその理由を返す
+func (m *Monitor) check(b byte) string {
+	if m.run > 0 && b == m.last {
+		m.run++
+		if m.run >= repetitionCutoff {
+			return fmt.Sprintf("反復回数テスト: 0x%02x が%d回連続しました", b, m.run)
+		}
+	} else {
+		m.last, m.run = b, 1
+	}
+
+	if m.seen == 0 {
+		m.ref, m.matches = b, 1
+	} else if b == m.ref {
+		m.matches++
+		if m.matches >= proportionCutoff {
+			return fmt.Sprintf("適応比率テスト: 0x%02x が%dサンプル中%d回出現しました", b, proportionWindow, m.matches)
+		}
+	}
+	if m.seen++; m.seen == proportionWindow {
+		m.seen = 0
+	}
+	return ""
+}
+
+// ヘルステストに失敗していればそのエラーを返す
+func (m *Monitor) Err() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.err
+}
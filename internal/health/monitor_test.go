@@ -0,0 +1,76 @@
+package health
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestMonitor_Read(t *testing.T) {
+	// 1つおきに同じ値が現れる列（反復回数テストには掛からない）
+	alternating := make([]byte, proportionWindow)
+	for i := range alternating {
+		if i%2 == 1 {
+			alternating[i] = byte(i)
+		}
+	}
+	// 反復の上限の直前まで同じ値が続き、その後は毎回異なる値
+	belowCutoff := append(bytes.Repeat([]byte{0xaa}, repetitionCutoff-1), 1, 2, 3)
+
+	tests := []struct {
+		name    string
+		input   []byte
+		wantErr bool
+	}{
+		{name: "反復回数テストの上限未満", input: belowCutoff, wantErr: false},
+		{name: "同じ値の連続", input: make([]byte, repetitionCutoff), wantErr: true},
+		{name: "ウィンドウ内での偏り", input: alternating, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMonitor(bytes.NewReader(tt.input))
+			buf := make([]byte, len(tt.input))
+			n, err := io.ReadFull(m, buf)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Read() エラー = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				if !bytes.Equal(buf[:n], tt.input) {
+					t.Errorf("Read() = %x, want %x", buf[:n], tt.input)
+				}
+				return
+			}
+			if !errors.Is(err, ErrUnhealthy) || !errors.Is(m.Err(), ErrUnhealthy) {
+				t.Errorf("エラー = %v, Err() = %v, want ErrUnhealthy", err, m.Err())
+			}
+			if !bytes.Equal(buf, make([]byte, len(buf))) {
+				t.Error("検査に失敗したバイトが呼び出し元に渡されました")
+			}
+		})
+	}
+}
+
+func TestMonitor_FailClosed(t *testing.T) {
+	// 一度失敗すると、乱数源が正常に戻っても読み込みを拒否し続ける
+	m := NewMonitor(io.MultiReader(bytes.NewReader(make([]byte, repetitionCutoff)), rand.Reader))
+	if _, err := m.Read(make([]byte, repetitionCutoff)); err == nil {
+		t.Fatal("Read() 同じ値の連続でエラーが返されませんでした")
+	}
+	if _, err := m.Read(make([]byte, 32)); !errors.Is(err, ErrUnhealthy) {
+		t.Errorf("Read() 失敗後のエラー = %v, want ErrUnhealthy", err)
+	}
+}
+
+func TestMonitor_CryptoRand(t *testing.T) {
+	m := NewMonitor(rand.Reader)
+	buf := make([]byte, 1<<20)
+	if _, err := io.ReadFull(m, buf); err != nil {
+		t.Fatalf("Read() エラー = %v", err)
+	}
+	if err := m.Err(); err != nil {
+		t.Errorf("Err() = %v", err)
+	}
+}
@@ -0,0 +1,280 @@
+package health
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/okamyuji/PasswordGenerator/internal/config"
+	"github.com/okamyuji/PasswordGenerator/internal/generator"
+	"github.com/okamyuji/PasswordGenerator/internal/identifier"
+	"github.com/okamyuji/PasswordGenerator/internal/keys"
+	"github.com/okamyuji/PasswordGenerator/internal/mnemonic"
+	"github.com/okamyuji/PasswordGenerator/internal/otp"
+	"github.com/okamyuji/PasswordGenerator/internal/preset"
+	"github.com/okamyuji/PasswordGenerator/internal/random"
+	"github.com/okamyuji/PasswordGenerator/internal/recovery"
+	"github.com/okamyuji/PasswordGenerator/internal/shamir"
+	"github.com/okamyuji/PasswordGenerator/internal/token"
+)
+
+// 既知解テストに使う決定的な乱数のシード
+const selfTestSeed = "pwgen.selftest.v1"
+
+// UUIDv7とULIDの既知解テストで使う時刻
+var selfTestTime = time.UnixMilli(1700000000000)
+
+// 生成モードごとの既知解テスト
+//
+// 決定的な乱数源を与えたときの出力を固定値と比較し、生成処理の誤りや改変を検出する。
+var knownAnswerTests = []struct {
+	name string
+	run  func(r io.Reader) (string, error)
+	want string
+}{
+	{
+		name: "password",
+		run: func(r io.Reader) (string, error) {
+			password, err := generator.NewWithReader(r).Generate(config.PasswordConfig{
+				Length: 16, UseUppercase: true, UseLowercase: true, UseNumbers: true, UseSymbols: true,
+			})
+			if err != nil {
+				return "", err
+			}
+			defer password.Destroy()
+			return password.Reveal(), nil
+		},
+		want: "n=bYxn)?4E@6ulo$",
+	},
+	{
+		name: "password-kana",
+		run: func(r io.Reader) (string, error) {
+			password, err := generator.NewWithReader(r).Generate(config.PasswordConfig{
+				Length: 12, UseHiragana: true, UseKatakana: true, UseFullWidthDigits: true,
+			})
+			if err != nil {
+				return "", err
+			}
+			defer password.Destroy()
+			return password.Reveal(), nil
+		},
+		want: "２ざ９ダパでゼべガヂ６ブ",
+	},
+	{
+		name: "password-rules",
+		run: func(r io.Reader) (string, error) {
+			password, err := generator.NewWithReader(r).Generate(config.PasswordConfig{
+				Length: 12, UseUppercase: true, UseLowercase: true, UseNumbers: true, UseSymbols: true,
+				Rules: config.Rules{MaxRepeat: 1, MaxSequence: 2, FirstClass: config.ClassLetter, LastClass: config.ClassAlphanumeric},
+			})
+			if err != nil {
+				return "", err
+			}
+			defer password.Destroy()
+			return password.Reveal(), nil
+		},
+		want: "EYx$?@4lon6n",
+	},
+	{
+		name: "token",
+		run: func(r io.Reader) (string, error) {
+			return token.NewWithReader(r).Generate(token.Options{Prefix: "pwg_", Checksum: token.ChecksumCRC32})
+		},
+		want: "pwg_znXY05cDkD2JC4B9XFcPKyd_y4b7T_cTcBZjzjAT74QwJiZxA",
+	},
+	{
+		name: "totp",
+		run: func(r io.Reader) (string, error) {
+			key, err := otp.NewWithReader(r).Generate(otp.Options{Issuer: "pwgen", Account: "selftest"})
+			if err != nil {
+				return "", err
+			}
+			return key.URI, nil
+		},
+		want: "otpauth://totp/pwgen:selftest?algorithm=SHA1&digits=6&issuer=pwgen&period=30&secret=ZZ25RU4XAOID3CILQB6VYVYPFMTX7S4G",
+	},
+	{
+		name: "uuidv4",
+		run:  identifierRun(identifier.KindUUIDv4),
+		want: "ce75d8d3-9703-403d-890b-807d5c570f2b 277fcb86-fb4f-4713-b016-63ce3013ef84",
+	},
+	{
+		name: "uuidv7",
+		run:  identifierRun(identifier.KindUUIDv7),
+		want: "018bcfe5-6800-7e75-98d3-9703903d890b 018bcfe5-6800-7e76-9c57-0f2b277fcb86",
+	},
+	{
+		name: "ulid",
+		run:  identifierRun(identifier.KindULID),
+		want: "01HF7YAT00SSTXHMWQ0E83V28B 01HF7YAT00SSTXHMWQ0E83V28C",
+	},
+	{
+		name: "nanoid",
+		run:  identifierRun(identifier.KindNanoID),
+		want: "XiiFoX8n1b8bhSE3Gk5F_ nQCUoglFLyUIsj2y7GWuH",
+	},
+	{
+		name: "mnemonic",
+		run: func(r io.Reader) (string, error) {
+			result, err := mnemonic.NewWithReader(r).Generate(12)
+			if err != nil {
+				return "", err
+			}
+			return result.Mnemonic, nil
+		},
+		want: "solar put crunch come decorate burst category hybrid later tired sense fire",
+	},
+	{
+		name: "recovery",
+		run: func(r io.Reader) (string, error) {
+			set, err := recovery.NewWithReader(r).Generate(recovery.Options{Count: 2, Hash: recovery.HashBcrypt})
+			if err != nil {
+				return "", err
+			}
+			codes := make([]string, len(set.Codes))
+			for i, c := range set.Codes {
+				ok, err := recovery.Verify(c.Code, c.Hash)
+				if err != nil {
+					return "", err
+				}
+				if !ok {
+					return "", fmt.Errorf("リカバリーコードがハッシュと一致しません")
+				}
+				codes[i] = c.Code
+			}
+			return strings.Join(codes, " "), nil
+		},
+		want: "KXXB-6KE4 CGEG-K6XF",
+	},
+	{
+		name: "framework",
+		run: func(r io.Reader) (string, error) {
+			secret, err := preset.NewWithReader(token.NewWithReader(r), r).Generate(preset.FrameworkDjango)
+			if err != nil {
+				return "", err
+			}
+			return secret.Value, nil
+		},
+		want: "p)5@+l8s_8u%pmb5-f1=oo%st#6evq(1z9en0&^cq5-=s(3h^v",
+	},
+	{
+		name: "shamir",
+		run: func(r io.Reader) (string, error) {
+			shares, err := shamir.NewWithReader(r).Split([]byte("pwgen"), shamir.Options{Shares: 3, Threshold: 2})
+			if err != nil {
+				return "", err
+			}
+			// 既知解との比較に加え、しきい値の組み合わせで復元できることを確認する
+			restored, err := shamir.Combine(shares[1:])
+			if err != nil {
+				return "", err
+			}
+			defer restored.Destroy()
+			if !restored.Equal([]byte("pwgen")) {
+				return "", fmt.Errorf("シェアから秘密を復元できません")
+			}
+			return shares[0].Encode(shamir.EncodingBase32)
+		},
+		want: "0777-A0G1-16MA-9W36-ZSAW-9SEK-PR73-H7R",
+	},
+}
+
+func identifierRun(kind identifier.Kind) func(r io.Reader) (string, error) {
+	return func(r io.Reader) (string, error) {
+		g := identifier.NewWithClock(r, func() time.Time { return selfTestTime })
+		result, err := g.Generate(identifier.Options{Kind: kind, Count: 2})
+		if err != nil {
+			return "", err
+		}
+		return strings.Join(result.IDs, " "), nil
+	}
+}
+
+// 起動時の自己診断
+//
+// 各生成モードの既知解テスト、鍵生成の検証、ヘルステスト自体の動作確認を行う。
+func SelfTest() error {
+	for _, kat := range knownAnswerTests {
+		got, err := kat.run(random.NewDeterministic(selfTestSeed))
+		if err != nil {
+			return fmt.Errorf("自己診断 %s: %w", kat.name, err)
+		}
+		if got != kat.want {
+			return fmt.Errorf("自己診断 %s: 既知解と一致しません", kat.name)
+		}
+	}
+	if err := keyTest(); err != nil {
+		return fmt.Errorf("自己診断 keys: %w", err)
+	}
+	if err := monitorTest(); err != nil {
+		return fmt.Errorf("自己診断 monitor: %w", err)
+	}
+	return nil
+}
+
+// 鍵生成の検証
+//
+// 鍵ペアの乱数は標準ライブラリが直接取得するため、WireGuardは RFC 7748 の既知解、
+// SSH鍵は生成した鍵での署名と検証（ペアワイズ一貫性テスト）で確認する。
+func keyTest() error {
+	// RFC 7748 6.1節のAliceの鍵
+	private, _ := hex.DecodeString("77076d0a7318a57d3c16c17251b26645df4c2f87ebc0992ab177fba51db92c2a")
+	public, err := keys.WireGuardPublicKey(base64.StdEncoding.EncodeToString(private))
+	if err != nil {
+		return err
+	}
+	if want, _ := hex.DecodeString("8520f0098930a754748b7ddcb43ef75a0dbf3a0d26381af4eba4a98eaa9b4e6a"); public != base64.StdEncoding.EncodeToString(want) {
+		return fmt.Errorf("WireGuardの公開鍵が既知解と一致しません")
+	}
+
+	pair, err := keys.New(generator.New()).GenerateSSH(keys.SSHOptions{Type: keys.SSHKeyEd25519, Passphrase: true})
+	if err != nil {
+		return err
+	}
+	signer, err := ssh.ParsePrivateKeyWithPassphrase([]byte(pair.PrivateKey), []byte(pair.Passphrase))
+	if err != nil {
+		return err
+	}
+	pub, _, _, _, err := ssh.ParseAuthorizedKey([]byte(pair.PublicKey))
+	if err != nil {
+		return err
+	}
+	message := make([]byte, 32)
+	if _, err := rand.Read(message); err != nil {
+		return err
+	}
+	sig, err := signer.Sign(rand.Reader, message)
+	if err != nil {
+		return err
+	}
+	if err := pub.Verify(message, sig); err != nil {
+		return fmt.Errorf("SSH鍵の署名を検証できません: %w", err)
+	}
+	return nil
+}
+
+// 反復する値と偏った値をそれぞれ検出できることを確認
+func monitorTest() error {
+	// 同じ値の連続
+	if _, err := NewMonitor(bytes.NewReader(make([]byte, repetitionCutoff))).Read(make([]byte, repetitionCutoff)); err == nil {
+		return fmt.Errorf("反復回数テストが同じ値の連続を検出しませんでした")
+	}
+	// 1つおきに同じ値が現れる列（反復回数テストには掛からない）
+	biased := make([]byte, proportionWindow)
+	for i := range biased {
+		if i%2 == 1 {
+			biased[i] = byte(i)
+		}
+	}
+	if _, err := NewMonitor(bytes.NewReader(biased)).Read(make([]byte, len(biased))); err == nil {
+		return fmt.Errorf("適応比率テストが偏った値を検出しませんでした")
+	}
+	return nil
+}
@@ -0,0 +1,9 @@
+package health
+
+import "testing"
+
+func TestSelfTest(t *testing.T) {
+	if err := SelfTest(); err != nil {
+		t.Fatalf("SelfTest() エラー = %v", err)
+	}
+}
@@ -0,0 +1,155 @@
+package identifier
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+)
+
+// 識別子の種類
+type Kind string
+
+const (
+	KindUUIDv4 Kind = "uuidv4"
+	KindUUIDv7 Kind = "uuidv7"
+	KindULID   Kind = "ulid"
+	KindNanoID Kind = "nanoid"
+)
+
+// 一度に生成できる識別子数の上限
+const (
+	DefaultCount = 1
+	MaxCount     = 1000
+)
+
+// 秘密として使用できるとみなす最小のエントロピー（トークンの最小長と同じ128ビット）
+const secretEntropyBits = 128
+
+// 識別子生成のオプション
+type Options struct {
+	Kind     Kind
+	Count    int
+	Alphabet string // NanoIDのみ
+	Size     int    // NanoIDのみ
+}
+
+// 生成された識別子と、秘密としての適否
+type Result struct {
+	Kind             Kind     `json:"kind"`
+	IDs              []string `json:"ids"`
+	EntropyBits      float64  `json:"entropyBits"` // 識別子1つあたりの乱数部分
+	SuitableAsSecret bool     `json:"suitableAsSecret"`
+	Warning          string   `json:"warning"`
+}
+
+// 種類ごとの秘密としての適否
+var warnings = map[Kind]string{
+	KindUUIDv4: "122ビットの乱数を含み推測は困難ですが、識別子としてログやURLに残りやすいため、秘密にはトークンの使用を推奨します",
+	KindUUIDv7: "先頭48ビットに生成時刻（ミリ秒）が含まれ、同一ミリ秒内では連番になるため、秘密には使用しないでください",
+	KindULID:   "先頭48ビットに生成時刻（ミリ秒）が含まれ、同一ミリ秒内では連番になるため、秘密には使用しないでください",
+}
+
+// 識別子を生成する
+//
+// 乱数はパスワードと同じく暗号論的に安全な乱数生成器から取得する。
+type Generator struct {
+	rand io.Reader
+	now  func() time.Time
+}
+
+// crypto/randを乱数源とするGeneratorを作成
+func New() *Generator {
+	return NewWithReader(rand.Reader)
+}
+
+// 指定された乱数源を使うGeneratorを作成
+func NewWithReader(r io.Reader) *Generator {
+	return NewWithClock(r, time.Now)
+}
+
+// 乱数源と、UUIDv7・ULIDの時刻部分に使う時計を指定してGeneratorを作成
+func NewWithClock(r io.Reader, now func() time.Time) *Generator {
+	return &Generator{rand: r, now: now}
+}
+
+// 種類名を解析
+func ParseKind(name string) (Kind, error) {
+	k := Kind(strings.ToLower(strings.TrimSpace(name)))
+	switch k {
+	case KindUUIDv4, KindUUIDv7, KindULID, KindNanoID:
+		return k, nil
+	case "uuid", "uuid4":
+		return KindUUIDv4, nil
+	case "uuid7":
+		return KindUUIDv7, nil
+	default:
+		return "", fmt.Errorf("未対応の識別子の種類: %s", name)
+	}
+}
+
+// 指定された種類の識別子をまとめて生成
+func (g *Generator) Generate(opts Options) (*Result, error) {
+	if opts.Count == 0 {
+		opts.Count = DefaultCount
+	}
+	if opts.Count < 1 || opts.Count > MaxCount {
+		return nil, fmt.Errorf("無効な識別子数: %d (1〜%d)", opts.Count, MaxCount)
+	}
+	if opts.Kind != KindNanoID && (opts.Alphabet != "" || opts.Size != 0) {
+		return nil, fmt.Errorf("アルファベットと長さはNanoIDでのみ指定できます")
+	}
+
+	result := &Result{Kind: opts.Kind, IDs: make([]string, 0, opts.Count)}
+	var next func() (string, error)
+	switch opts.Kind {
+	case KindUUIDv4:
+		result.EntropyBits = 122
+		next = func() (string, error) { return newUUIDv4(g.rand) }
+	case KindUUIDv7:
+		result.EntropyBits = 74
+		var state uuidV7State
+		next = func() (string, error) { return state.next(g.rand, g.now()) }
+	case KindULID:
+		result.EntropyBits = 80
+		var state ulidState
+		next = func() (string, error) { return state.next(g.rand, g.now()) }
+	case KindNanoID:
+		alphabet, size, err := nanoIDOptions(opts)
+		if err != nil {
+			return nil, err
+		}
+		result.EntropyBits = math.Round(float64(size)*math.Log2(float64(len(alphabet)))*10) / 10
+		next = func() (string, error) { return newNanoID(g.rand, alphabet, size) }
+	default:
+		return nil, fmt.Errorf("未対応の識別子の種類: %s", opts.Kind)
+	}
+
+	for i := 0; i < opts.Count; i++ {
+		id, err := next()
+		if err != nil {
+			return nil, err
+		}
+		result.IDs = append(result.IDs, id)
+	}
+
+	result.SuitableAsSecret, result.Warning = suitability(opts.Kind, result.EntropyBits)
+	return result, nil
+}
+
+// 秘密としての適否と警告
+func suitability(kind Kind, entropyBits float64) (bool, string) {
+	switch kind {
+	case KindUUIDv4:
+		return true, warnings[kind]
+	case KindNanoID:
+		if entropyBits < secretEntropyBits {
+			return false, fmt.Sprintf("エントロピーが%.1fビットで%dビット未満のため、秘密には長さかアルファベットを増やしてください", entropyBits, secretEntropyBits)
+		}
+		return true, ""
+	default:
+		return false, warnings[kind]
+	}
+}
@@ -0,0 +1,178 @@
+package identifier
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/okamyuji/PasswordGenerator/internal/random"
+)
+
+func TestGenerator_Generate(t *testing.T) {
+	tests := []struct {
+		name         string
+		opts         Options
+		wantPattern  string
+		wantBits     float64
+		wantSuitable bool
+		wantWarning  bool
+	}{
+		{
+			name:         "UUIDv4",
+			opts:         Options{Kind: KindUUIDv4, Count: 5},
+			wantPattern:  `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`,
+			wantBits:     122,
+			wantSuitable: true,
+			wantWarning:  true,
+		},
+		{
+			name:        "UUIDv7",
+			opts:        Options{Kind: KindUUIDv7, Count: 50},
+			wantPattern: `^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`,
+			wantBits:    74,
+			wantWarning: true,
+		},
+		{
+			name:        "ULID",
+			opts:        Options{Kind: KindULID, Count: 50},
+			wantPattern: `^[0-7][0-9A-HJKMNP-TV-Z]{25}$`,
+			wantBits:    80,
+			wantWarning: true,
+		},
+		{
+			name:        "NanoIDの既定値",
+			opts:        Options{Kind: KindNanoID},
+			wantPattern: `^[A-Za-z0-9_-]{21}$`,
+			wantBits:    126,
+			wantWarning: true,
+		},
+		{
+			name:         "カスタムアルファベットのNanoID",
+			opts:         Options{Kind: KindNanoID, Count: 3, Alphabet: "0123456789abcdef", Size: 32},
+			wantPattern:  `^[0-9a-f]{32}$`,
+			wantBits:     128,
+			wantSuitable: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := New()
+			// 同一ミリ秒内の単調増加を確認するため時刻を固定
+			now := time.Now()
+			g.now = func() time.Time { return now }
+
+			result, err := g.Generate(tt.opts)
+			if err != nil {
+				t.Fatalf("Generate() エラー = %v", err)
+			}
+			count := tt.opts.Count
+			if count == 0 {
+				count = DefaultCount
+			}
+			if len(result.IDs) != count {
+				t.Fatalf("識別子数 = %d, want %d", len(result.IDs), count)
+			}
+			re := regexp.MustCompile(tt.wantPattern)
+			seen := make(map[string]bool)
+			for _, id := range result.IDs {
+				if !re.MatchString(id) {
+					t.Errorf("識別子 %q が形式 %s に一致しません", id, tt.wantPattern)
+				}
+				if seen[id] {
+					t.Errorf("識別子 %q が重複しています", id)
+				}
+				seen[id] = true
+			}
+			if tt.opts.Kind == KindUUIDv7 || tt.opts.Kind == KindULID {
+				if !sort.StringsAreSorted(result.IDs) {
+					t.Errorf("識別子が単調増加になっていません: %v", result.IDs)
+				}
+			}
+
+			if result.EntropyBits != tt.wantBits {
+				t.Errorf("EntropyBits = %v, want %v", result.EntropyBits, tt.wantBits)
+			}
+			if result.SuitableAsSecret != tt.wantSuitable {
+				t.Errorf("SuitableAsSecret = %v, want %v", result.SuitableAsSecret, tt.wantSuitable)
+			}
+			if (result.Warning != "") != tt.wantWarning {
+				t.Errorf("Warning = %q", result.Warning)
+			}
+		})
+	}
+}
+
+func TestGenerator_GenerateErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+	}{
+		{name: "種類未指定", opts: Options{}},
+		{name: "識別子数超過", opts: Options{Kind: KindUUIDv4, Count: MaxCount + 1}},
+		{name: "UUIDでアルファベット指定", opts: Options{Kind: KindUUIDv4, Alphabet: "abc"}},
+		{name: "アルファベットが短い", opts: Options{Kind: KindNanoID, Alphabet: "a"}},
+		{name: "アルファベットに重複", opts: Options{Kind: KindNanoID, Alphabet: "abca"}},
+		{name: "NanoIDが長すぎる", opts: Options{Kind: KindNanoID, Size: 1000}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New().Generate(tt.opts); err == nil {
+				t.Error("Generate() エラーが返されませんでした")
+			}
+		})
+	}
+}
+
+func TestParseKind(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Kind
+		wantErr bool
+	}{
+		{input: "UUIDv7", want: KindUUIDv7},
+		{input: "uuid", want: KindUUIDv4},
+		{input: "ulid", want: KindULID},
+		{input: "snowflake", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseKind(tt.input)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("ParseKind(%q) = %v, %v", tt.input, got, err)
+			}
+		})
+	}
+}
+
+func TestGenerator_GenerateDeterministic(t *testing.T) {
+	tests := []struct {
+		opts Options
+		want []string
+	}{
+		{
+			opts: Options{Kind: KindUUIDv4, Count: 2},
+			want: []string{"17228871-c30c-41e2-86ba-1a13000a8d6e", "5aeed3b7-6a13-416f-8b1d-403297018f5d"},
+		},
+		{
+			opts: Options{Kind: KindNanoID},
+			want: []string{"fNXQqZgE2p62Sc6rEeTqg"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.opts.Kind), func(t *testing.T) {
+			result, err := NewWithReader(random.NewDeterministic("golden")).Generate(tt.opts)
+			if err != nil {
+				t.Fatalf("Generate() エラー = %v", err)
+			}
+			if strings.Join(result.IDs, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("IDs = %q, want %q", result.IDs, tt.want)
+			}
+		})
+	}
+}
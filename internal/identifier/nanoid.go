@@ -0,0 +1,52 @@
+package identifier
+
+import (
+	"fmt"
+	"io"
+	"unicode/utf8"
+
+	"github.com/okamyuji/PasswordGenerator/internal/random"
+)
+
+// NanoIDの既定値（nanoidパッケージと同じURLセーフなアルファベットと21文字）
+const (
+	DefaultNanoIDAlphabet = "useandom-26T198340PX75pxJACKVERYMINDBUSHWOLF_GQZbfghjklqvwyzrict"
+	DefaultNanoIDSize     = 21
+	maxNanoIDSize         = 256
+)
+
+// NanoIDのアルファベットと長さを検証
+func nanoIDOptions(opts Options) ([]rune, int, error) {
+	alphabet := opts.Alphabet
+	if alphabet == "" {
+		alphabet = DefaultNanoIDAlphabet
+	}
+	if !utf8.ValidString(alphabet) {
+		return nil, 0, fmt.Errorf("アルファベットが有効なUTF-8ではありません")
+	}
+	runes := []rune(alphabet)
+	seen := make(map[rune]bool, len(runes))
+	for _, r := range runes {
+		if seen[r] {
+			return nil, 0, fmt.Errorf("アルファベットに重複した文字があります: %q", r)
+		}
+		seen[r] = true
+	}
+	if len(runes) < 2 || len(runes) > 256 {
+		return nil, 0, fmt.Errorf("アルファベットは2〜256文字である必要があります: %d文字", len(runes))
+	}
+
+	size := opts.Size
+	if size == 0 {
+		size = DefaultNanoIDSize
+	}
+	if size < 1 || size > maxNanoIDSize {
+		return nil, 0, fmt.Errorf("無効なNanoIDの長さ: %d (1〜%d)", size, maxNanoIDSize)
+	}
+	return runes, size, nil
+}
+
+// アルファベットから一様に選んだsize文字のNanoIDを生成
+func newNanoID(r io.Reader, alphabet []rune, size int) (string, error) {
+	return random.String(r, alphabet, size)
+}
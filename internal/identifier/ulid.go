@@ -0,0 +1,73 @@
+package identifier
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// ULIDで使用するCrockford base32のアルファベット
+const ulidAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ULIDを単調増加に生成するための状態
+//
+// ULIDの仕様どおり、同一ミリ秒内では前回の80ビットの乱数に1を加える。
+type ulidState struct {
+	millis int64
+	random [10]byte
+}
+
+// 48ビットのUnixミリ秒時刻と80ビットの乱数からなるULIDを生成
+func (s *ulidState) next(r io.Reader, now time.Time) (string, error) {
+	millis := now.UnixMilli()
+	if millis > s.millis {
+		if _, err := io.ReadFull(r, s.random[:]); err != nil {
+			return "", err
+		}
+		s.millis = millis
+	} else if !increment(s.random[:]) {
+		return "", fmt.Errorf("同一ミリ秒内に生成できるULIDの上限を超えました")
+	}
+
+	var u [16]byte
+	u[0] = byte(s.millis >> 40)
+	u[1] = byte(s.millis >> 32)
+	u[2] = byte(s.millis >> 24)
+	u[3] = byte(s.millis >> 16)
+	u[4] = byte(s.millis >> 8)
+	u[5] = byte(s.millis)
+	copy(u[6:], s.random[:])
+	return encodeULID(u), nil
+}
+
+// 128ビットを先頭に2ビットの0を補って26文字のCrockford base32にエンコード
+func encodeULID(u [16]byte) string {
+	var out [26]byte
+	// 下位から5ビットずつ取り出す
+	for i := 25; i >= 0; i-- {
+		bit := (25 - i) * 5
+		idx := 0
+		for j := 0; j < 5; j++ {
+			pos := bit + j // 下位からのビット位置
+			if pos >= 128 {
+				break
+			}
+			if u[15-pos/8]>>(pos%8)&1 == 1 {
+				idx |= 1 << j
+			}
+		}
+		out[i] = ulidAlphabet[idx]
+	}
+	return string(out[:])
+}
+
+// ビッグエンディアンの整数に1を加える（桁あふれした場合はfalse）
+func increment(b []byte) bool {
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i]++
+		if b[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
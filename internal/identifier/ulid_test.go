@@ -0,0 +1,46 @@
+package identifier
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestULIDState_Next(t *testing.T) {
+	// ULID仕様の例の時刻部分（01ARYZ6S41）
+	now := time.UnixMilli(1469918176385)
+
+	var state ulidState
+	got, err := state.next(bytes.NewReader(make([]byte, 10)), now)
+	if err != nil {
+		t.Fatalf("next() エラー = %v", err)
+	}
+	if want := "01ARYZ6S410000000000000000"; got != want {
+		t.Errorf("next() = %q, want %q", got, want)
+	}
+
+	// 同一ミリ秒内では乱数部分に1を加える
+	got, err = state.next(bytes.NewReader(nil), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "01ARYZ6S410000000000000001"; got != want {
+		t.Errorf("next() = %q, want %q", got, want)
+	}
+
+	// 乱数部分が最大値の場合はエラー
+	state.random = [10]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	if _, err := state.next(bytes.NewReader(nil), now); err == nil {
+		t.Error("next() 桁あふれでエラーが返されませんでした")
+	}
+}
+
+func TestEncodeULID(t *testing.T) {
+	var u [16]byte
+	for i := range u {
+		u[i] = 0xff
+	}
+	if got, want := encodeULID(u), "7ZZZZZZZZZZZZZZZZZZZZZZZZZ"; got != want {
+		t.Errorf("encodeULID() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,77 @@
+package identifier
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"time"
+)
+
+// RFC 9562のUUIDv4（122ビットの乱数）を生成
+func newUUIDv4(r io.Reader) (string, error) {
+	var u [16]byte
+	if _, err := io.ReadFull(r, u[:]); err != nil {
+		return "", err
+	}
+	u[6] = 0x40 | u[6]&0x0f // バージョン4
+	u[8] = 0x80 | u[8]&0x3f // バリアント10
+	return formatUUID(u), nil
+}
+
+// UUIDv7を単調増加に生成するための状態
+//
+// RFC 9562 6.2の方式1に従い、同一ミリ秒内ではrand_aの12ビットをカウンターとして使う。
+type uuidV7State struct {
+	millis  int64
+	counter uint16
+}
+
+// RFC 9562のUUIDv7（48ビットのUnixミリ秒時刻 + 74ビットの乱数）を生成
+func (s *uuidV7State) next(r io.Reader, now time.Time) (string, error) {
+	var b [10]byte
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		return "", err
+	}
+
+	millis := now.UnixMilli()
+	if millis <= s.millis {
+		// 同一ミリ秒内（または時刻の巻き戻り）では前回の値からカウンターを進め、
+		// 桁あふれした場合は時刻を1ミリ秒進める
+		millis = s.millis
+		s.counter++
+		if s.counter > 0x0fff {
+			millis++
+			s.counter = binary.BigEndian.Uint16(b[:2]) & 0x0fff
+		}
+	} else {
+		s.counter = binary.BigEndian.Uint16(b[:2]) & 0x0fff
+	}
+	s.millis = millis
+
+	var u [16]byte
+	u[0] = byte(millis >> 40)
+	u[1] = byte(millis >> 32)
+	u[2] = byte(millis >> 24)
+	u[3] = byte(millis >> 16)
+	u[4] = byte(millis >> 8)
+	u[5] = byte(millis)
+	binary.BigEndian.PutUint16(u[6:8], 0x7000|s.counter)
+	copy(u[8:], b[2:])
+	u[8] = 0x80 | u[8]&0x3f
+	return formatUUID(u), nil
+}
+
+// 8-4-4-4-12形式の文字列に変換
+func formatUUID(u [16]byte) string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:])
+}
@@ -0,0 +1,66 @@
+package identifier
+
+import (
+	"bytes"
+	"encoding/hex"
+	"regexp"
+	"testing"
+	"time"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestNewUUIDv4(t *testing.T) {
+	got, err := newUUIDv4(bytes.NewReader(make([]byte, 16)))
+	if err != nil {
+		t.Fatalf("newUUIDv4() エラー = %v", err)
+	}
+	if want := "00000000-0000-4000-8000-000000000000"; got != want {
+		t.Errorf("newUUIDv4() = %q, want %q", got, want)
+	}
+
+	got, err = newUUIDv4(bytes.NewReader(bytes.Repeat([]byte{0xff}, 16)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "ffffffff-ffff-4fff-bfff-ffffffffffff"; got != want {
+		t.Errorf("newUUIDv4() = %q, want %q", got, want)
+	}
+}
+
+func TestUUIDv7State_Next(t *testing.T) {
+	// RFC 9562 付録A.6のテストベクター
+	now := time.UnixMilli(0x017F22E279B0)
+	random, _ := hex.DecodeString("0CC318C4DC0C0C07398F")
+
+	var state uuidV7State
+	got, err := state.next(bytes.NewReader(random), now)
+	if err != nil {
+		t.Fatalf("next() エラー = %v", err)
+	}
+	if want := "017f22e2-79b0-7cc3-98c4-dc0c0c07398f"; got != want {
+		t.Errorf("next() = %q, want %q", got, want)
+	}
+
+	// 同一ミリ秒内ではカウンターが進む
+	got, err = state.next(bytes.NewReader(make([]byte, 10)), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "017f22e2-79b0-7cc4-8000-000000000000"; got != want {
+		t.Errorf("next() = %q, want %q", got, want)
+	}
+
+	// カウンターが桁あふれすると時刻を1ミリ秒進める
+	state.counter = 0x0fff
+	got, err = state.next(bytes.NewReader(make([]byte, 10)), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "017f22e2-79b1-7000-8000-000000000000"; got != want {
+		t.Errorf("next() = %q, want %q", got, want)
+	}
+	if !uuidPattern.MatchString(got) {
+		t.Errorf("next() = %q がUUIDの形式ではありません", got)
+	}
+}
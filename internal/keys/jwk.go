@@ -0,0 +1,275 @@
+package keys
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+)
+
+// JWKの署名アルゴリズム（RFC 7518 / RFC 8037）
+type JWKAlgorithm string
+
+const (
+	JWKHS256 JWKAlgorithm = "HS256"
+	JWKHS384 JWKAlgorithm = "HS384"
+	JWKHS512 JWKAlgorithm = "HS512"
+	JWKES256 JWKAlgorithm = "ES256"
+	JWKES384 JWKAlgorithm = "ES384"
+	JWKES512 JWKAlgorithm = "ES512"
+	JWKRS256 JWKAlgorithm = "RS256"
+	JWKRS384 JWKAlgorithm = "RS384"
+	JWKRS512 JWKAlgorithm = "RS512"
+	JWKPS256 JWKAlgorithm = "PS256"
+	JWKPS384 JWKAlgorithm = "PS384"
+	JWKPS512 JWKAlgorithm = "PS512"
+	JWKEdDSA JWKAlgorithm = "EdDSA"
+)
+
+// HMACアルゴリズムごとの鍵長（バイト）
+//
+// RFC 7518 3.2に従い、ハッシュの出力長以上の鍵を使う。
+var hmacKeyLengths = map[JWKAlgorithm]int{
+	JWKHS256: 32,
+	JWKHS384: 48,
+	JWKHS512: 64,
+}
+
+// ECアルゴリズムごとの曲線
+var ecCurves = map[JWKAlgorithm]elliptic.Curve{
+	JWKES256: elliptic.P256(),
+	JWKES384: elliptic.P384(),
+	JWKES512: elliptic.P521(),
+}
+
+// JWK生成のオプション
+type JWKOptions struct {
+	Algorithm JWKAlgorithm
+	Bits      int // RSAのみ
+}
+
+// JSON Web Key（RFC 7517）
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	Kid string `json:"kid,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	D   string `json:"d,omitempty"`
+	P   string `json:"p,omitempty"`
+	Q   string `json:"q,omitempty"`
+	DP  string `json:"dp,omitempty"`
+	DQ  string `json:"dq,omitempty"`
+	QI  string `json:"qi,omitempty"`
+	K   string `json:"k,omitempty"`
+}
+
+// 公開鍵を配布するためのJWK Set
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// 生成されたJWK
+//
+// 対称鍵（oct）の場合はPublicとJWKSを持たない。
+type JWKResult struct {
+	Key    JWK     `json:"jwk"`
+	Public *JWK    `json:"publicJwk,omitempty"`
+	JWKS   *JWKSet `json:"jwks,omitempty"`
+}
+
+// アルゴリズム名を解析
+func ParseJWKAlgorithm(name string) (JWKAlgorithm, error) {
+	name = strings.TrimSpace(name)
+	if strings.EqualFold(name, string(JWKEdDSA)) {
+		return JWKEdDSA, nil
+	}
+	alg := JWKAlgorithm(strings.ToUpper(name))
+	if _, ok := hmacKeyLengths[alg]; ok {
+		return alg, nil
+	}
+	if _, ok := ecCurves[alg]; ok {
+		return alg, nil
+	}
+	switch alg {
+	case JWKRS256, JWKRS384, JWKRS512, JWKPS256, JWKPS384, JWKPS512:
+		return alg, nil
+	}
+	return "", fmt.Errorf("未対応のJWKアルゴリズム: %s", name)
+}
+
+// 指定されたアルゴリズムのJWKを生成
+//
+// 非対称鍵のkidはRFC 7638の公開鍵のサムプリントとする。対称鍵のサムプリントは
+// 鍵そのもののハッシュになるため、kidにはランダムな値を使う。
+func (g *Generator) GenerateJWK(opts JWKOptions) (*JWKResult, error) {
+	alg, err := ParseJWKAlgorithm(string(opts.Algorithm))
+	if err != nil {
+		return nil, err
+	}
+	if opts.Bits != 0 && !isRSAAlgorithm(alg) {
+		return nil, fmt.Errorf("%sではビット数を指定できません", alg)
+	}
+
+	var key JWK
+	switch {
+	case hmacKeyLengths[alg] > 0:
+		k, err := g.randomBytes(hmacKeyLengths[alg])
+		if err != nil {
+			return nil, err
+		}
+		kid, err := g.randomBytes(16)
+		if err != nil {
+			return nil, err
+		}
+		key = JWK{Kty: "oct", K: b64(k), Kid: b64(kid)}
+	case ecCurves[alg] != nil:
+		if key, err = g.ecJWK(ecCurves[alg]); err != nil {
+			return nil, err
+		}
+	case alg == JWKEdDSA:
+		pub, priv, err := ed25519.GenerateKey(g.rand)
+		if err != nil {
+			return nil, err
+		}
+		key = JWK{Kty: "OKP", Crv: "Ed25519", X: b64(pub), D: b64(priv.Seed())}
+	default:
+		if key, err = g.rsaJWK(opts.Bits); err != nil {
+			return nil, err
+		}
+	}
+	key.Use = "sig"
+	key.Alg = string(alg)
+
+	result := &JWKResult{Key: key}
+	if key.Kty == "oct" {
+		return result, nil
+	}
+
+	if result.Key.Kid, err = Thumbprint(key); err != nil {
+		return nil, err
+	}
+	public := result.Key.PublicKey()
+	result.Public = &public
+	result.JWKS = &JWKSet{Keys: []JWK{public}}
+	return result, nil
+}
+
+// 秘密鍵のメンバーを除いた公開鍵のJWKを返す
+func (j JWK) PublicKey() JWK {
+	j.D, j.P, j.Q, j.DP, j.DQ, j.QI, j.K = "", "", "", "", "", "", ""
+	return j
+}
+
+// RFC 7638のJWKサムプリント（SHA-256、base64url）を計算
+func Thumbprint(j JWK) (string, error) {
+	// 必須メンバーのみを辞書順に並べたJSONのハッシュを取る
+	var members [][2]string
+	switch j.Kty {
+	case "EC":
+		members = [][2]string{{"crv", j.Crv}, {"kty", j.Kty}, {"x", j.X}, {"y", j.Y}}
+	case "RSA":
+		members = [][2]string{{"e", j.E}, {"kty", j.Kty}, {"n", j.N}}
+	case "OKP":
+		members = [][2]string{{"crv", j.Crv}, {"kty", j.Kty}, {"x", j.X}}
+	case "oct":
+		members = [][2]string{{"k", j.K}, {"kty", j.Kty}}
+	default:
+		return "", fmt.Errorf("未対応の鍵の種類: %s", j.Kty)
+	}
+
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for i, m := range members {
+		if m[1] == "" {
+			return "", fmt.Errorf("JWKに%sがありません", m[0])
+		}
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		name, _ := json.Marshal(m[0])
+		value, _ := json.Marshal(m[1])
+		sb.Write(name)
+		sb.WriteByte(':')
+		sb.Write(value)
+	}
+	sb.WriteByte('}')
+
+	sum := sha256.Sum256([]byte(sb.String()))
+	return b64(sum[:]), nil
+}
+
+func (g *Generator) ecJWK(curve elliptic.Curve) (JWK, error) {
+	priv, err := ecdsa.GenerateKey(curve, g.rand)
+	if err != nil {
+		return JWK{}, err
+	}
+	d, err := priv.Bytes()
+	if err != nil {
+		return JWK{}, err
+	}
+	point, err := priv.PublicKey.Bytes()
+	if err != nil {
+		return JWK{}, err
+	}
+	// 非圧縮形式 0x04 || X || Y の座標を取り出す
+	size := (len(point) - 1) / 2
+	return JWK{
+		Kty: "EC",
+		Crv: curve.Params().Name,
+		X:   b64(point[1 : 1+size]),
+		Y:   b64(point[1+size:]),
+		D:   b64(d),
+	}, nil
+}
+
+func (g *Generator) rsaJWK(bits int) (JWK, error) {
+	if bits == 0 {
+		bits = DefaultRSABits
+	}
+	if bits < MinRSABits || bits > MaxRSABits || bits%1024 != 0 {
+		return JWK{}, fmt.Errorf("無効なRSA鍵のビット数: %d (2048 / 3072 / 4096)", bits)
+	}
+	priv, err := rsa.GenerateKey(g.rand, bits)
+	if err != nil {
+		return JWK{}, err
+	}
+	return JWK{
+		Kty: "RSA",
+		N:   b64(priv.N.Bytes()),
+		E:   b64(big.NewInt(int64(priv.E)).Bytes()),
+		D:   b64(priv.D.Bytes()),
+		P:   b64(priv.Primes[0].Bytes()),
+		Q:   b64(priv.Primes[1].Bytes()),
+		DP:  b64(priv.Precomputed.Dp.Bytes()),
+		DQ:  b64(priv.Precomputed.Dq.Bytes()),
+		QI:  b64(priv.Precomputed.Qinv.Bytes()),
+	}, nil
+}
+
+func isRSAAlgorithm(alg JWKAlgorithm) bool {
+	return strings.HasPrefix(string(alg), "RS") || strings.HasPrefix(string(alg), "PS")
+}
+
+func (g *Generator) randomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := io.ReadFull(g.rand, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,199 @@
+package keys
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+	"testing/iotest"
+)
+
+func TestThumbprint(t *testing.T) {
+	// RFC 7638 3.1の例
+	j := JWK{
+		Kty: "RSA",
+		N:   "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw",
+		E:   "AQAB",
+		Alg: "RS256",
+		Kid: "2011-04-29",
+	}
+	got, err := Thumbprint(j)
+	if err != nil {
+		t.Fatalf("Thumbprint() エラー = %v", err)
+	}
+	if want := "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs"; got != want {
+		t.Errorf("Thumbprint() = %q, want %q", got, want)
+	}
+
+	for _, invalid := range []JWK{{Kty: "RSA", E: "AQAB"}, {Kty: "unknown"}} {
+		if _, err := Thumbprint(invalid); err == nil {
+			t.Errorf("Thumbprint(%+v) エラーが返されませんでした", invalid)
+		}
+	}
+}
+
+func TestGenerator_GenerateJWK(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    JWKOptions
+		wantKty string
+		wantCrv string
+		check   func(t *testing.T, key JWK)
+	}{
+		{
+			name:    "HS256",
+			opts:    JWKOptions{Algorithm: "hs256"},
+			wantKty: "oct",
+			check:   checkKeyLength(32),
+		},
+		{
+			name:    "HS512",
+			opts:    JWKOptions{Algorithm: JWKHS512},
+			wantKty: "oct",
+			check:   checkKeyLength(64),
+		},
+		{
+			name:    "ES256",
+			opts:    JWKOptions{Algorithm: JWKES256},
+			wantKty: "EC",
+			wantCrv: "P-256",
+			check:   checkECKey(elliptic.P256()),
+		},
+		{
+			name:    "ES512",
+			opts:    JWKOptions{Algorithm: JWKES512},
+			wantKty: "EC",
+			wantCrv: "P-521",
+			check:   checkECKey(elliptic.P521()),
+		},
+		{
+			name:    "RS256",
+			opts:    JWKOptions{Algorithm: JWKRS256, Bits: 2048},
+			wantKty: "RSA",
+			check: func(t *testing.T, key JWK) {
+				n, _ := base64.RawURLEncoding.DecodeString(key.N)
+				if bits := new(big.Int).SetBytes(n).BitLen(); bits != 2048 {
+					t.Errorf("nのビット数 = %d, want 2048", bits)
+				}
+				if key.E != "AQAB" || key.D == "" || key.QI == "" {
+					t.Errorf("RSA鍵のメンバーが不足しています: %+v", key)
+				}
+			},
+		},
+		{
+			name:    "EdDSA",
+			opts:    JWKOptions{Algorithm: "eddsa"},
+			wantKty: "OKP",
+			wantCrv: "Ed25519",
+			check: func(t *testing.T, key JWK) {
+				seed, _ := base64.RawURLEncoding.DecodeString(key.D)
+				public := ed25519.NewKeyFromSeed(seed).Public().(ed25519.PublicKey)
+				if b64(public) != key.X {
+					t.Errorf("xが秘密鍵と対応していません")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := New(&mockPasswordGenerator{}).GenerateJWK(tt.opts)
+			if err != nil {
+				t.Fatalf("GenerateJWK() エラー = %v", err)
+			}
+			key := result.Key
+			if key.Kty != tt.wantKty || key.Crv != tt.wantCrv || key.Use != "sig" || key.Kid == "" {
+				t.Errorf("JWK = %+v", key)
+			}
+			tt.check(t, key)
+
+			if tt.wantKty == "oct" {
+				if result.Public != nil || result.JWKS != nil {
+					t.Error("対称鍵に公開鍵が含まれています")
+				}
+				return
+			}
+
+			if want, _ := Thumbprint(key); key.Kid != want {
+				t.Errorf("kid = %q, want サムプリント %q", key.Kid, want)
+			}
+			if result.Public == nil || result.JWKS == nil || len(result.JWKS.Keys) != 1 {
+				t.Fatalf("公開鍵またはJWKSがありません: %+v", result)
+			}
+			public, err := json.Marshal(result.JWKS.Keys[0])
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, member := range []string{`"d"`, `"p"`, `"q"`, `"dp"`, `"dq"`, `"qi"`, `"k"`} {
+				if bytes.Contains(public, []byte(member)) {
+					t.Errorf("JWKSに秘密鍵のメンバー %s が含まれています: %s", member, public)
+				}
+			}
+			if result.Public.Kid != key.Kid {
+				t.Errorf("公開鍵のkid = %q, want %q", result.Public.Kid, key.Kid)
+			}
+		})
+	}
+}
+
+func TestGenerator_GenerateJWKErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		opts JWKOptions
+	}{
+		{name: "アルゴリズム未指定", opts: JWKOptions{}},
+		{name: "未対応のアルゴリズム", opts: JWKOptions{Algorithm: "none"}},
+		{name: "RSA鍵が短い", opts: JWKOptions{Algorithm: JWKPS256, Bits: 1024}},
+		{name: "ECでビット数指定", opts: JWKOptions{Algorithm: JWKES256, Bits: 2048}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(&mockPasswordGenerator{}).GenerateJWK(tt.opts); err == nil {
+				t.Error("GenerateJWK() エラーが返されませんでした")
+			}
+		})
+	}
+}
+
+func checkKeyLength(n int) func(t *testing.T, key JWK) {
+	return func(t *testing.T, key JWK) {
+		t.Helper()
+		k, err := base64.RawURLEncoding.DecodeString(key.K)
+		if err != nil || len(k) != n {
+			t.Errorf("鍵長 = %d, want %d", len(k), n)
+		}
+	}
+}
+
+func checkECKey(curve elliptic.Curve) func(t *testing.T, key JWK) {
+	return func(t *testing.T, key JWK) {
+		t.Helper()
+		d, _ := base64.RawURLEncoding.DecodeString(key.D)
+		x, _ := base64.RawURLEncoding.DecodeString(key.X)
+		y, _ := base64.RawURLEncoding.DecodeString(key.Y)
+		priv, err := ecdsa.ParseRawPrivateKey(curve, d)
+		if err != nil {
+			t.Fatalf("秘密鍵の読み込みに失敗: %v", err)
+		}
+		point, _ := priv.PublicKey.Bytes()
+		if want := append(append([]byte{4}, x...), y...); !bytes.Equal(point, want) {
+			t.Errorf("x, yが秘密鍵と対応していません")
+		}
+	}
+}
+
+// 対称鍵・kid・Ed25519鍵は注入した乱数源から生成し、乱数源の異常はエラーとして返す
+func TestGenerator_GenerateJWKReader(t *testing.T) {
+	errRead := errors.New("乱数源の異常")
+	for _, alg := range []JWKAlgorithm{JWKHS256, JWKEdDSA} {
+		if _, err := NewWithReader(&mockPasswordGenerator{}, iotest.ErrReader(errRead)).GenerateJWK(JWKOptions{Algorithm: alg}); !errors.Is(err, errRead) {
+			t.Errorf("GenerateJWK(%s) エラー = %v, want %v", alg, err, errRead)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package keys
+
+import (
+	"crypto/rand"
+	"io"
+
+	"github.com/okamyuji/PasswordGenerator/internal/config"
+	"github.com/okamyuji/PasswordGenerator/internal/secret"
+)
+
+// 秘密鍵を暗号化するパスフレーズの生成に使用するコントラクト
+type PasswordGenerator interface {
+	Generate(cfg config.PasswordConfig) (*secret.Secret, error)
+}
+
+// 生成するパスフレーズの設定
+//
+// ssh-keygen -p などで手入力しやすいよう記号は含めない。
+var passphraseConfig = config.PasswordConfig{
+	Length:       32,
+	UseUppercase: true,
+	UseLowercase: true,
+	UseNumbers:   true,
+}
+
+// SSH鍵やWireGuard鍵などの鍵素材を生成する
+type Generator struct {
+	passwords PasswordGenerator
+	rand      io.Reader
+}
+
+// パスフレーズの生成に使うパスワードジェネレーターを指定して作成（鍵素材はcrypto/randから生成）
+func New(passwords PasswordGenerator) *Generator {
+	return NewWithReader(passwords, rand.Reader)
+}
+
+// 鍵素材の乱数源を指定して作成（サーバーではヘルステストで監視する乱数源を渡す）
+//
+// RSA・ECDSA鍵はGo 1.26以降、標準ライブラリが常に自身の安全な乱数源を使う。
+func NewWithReader(passwords PasswordGenerator, r io.Reader) *Generator {
+	return &Generator{passwords: passwords, rand: r}
+}
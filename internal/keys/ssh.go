@@ -0,0 +1,129 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// SSH鍵の種類
+type SSHKeyType string
+
+const (
+	SSHKeyEd25519 SSHKeyType = "ed25519"
+	SSHKeyRSA     SSHKeyType = "rsa"
+)
+
+// RSA鍵のビット数
+const (
+	DefaultRSABits = 3072
+	MinRSABits     = 2048
+	MaxRSABits     = 4096
+)
+
+// SSH鍵生成のオプション
+type SSHOptions struct {
+	Type       SSHKeyType
+	Bits       int    // RSAのみ
+	Comment    string // 公開鍵と秘密鍵に記録するコメント（例: user@host）
+	Passphrase bool   // パスフレーズを生成して秘密鍵を暗号化する
+}
+
+// 生成されたSSH鍵ペア
+//
+// 秘密鍵とパスフレーズはこの値でのみ返され、保存やログ出力は行わない。
+type SSHKeyPair struct {
+	Type        SSHKeyType `json:"type"`
+	PublicKey   string     `json:"publicKey"`  // authorized_keys形式
+	PrivateKey  string     `json:"privateKey"` // OpenSSH形式のPEM
+	Passphrase  string     `json:"passphrase,omitempty"`
+	Fingerprint string     `json:"fingerprint"` // SHA256:...
+}
+
+// 鍵の種類を解析
+func ParseSSHKeyType(name string) (SSHKeyType, error) {
+	t := SSHKeyType(strings.ToLower(strings.TrimSpace(name)))
+	switch t {
+	case "":
+		return SSHKeyEd25519, nil
+	case SSHKeyEd25519, SSHKeyRSA:
+		return t, nil
+	default:
+		return "", fmt.Errorf("未対応のSSH鍵の種類: %s", name)
+	}
+}
+
+// OpenSSH形式のSSH鍵ペアを生成
+func (g *Generator) GenerateSSH(opts SSHOptions) (*SSHKeyPair, error) {
+	if opts.Type == "" {
+		opts.Type = SSHKeyEd25519
+	}
+	if strings.ContainsAny(opts.Comment, "\r\n") {
+		return nil, fmt.Errorf("コメントに改行は使用できません")
+	}
+
+	var private crypto.PrivateKey
+	var public crypto.PublicKey
+	switch opts.Type {
+	case SSHKeyEd25519:
+		if opts.Bits != 0 {
+			return nil, fmt.Errorf("Ed25519鍵ではビット数を指定できません")
+		}
+		pub, priv, err := ed25519.GenerateKey(g.rand)
+		if err != nil {
+			return nil, err
+		}
+		private, public = priv, pub
+	case SSHKeyRSA:
+		if opts.Bits == 0 {
+			opts.Bits = DefaultRSABits
+		}
+		if opts.Bits < MinRSABits || opts.Bits > MaxRSABits || opts.Bits%1024 != 0 {
+			return nil, fmt.Errorf("無効なRSA鍵のビット数: %d (2048 / 3072 / 4096)", opts.Bits)
+		}
+		priv, err := rsa.GenerateKey(g.rand, opts.Bits)
+		if err != nil {
+			return nil, err
+		}
+		private, public = priv, &priv.PublicKey
+	default:
+		return nil, fmt.Errorf("未対応のSSH鍵の種類: %s", opts.Type)
+	}
+
+	sshPublic, err := ssh.NewPublicKey(public)
+	if err != nil {
+		return nil, err
+	}
+	pair := &SSHKeyPair{
+		Type:        opts.Type,
+		PublicKey:   strings.TrimSuffix(string(ssh.MarshalAuthorizedKey(sshPublic)), "\n"),
+		Fingerprint: ssh.FingerprintSHA256(sshPublic),
+	}
+	if opts.Comment != "" {
+		pair.PublicKey += " " + opts.Comment
+	}
+
+	var block *pem.Block
+	if opts.Passphrase {
+		passphrase, err := g.passwords.Generate(passphraseConfig)
+		if err != nil {
+			return nil, err
+		}
+		defer passphrase.Destroy()
+		// パスフレーズはレスポンスで一度だけ返すため、ここでのみ文字列に変換する
+		pair.Passphrase = passphrase.Reveal()
+		block, err = ssh.MarshalPrivateKeyWithPassphrase(private, opts.Comment, passphrase.Bytes())
+	} else {
+		block, err = ssh.MarshalPrivateKey(private, opts.Comment)
+	}
+	if err != nil {
+		return nil, err
+	}
+	pair.PrivateKey = string(pem.EncodeToMemory(block))
+	return pair, nil
+}
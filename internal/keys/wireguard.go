@@ -0,0 +1,70 @@
+package keys
+
+import (
+	"crypto/ecdh"
+	"encoding/base64"
+	"fmt"
+	"io"
+)
+
+// WireGuardの鍵長（バイト）
+const wireGuardKeyLen = 32
+
+// 生成されたWireGuardの鍵
+//
+// wg genkey / wg pubkey / wg genpsk と同じbase64形式。
+type WireGuardKeys struct {
+	PrivateKey   string `json:"privateKey"`
+	PublicKey    string `json:"publicKey"`
+	PresharedKey string `json:"presharedKey,omitempty"`
+}
+
+// Curve25519の鍵ペアと、必要であれば事前共有鍵を生成
+func (g *Generator) GenerateWireGuard(presharedKey bool) (*WireGuardKeys, error) {
+	private := make([]byte, wireGuardKeyLen)
+	if _, err := io.ReadFull(g.rand, private); err != nil {
+		return nil, err
+	}
+	// wg genkeyと同様にクランプした値を秘密鍵とする
+	private[0] &= 248
+	private[31] = (private[31] & 127) | 64
+
+	public, err := wireGuardPublicKey(private)
+	if err != nil {
+		return nil, err
+	}
+	keys := &WireGuardKeys{
+		PrivateKey: base64.StdEncoding.EncodeToString(private),
+		PublicKey:  base64.StdEncoding.EncodeToString(public),
+	}
+
+	if presharedKey {
+		psk := make([]byte, wireGuardKeyLen)
+		if _, err := io.ReadFull(g.rand, psk); err != nil {
+			return nil, err
+		}
+		keys.PresharedKey = base64.StdEncoding.EncodeToString(psk)
+	}
+	return keys, nil
+}
+
+// base64形式の秘密鍵から公開鍵を算出（wg pubkeyと同等）
+func WireGuardPublicKey(privateKey string) (string, error) {
+	private, err := base64.StdEncoding.DecodeString(privateKey)
+	if err != nil || len(private) != wireGuardKeyLen {
+		return "", fmt.Errorf("無効なWireGuardの秘密鍵です")
+	}
+	public, err := wireGuardPublicKey(private)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(public), nil
+}
+
+func wireGuardPublicKey(private []byte) ([]byte, error) {
+	key, err := ecdh.X25519().NewPrivateKey(private)
+	if err != nil {
+		return nil, err
+	}
+	return key.PublicKey().Bytes(), nil
+}
@@ -0,0 +1,81 @@
+package keys
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"testing/iotest"
+
+	"github.com/okamyuji/PasswordGenerator/internal/random"
+)
+
+func TestWireGuardPublicKey(t *testing.T) {
+	// RFC 7748 6.1のテストベクター
+	private, _ := hex.DecodeString("77076d0a7318a57d3c16c17251b26645df4c2f87ebc0992ab177fba51db92c2a")
+	public, _ := hex.DecodeString("8520f0098930a754748b7ddcb43ef75a0dbf3a0d26381af4eba4a98eaa9b4e6a")
+
+	got, err := WireGuardPublicKey(base64.StdEncoding.EncodeToString(private))
+	if err != nil {
+		t.Fatalf("WireGuardPublicKey() エラー = %v", err)
+	}
+	if want := base64.StdEncoding.EncodeToString(public); got != want {
+		t.Errorf("WireGuardPublicKey() = %q, want %q", got, want)
+	}
+
+	for _, invalid := range []string{"", "not base64", base64.StdEncoding.EncodeToString(private[:31])} {
+		if _, err := WireGuardPublicKey(invalid); err == nil {
+			t.Errorf("WireGuardPublicKey(%q) エラーが返されませんでした", invalid)
+		}
+	}
+}
+
+func TestGenerator_GenerateWireGuard(t *testing.T) {
+	for _, psk := range []bool{false, true} {
+		keys, err := New(&mockPasswordGenerator{}).GenerateWireGuard(psk)
+		if err != nil {
+			t.Fatalf("GenerateWireGuard() エラー = %v", err)
+		}
+
+		private, err := base64.StdEncoding.DecodeString(keys.PrivateKey)
+		if err != nil || len(private) != 32 {
+			t.Fatalf("PrivateKey = %q", keys.PrivateKey)
+		}
+		if private[0]&7 != 0 || private[31]&128 != 0 || private[31]&64 == 0 {
+			t.Errorf("秘密鍵がクランプされていません: %x", private)
+		}
+		if public, _ := WireGuardPublicKey(keys.PrivateKey); public != keys.PublicKey {
+			t.Errorf("PublicKey = %q, want %q", keys.PublicKey, public)
+		}
+
+		if !psk {
+			if keys.PresharedKey != "" {
+				t.Errorf("PresharedKey = %q, want empty", keys.PresharedKey)
+			}
+			continue
+		}
+		if b, err := base64.StdEncoding.DecodeString(keys.PresharedKey); err != nil || len(b) != 32 {
+			t.Errorf("PresharedKey = %q", keys.PresharedKey)
+		}
+	}
+}
+
+// 鍵素材は注入した乱数源から読み込む
+func TestGenerator_GenerateWireGuardReader(t *testing.T) {
+	first, err := NewWithReader(&mockPasswordGenerator{}, random.NewDeterministic("wireguard")).GenerateWireGuard(true)
+	if err != nil {
+		t.Fatalf("GenerateWireGuard() エラー = %v", err)
+	}
+	second, err := NewWithReader(&mockPasswordGenerator{}, random.NewDeterministic("wireguard")).GenerateWireGuard(true)
+	if err != nil {
+		t.Fatalf("GenerateWireGuard() エラー = %v", err)
+	}
+	if *first != *second {
+		t.Errorf("同じ乱数源から異なる鍵が生成されました: %+v, %+v", first, second)
+	}
+
+	errRead := errors.New("乱数源の異常")
+	if _, err := NewWithReader(&mockPasswordGenerator{}, iotest.ErrReader(errRead)).GenerateWireGuard(false); !errors.Is(err, errRead) {
+		t.Errorf("GenerateWireGuard() エラー = %v, want %v", err, errRead)
+	}
+}
@@ -0,0 +1,197 @@
+package mnemonic
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// BIP-39の英語の単語リスト（2048語）
+//
+//go:embed english.txt
+var englishText string
+
+var (
+	wordList  = strings.Split(strings.TrimSpace(englishText), "\n")
+	wordIndex = func() map[string]int {
+		m := make(map[string]int, len(wordList))
+		for i, w := range wordList {
+			m[w] = i
+		}
+		return m
+	}()
+)
+
+// 単語数の既定値（256ビット）
+const DefaultWords = 24
+
+// 生成された秘密とそのニーモニック
+type Result struct {
+	Entropy  string `json:"entropy"` // 16進数
+	Mnemonic string `json:"mnemonic"`
+	Words    int    `json:"words"`
+}
+
+// ランダムな秘密を生成し、BIP-39のニーモニックとして返す
+type Generator struct {
+	rand io.Reader
+}
+
+// crypto/randを乱数源とするGeneratorを作成
+func New() *Generator {
+	return NewWithReader(rand.Reader)
+}
+
+// 指定された乱数源を使うGeneratorを作成
+func NewWithReader(r io.Reader) *Generator {
+	return &Generator{rand: r}
+}
+
+// 指定された単語数（12 / 15 / 18 / 21 / 24）に対応する長さの秘密を生成
+func (g *Generator) Generate(words int) (*Result, error) {
+	if words == 0 {
+		words = DefaultWords
+	}
+	size, err := entropySize(words)
+	if err != nil {
+		return nil, err
+	}
+	entropy := make([]byte, size)
+	if _, err := io.ReadFull(g.rand, entropy); err != nil {
+		return nil, err
+	}
+	m, err := Encode(entropy)
+	if err != nil {
+		return nil, err
+	}
+	return &Result{Entropy: hex.EncodeToString(entropy), Mnemonic: m, Words: words}, nil
+}
+
+// バイト列をBIP-39のニーモニックにエンコード
+//
+// 長さは16〜32バイトの4の倍数で、SHA-256の先頭（バイト数/4）ビットをチェックサムとして付加する。
+func Encode(entropy []byte) (string, error) {
+	n := len(entropy)
+	if n < 16 || n > 32 || n%4 != 0 {
+		return "", fmt.Errorf("無効な長さ: %dバイト (16〜32バイトの4の倍数)", n)
+	}
+	sum := sha256.Sum256(entropy)
+	data := append(append([]byte{}, entropy...), sum[0])
+	words := (n*8 + n/4) / 11
+
+	out := make([]string, words)
+	for i := range out {
+		out[i] = wordList[bits11(data, i*11)]
+	}
+	return strings.Join(out, " "), nil
+}
+
+// BIP-39のニーモニックをバイト列にデコードし、チェックサムを検証
+//
+// 大文字小文字と単語間の空白の数は区別しない。
+func Decode(mnemonic string) ([]byte, error) {
+	words := strings.Fields(strings.ToLower(mnemonic))
+	size, err := entropySize(len(words))
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, size+1)
+	for i, w := range words {
+		idx, ok := wordIndex[w]
+		if !ok {
+			return nil, fmt.Errorf("単語リストにない単語です（%d語目）: %s", i+1, w)
+		}
+		for b := 0; b < 11; b++ {
+			if idx>>(10-b)&1 == 1 {
+				pos := i*11 + b
+				data[pos/8] |= 0x80 >> (pos % 8)
+			}
+		}
+	}
+
+	entropy := data[:size]
+	checksumBits := uint(size / 4)
+	sum := sha256.Sum256(entropy)
+	mask := byte(0xff) << (8 - checksumBits)
+	if data[size]&mask != sum[0]&mask {
+		return nil, fmt.Errorf("チェックサムが一致しません")
+	}
+	return entropy, nil
+}
+
+// 任意の長さのバイト列を先頭から11ビットずつ単語リストの単語に変換
+//
+// BIP-39と異なりチェックサムは付加しないため、誤りの検出は呼び出し元で行う。
+// 最後の単語の余りのビットは0で埋める。
+func EncodeWords(data []byte) string {
+	words := (len(data)*8 + 10) / 11
+	padded := make([]byte, (words*11+7)/8)
+	copy(padded, data)
+	defer clear(padded)
+
+	out := make([]string, words)
+	for i := range out {
+		out[i] = wordList[bits11(padded, i*11)]
+	}
+	return strings.Join(out, " ")
+}
+
+// EncodeWordsで変換した単語列をバイト列に戻す
+//
+// 単語数×11ビットに収まるバイト数を返すため、余りのビットが8ビット以上あると
+// 末尾に0のバイトが1つ付く。
+func DecodeWords(s string) ([]byte, error) {
+	words := strings.Fields(strings.ToLower(s))
+	if len(words) == 0 {
+		return nil, fmt.Errorf("単語がありません")
+	}
+
+	data := make([]byte, (len(words)*11+7)/8)
+	for i, w := range words {
+		idx, ok := wordIndex[w]
+		if !ok {
+			clear(data)
+			return nil, fmt.Errorf("単語リストにない単語です（%d語目）: %s", i+1, w)
+		}
+		for b := 0; b < 11; b++ {
+			if idx>>(10-b)&1 == 1 {
+				pos := i*11 + b
+				data[pos/8] |= 0x80 >> (pos % 8)
+			}
+		}
+	}
+
+	n := len(words) * 11 / 8
+	if slices.ContainsFunc(data[n:], func(b byte) bool { return b != 0 }) {
+		clear(data)
+		return nil, fmt.Errorf("単語列の末尾が不正です")
+	}
+	return data[:n], nil
+}
+
+// 単語数から秘密のバイト数を求める
+func entropySize(words int) (int, error) {
+	switch words {
+	case 12, 15, 18, 21, 24:
+		// 単語数 × 11 = 秘密のビット数 × 33/32
+		return words * 11 * 32 / 33 / 8, nil
+	default:
+		return 0, fmt.Errorf("無効な単語数: %d (12 / 15 / 18 / 21 / 24)", words)
+	}
+}
+
+// data の先頭からoffsetビット目以降の11ビットを取り出す
+func bits11(data []byte, offset int) int {
+	v := 0
+	for b := 0; b < 11; b++ {
+		pos := offset + b
+		v = v<<1 | int(data[pos/8]>>(7-pos%8)&1)
+	}
+	return v
+}
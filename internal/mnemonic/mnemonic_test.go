@@ -0,0 +1,183 @@
+package mnemonic
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/okamyuji/PasswordGenerator/internal/random"
+)
+
+// BIP-39の参照実装のテストベクター
+var vectors = []struct {
+	entropy  string
+	mnemonic string
+}{
+	{
+		entropy:  "00000000000000000000000000000000",
+		mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+	},
+	{
+		entropy:  "7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+		mnemonic: "legal winner thank year wave sausage worth useful legal winner thank yellow",
+	},
+	{
+		entropy:  "80808080808080808080808080808080",
+		mnemonic: "letter advice cage absurd amount doctor acoustic avoid letter advice cage above",
+	},
+	{
+		entropy:  "ffffffffffffffffffffffffffffffff",
+		mnemonic: "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo wrong",
+	},
+	{
+		entropy:  "000000000000000000000000000000000000000000000000",
+		mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon agent",
+	},
+	{
+		entropy:  "0000000000000000000000000000000000000000000000000000000000000000",
+		mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+	},
+	{
+		entropy:  "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		mnemonic: "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo vote",
+	},
+	{
+		entropy:  "9e885d952ad362caeb4efe34a8e91bd2",
+		mnemonic: "ozone drill grab fiber curtain grace pudding thank cruise elder eight picnic",
+	},
+}
+
+func TestEncodeDecode(t *testing.T) {
+	for _, tt := range vectors {
+		t.Run(tt.entropy, func(t *testing.T) {
+			entropy, _ := hex.DecodeString(tt.entropy)
+			got, err := Encode(entropy)
+			if err != nil {
+				t.Fatalf("Encode() エラー = %v", err)
+			}
+			if got != tt.mnemonic {
+				t.Errorf("Encode() = %q, want %q", got, tt.mnemonic)
+			}
+
+			decoded, err := Decode(tt.mnemonic)
+			if err != nil {
+				t.Fatalf("Decode() エラー = %v", err)
+			}
+			if !bytes.Equal(decoded, entropy) {
+				t.Errorf("Decode() = %x, want %x", decoded, entropy)
+			}
+		})
+	}
+}
+
+func TestDecode_Normalization(t *testing.T) {
+	got, err := Decode("  Legal WINNER thank year\twave sausage worth useful legal winner thank yellow\n")
+	if err != nil {
+		t.Fatalf("Decode() エラー = %v", err)
+	}
+	if hex.EncodeToString(got) != "7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f" {
+		t.Errorf("Decode() = %x", got)
+	}
+}
+
+func TestDecode_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		mnemonic string
+	}{
+		{name: "空", mnemonic: ""},
+		{name: "単語数が不正", mnemonic: "abandon abandon abandon"},
+		{name: "単語リストにない単語", mnemonic: strings.Repeat("abandon ", 11) + "bitcoin"},
+		{name: "チェックサム不一致", mnemonic: strings.Repeat("abandon ", 11) + "abandon"},
+		{name: "単語の入れ替え", mnemonic: "legal winner thank year wave sausage worth useful legal winner yellow thank"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Decode(tt.mnemonic); err == nil {
+				t.Error("Decode() エラーが返されませんでした")
+			}
+		})
+	}
+}
+
+func TestEncodeWords_RoundTrip(t *testing.T) {
+	for n := 1; n <= 40; n++ {
+		data := bytes.Repeat([]byte{0xa5}, n)
+		data[n-1] = byte(n)
+		words := EncodeWords(data)
+		if got := len(strings.Fields(words)); got != (n*8+10)/11 {
+			t.Errorf("EncodeWords(%dバイト) 単語数 = %d", n, got)
+		}
+		got, err := DecodeWords(strings.ToUpper(words))
+		if err != nil {
+			t.Fatalf("DecodeWords() エラー = %v", err)
+		}
+		// 余りのビットが8ビット以上ある場合は末尾に0のバイトが付く
+		if !bytes.Equal(got, data) && !bytes.Equal(got, append(data, 0)) {
+			t.Errorf("DecodeWords(EncodeWords(%x)) = %x", data, got)
+		}
+	}
+}
+
+func TestDecodeWords_Errors(t *testing.T) {
+	for _, words := range []string{"", "abandon bitcoin", "zoo zoo"} {
+		if _, err := DecodeWords(words); err == nil {
+			t.Errorf("DecodeWords(%q) エラーが返されませんでした", words)
+		}
+	}
+}
+
+func TestEncode_InvalidLength(t *testing.T) {
+	for _, n := range []int{0, 12, 17, 36} {
+		if _, err := Encode(make([]byte, n)); err == nil {
+			t.Errorf("Encode(%dバイト) エラーが返されませんでした", n)
+		}
+	}
+}
+
+func TestGenerator_Generate(t *testing.T) {
+	for _, words := range []int{0, 12, 18, 24} {
+		result, err := New().Generate(words)
+		if err != nil {
+			t.Fatalf("Generate(%d) エラー = %v", words, err)
+		}
+		want := words
+		if want == 0 {
+			want = DefaultWords
+		}
+		if result.Words != want || len(strings.Fields(result.Mnemonic)) != want {
+			t.Errorf("Generate(%d) = %+v", words, result)
+		}
+		decoded, err := Decode(result.Mnemonic)
+		if err != nil || hex.EncodeToString(decoded) != result.Entropy {
+			t.Errorf("Decode() = %x, %v, want %s", decoded, err, result.Entropy)
+		}
+	}
+
+	if _, err := New().Generate(13); err == nil {
+		t.Error("Generate(13) エラーが返されませんでした")
+	}
+}
+
+func TestWordList(t *testing.T) {
+	if len(wordList) != 2048 || wordList[0] != "abandon" || wordList[2047] != "zoo" {
+		t.Errorf("単語リストが不正です: %d語", len(wordList))
+	}
+}
+
+func TestGenerator_GenerateDeterministic(t *testing.T) {
+	result, err := NewWithReader(random.NewDeterministic("golden")).Generate(12)
+	if err != nil {
+		t.Fatalf("Generate() エラー = %v", err)
+	}
+	want := Result{
+		Entropy:  "17228871c30c61e2c6ba1a13000a8d6e",
+		Mnemonic: "blame begin broccoli maid ship vapor brain drive basic able person symbol",
+		Words:    12,
+	}
+	if *result != want {
+		t.Errorf("Generate() = %+v, want %+v", *result, want)
+	}
+}
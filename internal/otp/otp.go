@@ -0,0 +1,290 @@
+package otp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"io"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ワンタイムパスワードの種類
+type Type string
+
+const (
+	TypeTOTP Type = "totp"
+	TypeHOTP Type = "hotp"
+)
+
+// HMACのハッシュアルゴリズム
+type Algorithm string
+
+const (
+	AlgorithmSHA1   Algorithm = "SHA1"
+	AlgorithmSHA256 Algorithm = "SHA256"
+	AlgorithmSHA512 Algorithm = "SHA512"
+)
+
+// 既定値（多くの認証アプリが対応している組み合わせ）
+const (
+	DefaultDigits = 6
+	DefaultPeriod = 30
+	// 検証時に前後何ステップまでの時刻ずれを許容するか
+	DefaultSkew = 1
+	// HOTPの検証で先読みするカウンター数
+	DefaultLookAhead = 10
+)
+
+// シークレットのエンコーディング（RFC 4648 base32、パディングなし）
+var secretEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// OTPのパラメータ
+type Options struct {
+	Type      Type
+	Issuer    string
+	Account   string
+	Algorithm Algorithm
+	Digits    int
+	Period    int    // TOTPのみ（秒）
+	Counter   uint64 // HOTPのみ（初期カウンター）
+}
+
+// 生成されたOTPの鍵
+type Key struct {
+	Options
+	Secret string // base32エンコードされたシード
+	URI    string // otpauth:// プロビジョニングURI
+}
+
+type Generator struct {
+	rand io.Reader
+}
+
+// crypto/randを乱数源とするGeneratorを作成
+func New() *Generator {
+	return NewWithReader(rand.Reader)
+}
+
+// 指定された乱数源を使うGeneratorを作成
+func NewWithReader(r io.Reader) *Generator {
+	return &Generator{rand: r}
+}
+
+// 既定値を補完してオプションを検証
+func (o Options) normalize() (Options, error) {
+	if o.Type == "" {
+		o.Type = TypeTOTP
+	}
+	if o.Type != TypeTOTP && o.Type != TypeHOTP {
+		return o, fmt.Errorf("未対応のOTPの種類: %s", o.Type)
+	}
+	if o.Algorithm == "" {
+		o.Algorithm = AlgorithmSHA1
+	}
+	o.Algorithm = Algorithm(strings.ToUpper(string(o.Algorithm)))
+	if _, err := o.Algorithm.hash(); err != nil {
+		return o, err
+	}
+	if o.Digits == 0 {
+		o.Digits = DefaultDigits
+	}
+	if o.Digits < 6 || o.Digits > 8 {
+		return o, fmt.Errorf("無効な桁数: %d (6〜8)", o.Digits)
+	}
+	// 先読みの範囲と次のカウンターがuint64の範囲に収まるようにする
+	if o.Type == TypeHOTP && o.Counter > math.MaxUint64-DefaultLookAhead-1 {
+		return o, fmt.Errorf("無効なカウンター: %d", o.Counter)
+	}
+	if o.Type == TypeTOTP {
+		if o.Period == 0 {
+			o.Period = DefaultPeriod
+		}
+		if o.Period < 1 || o.Period > 300 {
+			return o, fmt.Errorf("無効な周期: %d秒", o.Period)
+		}
+	}
+	if strings.Contains(o.Issuer, ":") || strings.Contains(o.Account, ":") {
+		return o, fmt.Errorf("発行者とアカウント名にコロンは使用できません")
+	}
+	if o.Account == "" {
+		return o, fmt.Errorf("アカウント名が指定されていません")
+	}
+	return o, nil
+}
+
+func (a Algorithm) hash() (func() hash.Hash, error) {
+	switch a {
+	case AlgorithmSHA1:
+		return sha1.New, nil
+	case AlgorithmSHA256:
+		return sha256.New, nil
+	case AlgorithmSHA512:
+		return sha512.New, nil
+	default:
+		return nil, fmt.Errorf("未対応のアルゴリズム: %s", a)
+	}
+}
+
+// RFC 4226で推奨されるハッシュの出力長と同じ長さのシード
+func (a Algorithm) secretSize() int {
+	switch a {
+	case AlgorithmSHA256:
+		return 32
+	case AlgorithmSHA512:
+		return 64
+	default:
+		return 20
+	}
+}
+
+// 新しいシードを生成してプロビジョニングURIを作成
+func (g *Generator) Generate(opts Options) (*Key, error) {
+	opts, err := opts.normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	seed := make([]byte, opts.Algorithm.secretSize())
+	if _, err := io.ReadFull(g.rand, seed); err != nil {
+		return nil, err
+	}
+	key := &Key{Options: opts, Secret: secretEncoding.EncodeToString(seed)}
+	key.URI = key.provisioningURI()
+	return key, nil
+}
+
+// Key Uri Format に従ったotpauth:// URIを作成
+func (k *Key) provisioningURI() string {
+	label := k.Account
+	if k.Issuer != "" {
+		label = k.Issuer + ":" + k.Account
+	}
+
+	q := url.Values{}
+	q.Set("secret", k.Secret)
+	if k.Issuer != "" {
+		q.Set("issuer", k.Issuer)
+	}
+	q.Set("algorithm", string(k.Algorithm))
+	q.Set("digits", strconv.Itoa(k.Digits))
+	if k.Type == TypeTOTP {
+		q.Set("period", strconv.Itoa(k.Period))
+	} else {
+		q.Set("counter", strconv.FormatUint(k.Counter, 10))
+	}
+
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     string(k.Type),
+		Path:     "/" + label,
+		RawQuery: strings.ReplaceAll(q.Encode(), "+", "%20"),
+	}
+	return u.String()
+}
+
+// base32のシードをデコード（小文字、空白、パディングを許容）
+func DecodeSecret(secret string) ([]byte, error) {
+	s := strings.ToUpper(strings.ReplaceAll(secret, " ", ""))
+	s = strings.TrimRight(s, "=")
+	seed, err := secretEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("無効なシークレット: %w", err)
+	}
+	if len(seed) == 0 {
+		return nil, fmt.Errorf("シークレットが空です")
+	}
+	return seed, nil
+}
+
+// RFC 4226のHOTP値を計算
+func HOTP(seed []byte, counter uint64, digits int, alg Algorithm) (string, error) {
+	newHash, err := alg.hash()
+	if err != nil {
+		return "", err
+	}
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], counter)
+	mac := hmac.New(newHash, seed)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	// 動的切り捨て
+	offset := sum[len(sum)-1] & 0x0f
+	code := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < digits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", digits, code%mod), nil
+}
+
+// RFC 6238のTOTP値を計算
+func TOTP(seed []byte, t time.Time, period, digits int, alg Algorithm) (string, error) {
+	return HOTP(seed, uint64(t.Unix())/uint64(period), digits, alg)
+}
+
+// 入力されたコードを検証
+//
+// TOTPは前後DefaultSkewステップまで、HOTPはCounterからDefaultLookAhead個先まで照合する。
+// HOTPで一致した場合は次に使用すべきカウンターを返す。
+func Verify(secret, code string, opts Options, now time.Time) (bool, uint64, error) {
+	if opts.Account == "" {
+		// 検証ではラベルは不要なので、既定値の補完だけを行う
+		opts.Account = "-"
+	}
+	opts, err := opts.normalize()
+	if err != nil {
+		return false, 0, err
+	}
+	seed, err := DecodeSecret(secret)
+	if err != nil {
+		return false, 0, err
+	}
+	code = strings.ReplaceAll(code, " ", "")
+	if len(code) != opts.Digits {
+		return false, 0, nil
+	}
+
+	match := func(counter uint64) (bool, error) {
+		want, err := HOTP(seed, counter, opts.Digits, opts.Algorithm)
+		if err != nil {
+			return false, err
+		}
+		return subtle.ConstantTimeCompare([]byte(want), []byte(code)) == 1, nil
+	}
+
+	if opts.Type == TypeHOTP {
+		for i := uint64(0); i <= DefaultLookAhead; i++ {
+			c := opts.Counter + i
+			ok, err := match(c)
+			if err != nil || ok {
+				return ok, c + 1, err
+			}
+		}
+		return false, 0, nil
+	}
+
+	step := now.Unix() / int64(opts.Period)
+	for d := int64(-DefaultSkew); d <= DefaultSkew; d++ {
+		if step+d < 0 {
+			continue
+		}
+		ok, err := match(uint64(step + d))
+		if err != nil || ok {
+			return ok, 0, err
+		}
+	}
+	return false, 0, nil
+}
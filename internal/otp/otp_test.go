@@ -0,0 +1,199 @@
+package otp
+
+import (
+	"math"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/okamyuji/PasswordGenerator/internal/random"
+)
+
+func TestHOTP_RFC4226(t *testing.T) {
+	seed := []byte("12345678901234567890")
+	want := []string{"755224", "287082", "359152", "969429", "338314", "254676", "287922", "162583", "399871", "520489"}
+	for counter, w := range want {
+		got, err := HOTP(seed, uint64(counter), 6, AlgorithmSHA1)
+		if err != nil {
+			t.Fatalf("HOTP() エラー = %v", err)
+		}
+		if got != w {
+			t.Errorf("HOTP(counter=%d) = %v, want %v", counter, got, w)
+		}
+	}
+}
+
+func TestTOTP_RFC6238(t *testing.T) {
+	seeds := map[Algorithm][]byte{
+		AlgorithmSHA1:   []byte("12345678901234567890"),
+		AlgorithmSHA256: []byte("12345678901234567890123456789012"),
+		AlgorithmSHA512: []byte("1234567890123456789012345678901234567890123456789012345678901234"),
+	}
+	tests := []struct {
+		unix int64
+		alg  Algorithm
+		want string
+	}{
+		{unix: 59, alg: AlgorithmSHA1, want: "94287082"},
+		{unix: 59, alg: AlgorithmSHA256, want: "46119246"},
+		{unix: 59, alg: AlgorithmSHA512, want: "90693936"},
+		{unix: 1111111109, alg: AlgorithmSHA1, want: "07081804"},
+		{unix: 1234567890, alg: AlgorithmSHA256, want: "91819424"},
+		{unix: 2000000000, alg: AlgorithmSHA512, want: "38618901"},
+		{unix: 20000000000, alg: AlgorithmSHA1, want: "65353130"},
+	}
+	for _, tt := range tests {
+		got, err := TOTP(seeds[tt.alg], time.Unix(tt.unix, 0), 30, 8, tt.alg)
+		if err != nil {
+			t.Fatalf("TOTP() エラー = %v", err)
+		}
+		if got != tt.want {
+			t.Errorf("TOTP(%d, %s) = %v, want %v", tt.unix, tt.alg, got, tt.want)
+		}
+	}
+}
+
+func TestGenerator_Generate(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       Options
+		wantSeed   int
+		wantParams map[string]string
+		wantErr    bool
+	}{
+		{
+			name:     "既定のTOTP",
+			opts:     Options{Issuer: "Acme Corp", Account: "alice@example.com"},
+			wantSeed: 20,
+			wantParams: map[string]string{
+				"issuer": "Acme Corp", "algorithm": "SHA1", "digits": "6", "period": "30",
+			},
+		},
+		{
+			name:     "SHA256の8桁",
+			opts:     Options{Account: "svc", Algorithm: "sha256", Digits: 8, Period: 60},
+			wantSeed: 32,
+			wantParams: map[string]string{
+				"algorithm": "SHA256", "digits": "8", "period": "60",
+			},
+		},
+		{
+			name:     "HOTP",
+			opts:     Options{Type: TypeHOTP, Account: "svc", Counter: 5},
+			wantSeed: 20,
+			wantParams: map[string]string{
+				"counter": "5",
+			},
+		},
+		{name: "アカウントなし", opts: Options{}, wantErr: true},
+		{name: "コロンを含む発行者", opts: Options{Issuer: "a:b", Account: "x"}, wantErr: true},
+		{name: "不正な桁数", opts: Options{Account: "x", Digits: 10}, wantErr: true},
+		{name: "未対応のアルゴリズム", opts: Options{Account: "x", Algorithm: "MD5"}, wantErr: true},
+		{name: "未対応の種類", opts: Options{Account: "x", Type: "sms"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := New().Generate(tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Generate() エラー = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			seed, err := DecodeSecret(key.Secret)
+			if err != nil || len(seed) != tt.wantSeed {
+				t.Errorf("シードの長さ = %d, %v, want %d", len(seed), err, tt.wantSeed)
+			}
+
+			u, err := url.Parse(key.URI)
+			if err != nil {
+				t.Fatalf("URIの解析に失敗: %v", err)
+			}
+			if u.Scheme != "otpauth" || u.Host != string(key.Type) {
+				t.Errorf("URI = %v", key.URI)
+			}
+			wantLabel := "/" + tt.opts.Account
+			if tt.opts.Issuer != "" {
+				wantLabel = "/" + tt.opts.Issuer + ":" + tt.opts.Account
+			}
+			if u.Path != wantLabel {
+				t.Errorf("ラベル = %v, want %v", u.Path, wantLabel)
+			}
+			if strings.Contains(key.URI, "+") {
+				t.Errorf("空白が+でエンコードされています: %v", key.URI)
+			}
+			q := u.Query()
+			if q.Get("secret") != key.Secret {
+				t.Errorf("secret = %v, want %v", q.Get("secret"), key.Secret)
+			}
+			for k, v := range tt.wantParams {
+				if q.Get(k) != v {
+					t.Errorf("%s = %v, want %v", k, q.Get(k), v)
+				}
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	const secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ" // "12345678901234567890"
+	now := time.Unix(1111111109, 0)
+
+	tests := []struct {
+		name        string
+		code        string
+		opts        Options
+		want        bool
+		wantCounter uint64
+	}{
+		{name: "TOTP - 現在のステップ", code: "07081804", opts: Options{Digits: 8}, want: true},
+		{name: "TOTP - 1ステップ前", code: mustTOTP(t, now.Add(-30*time.Second)), opts: Options{}, want: true},
+		{name: "TOTP - 2ステップ前", code: mustTOTP(t, now.Add(-60*time.Second)), opts: Options{}, want: false},
+		{name: "TOTP - 桁数不一致", code: "081804", opts: Options{Digits: 8}, want: false},
+		{name: "HOTP - 先読み", code: "254676", opts: Options{Type: TypeHOTP, Counter: 2}, want: true, wantCounter: 6},
+		{name: "HOTP - 過去のカウンター", code: "755224", opts: Options{Type: TypeHOTP, Counter: 1}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, next, err := Verify(strings.ToLower(secret), tt.code, tt.opts, now)
+			if err != nil {
+				t.Fatalf("Verify() エラー = %v", err)
+			}
+			if got != tt.want || next != tt.wantCounter {
+				t.Errorf("Verify() = %v, %d, want %v, %d", got, next, tt.want, tt.wantCounter)
+			}
+		})
+	}
+
+	if _, _, err := Verify("not base32!", "123456", Options{}, now); err == nil {
+		t.Error("Verify() 不正なシークレットでエラーが返されませんでした")
+	}
+	// 先読みの範囲がuint64を超えるカウンター
+	for _, counter := range []uint64{math.MaxUint64, math.MaxUint64 - DefaultLookAhead} {
+		if _, _, err := Verify(secret, "755224", Options{Type: TypeHOTP, Counter: counter}, now); err == nil {
+			t.Errorf("Verify() カウンター %d でエラーが返されませんでした", counter)
+		}
+	}
+}
+
+func mustTOTP(t *testing.T, at time.Time) string {
+	t.Helper()
+	code, err := TOTP([]byte("12345678901234567890"), at, DefaultPeriod, DefaultDigits, AlgorithmSHA1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return code
+}
+
+func TestGenerator_GenerateDeterministic(t *testing.T) {
+	key, err := NewWithReader(random.NewDeterministic("golden")).Generate(Options{Issuer: "Acme", Account: "alice"})
+	if err != nil {
+		t.Fatalf("Generate() エラー = %v", err)
+	}
+	if want := "C4RIQ4ODBRQ6FRV2DIJQACUNNZNO5U5X"; key.Secret != want {
+		t.Errorf("Secret = %q, want %q", key.Secret, want)
+	}
+}
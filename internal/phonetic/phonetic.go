@@ -0,0 +1,190 @@
+package phonetic
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// 既定の区切りの文字数と区切り文字
+const (
+	DefaultChunkSize = 4
+	DefaultSeparator = "-"
+	// 区切りの文字数の上限
+	MaxChunkSize = 16
+)
+
+// 文字の種類（UIで色分けするためのタグ）
+type Class string
+
+const (
+	ClassUppercase Class = "uppercase"
+	ClassLowercase Class = "lowercase"
+	ClassDigit     Class = "digit"
+	ClassSymbol    Class = "symbol"
+	ClassOther     Class = "other"
+)
+
+// NATOフォネティックコード（A〜Z）
+var natoAlphabet = [26]string{
+	"Alfa", "Bravo", "Charlie", "Delta", "Echo", "Foxtrot", "Golf", "Hotel", "India",
+	"Juliett", "Kilo", "Lima", "Mike", "November", "Oscar", "Papa", "Quebec", "Romeo",
+	"Sierra", "Tango", "Uniform", "Victor", "Whiskey", "X-ray", "Yankee", "Zulu",
+}
+
+// NATOフォネティックコードのカタカナ読み（A〜Z）
+var natoAlphabetJa = [26]string{
+	"アルファ", "ブラボー", "チャーリー", "デルタ", "エコー", "フォックストロット", "ゴルフ", "ホテル", "インディア",
+	"ジュリエット", "キロ", "リマ", "マイク", "ノベンバー", "オスカー", "パパ", "ケベック", "ロメオ",
+	"シエラ", "タンゴ", "ユニフォーム", "ビクター", "ウィスキー", "エックスレイ", "ヤンキー", "ズールー",
+}
+
+// 数字の読み（0〜9）
+var (
+	digitNames   = [10]string{"Zero", "One", "Two", "Three", "Four", "Five", "Six", "Seven", "Eight", "Nine"}
+	digitNamesJa = [10]string{"ゼロ", "イチ", "ニ", "サン", "ヨン", "ゴ", "ロク", "ナナ", "ハチ", "キュウ"}
+)
+
+// 記号の英語名と日本語の読み
+var symbolNames = map[rune]struct{ en, ja string }{
+	' ':  {"space", "スペース"},
+	'!':  {"exclamation", "エクスクラメーション"},
+	'"':  {"double quote", "ダブルクォート"},
+	'#':  {"hash", "シャープ"},
+	'$':  {"dollar", "ドル"},
+	'%':  {"percent", "パーセント"},
+	'&':  {"ampersand", "アンド"},
+	'\'': {"apostrophe", "シングルクォート"},
+	'(':  {"open paren", "丸カッコ開き"},
+	')':  {"close paren", "丸カッコ閉じ"},
+	'*':  {"asterisk", "アスタリスク"},
+	'+':  {"plus", "プラス"},
+	',':  {"comma", "カンマ"},
+	'-':  {"hyphen", "ハイフン"},
+	'.':  {"period", "ピリオド"},
+	'/':  {"slash", "スラッシュ"},
+	':':  {"colon", "コロン"},
+	';':  {"semicolon", "セミコロン"},
+	'<':  {"less than", "小なり"},
+	'=':  {"equals", "イコール"},
+	'>':  {"greater than", "大なり"},
+	'?':  {"question", "クエスチョン"},
+	'@':  {"at", "アットマーク"},
+	'[':  {"open bracket", "角カッコ開き"},
+	'\\': {"backslash", "バックスラッシュ"},
+	']':  {"close bracket", "角カッコ閉じ"},
+	'^':  {"caret", "キャレット"},
+	'_':  {"underscore", "アンダースコア"},
+	'`':  {"backtick", "バッククォート"},
+	'{':  {"open brace", "波カッコ開き"},
+	'|':  {"pipe", "パイプ"},
+	'}':  {"close brace", "波カッコ閉じ"},
+	'~':  {"tilde", "チルダ"},
+}
+
+// 1文字とその種類、英語と日本語の読み
+type Letter struct {
+	Char     string `json:"char"`
+	Class    Class  `json:"class"`
+	English  string `json:"en"`
+	Japanese string `json:"ja"`
+}
+
+// 読み上げや書き写しのための表現
+type Rendering struct {
+	Chunks  []string `json:"chunks"`
+	Chunked string   `json:"chunked"` // 区切り文字で連結したもの（例: xK7#-mQ2!）
+	Letters []Letter `json:"letters"`
+}
+
+// 表現のオプション
+type Options struct {
+	ChunkSize int    // 0なら既定値
+	Separator string // 空文字なら既定値
+}
+
+// 既定値を補い、区切りの指定を検証
+func (o Options) Normalize() (Options, error) {
+	if o.ChunkSize == 0 {
+		o.ChunkSize = DefaultChunkSize
+	}
+	if o.ChunkSize < 1 || o.ChunkSize > MaxChunkSize {
+		return o, fmt.Errorf("無効な区切りの文字数: %d (1〜%d)", o.ChunkSize, MaxChunkSize)
+	}
+	if o.Separator == "" {
+		o.Separator = DefaultSeparator
+	}
+	if utf8.RuneCountInString(o.Separator) > 3 {
+		return o, fmt.Errorf("区切り文字が長すぎます: %q", o.Separator)
+	}
+	return o, nil
+}
+
+// パスワードを区切りと読みで表現
+func Render(s string, opts Options) (*Rendering, error) {
+	opts, err := opts.Normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	chunks := Chunk(s, opts.ChunkSize)
+	return &Rendering{
+		Chunks:  chunks,
+		Chunked: strings.Join(chunks, opts.Separator),
+		Letters: Spell(s),
+	}, nil
+}
+
+// 1文字ずつ種類を判定し、NATOフォネティックコードと記号名で綴る
+//
+// 英語では大文字は大文字の単語（ALFA）、小文字は小文字の単語（alfa）で区別し、
+// 日本語では「大文字」「小文字」を前に付ける。
+// 読みが定義されていない文字はUnicodeのコードポイントで表す。
+func Spell(s string) []Letter {
+	letters := make([]Letter, 0, utf8.RuneCountInString(s))
+	for _, r := range s {
+		letters = append(letters, spell(r))
+	}
+	return letters
+}
+
+func spell(r rune) Letter {
+	l := Letter{Char: string(r)}
+	switch {
+	case r >= 'A' && r <= 'Z':
+		l.Class = ClassUppercase
+		l.English = strings.ToUpper(natoAlphabet[r-'A'])
+		l.Japanese = "大文字 " + natoAlphabetJa[r-'A']
+	case r >= 'a' && r <= 'z':
+		l.Class = ClassLowercase
+		l.English = strings.ToLower(natoAlphabet[r-'a'])
+		l.Japanese = "小文字 " + natoAlphabetJa[r-'a']
+	case r >= '0' && r <= '9':
+		l.Class = ClassDigit
+		l.English = digitNames[r-'0']
+		l.Japanese = digitNamesJa[r-'0']
+	default:
+		if name, ok := symbolNames[r]; ok {
+			l.Class = ClassSymbol
+			l.English, l.Japanese = name.en, name.ja
+		} else {
+			l.Class = ClassOther
+			l.English = fmt.Sprintf("U+%04X", r)
+			l.Japanese = l.English
+		}
+	}
+	return l
+}
+
+// 文字列をsize文字ごとのまとまりに分割（sizeが0以下なら既定値）
+func Chunk(s string, size int) []string {
+	if size <= 0 {
+		size = DefaultChunkSize
+	}
+	runes := []rune(s)
+	chunks := make([]string, 0, (len(runes)+size-1)/size)
+	for i := 0; i < len(runes); i += size {
+		chunks = append(chunks, string(runes[i:min(i+size, len(runes))]))
+	}
+	return chunks
+}
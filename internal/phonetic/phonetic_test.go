@@ -0,0 +1,84 @@
+package phonetic
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSpell(t *testing.T) {
+	got := Spell("xK7#mQ2!é")
+	want := []Letter{
+		{Char: "x", Class: ClassLowercase, English: "x-ray", Japanese: "小文字 エックスレイ"},
+		{Char: "K", Class: ClassUppercase, English: "KILO", Japanese: "大文字 キロ"},
+		{Char: "7", Class: ClassDigit, English: "Seven", Japanese: "ナナ"},
+		{Char: "#", Class: ClassSymbol, English: "hash", Japanese: "シャープ"},
+		{Char: "m", Class: ClassLowercase, English: "mike", Japanese: "小文字 マイク"},
+		{Char: "Q", Class: ClassUppercase, English: "QUEBEC", Japanese: "大文字 ケベック"},
+		{Char: "2", Class: ClassDigit, English: "Two", Japanese: "ニ"},
+		{Char: "!", Class: ClassSymbol, English: "exclamation", Japanese: "エクスクラメーション"},
+		{Char: "é", Class: ClassOther, English: "U+00E9", Japanese: "U+00E9"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Spell() = %v, want %v", got, want)
+	}
+}
+
+func TestSpell_AllPrintableASCII(t *testing.T) {
+	for r := rune(0x20); r < 0x7f; r++ {
+		l := Spell(string(r))[0]
+		if l.Class == ClassOther || l.English == "" || l.Japanese == "" {
+			t.Errorf("Spell(%q) に読みがありません: %+v", r, l)
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name        string
+		s           string
+		opts        Options
+		wantChunked string
+		wantErr     bool
+	}{
+		{name: "既定値", s: "xK7#mQ2!", wantChunked: "xK7#-mQ2!"},
+		{name: "区切りの文字数と区切り文字を指定", s: "abcdefgh", opts: Options{ChunkSize: 3, Separator: " "}, wantChunked: "abc def gh"},
+		{name: "区切りの文字数が大きすぎる", s: "abc", opts: Options{ChunkSize: MaxChunkSize + 1}, wantErr: true},
+		{name: "負の区切りの文字数", s: "abc", opts: Options{ChunkSize: -1}, wantErr: true},
+		{name: "区切り文字が長すぎる", s: "abc", opts: Options{Separator: "----"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Render(tt.s, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Render() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Chunked != tt.wantChunked {
+				t.Errorf("Render().Chunked = %q, want %q", got.Chunked, tt.wantChunked)
+			}
+			if len(got.Letters) != len([]rune(tt.s)) {
+				t.Errorf("Render().Letters の数 = %d, want %d", len(got.Letters), len([]rune(tt.s)))
+			}
+		})
+	}
+}
+
+func TestChunk(t *testing.T) {
+	tests := []struct {
+		s    string
+		size int
+		want []string
+	}{
+		{s: "xK7#mQ2!", size: 4, want: []string{"xK7#", "mQ2!"}},
+		{s: "abcdefghij", size: 0, want: []string{"abcd", "efgh", "ij"}},
+		{s: "パスワード", size: 3, want: []string{"パスワ", "ード"}},
+		{s: "", size: 4, want: []string{}},
+	}
+	for _, tt := range tests {
+		if got := Chunk(tt.s, tt.size); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Chunk(%q, %d) = %q, want %q", tt.s, tt.size, got, tt.want)
+		}
+	}
+}
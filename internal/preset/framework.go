@@ -0,0 +1,143 @@
+package preset
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/okamyuji/PasswordGenerator/internal/format"
+	"github.com/okamyuji/PasswordGenerator/internal/random"
+	"github.com/okamyuji/PasswordGenerator/internal/token"
+)
+
+// シークレットキーのプリセットを持つフレームワーク
+type Framework string
+
+const (
+	FrameworkDjango   Framework = "django"
+	FrameworkRails    Framework = "rails"
+	FrameworkLaravel  Framework = "laravel"
+	FrameworkFlask    Framework = "flask"
+	FrameworkNextAuth Framework = "nextauth"
+)
+
+// Djangoのget_random_secret_key()と同じアルファベット
+const djangoAlphabet = "abcdefghijklmnopqrstuvwxyz0123456789!@#$%^&*(-_=+)"
+
+// フレームワークごとのシークレットの形式
+type framework struct {
+	envName     string
+	description string
+	generate    func(g *Generator) (string, error)
+}
+
+var frameworks = map[Framework]framework{
+	// django-admin startproject と同じ50文字
+	FrameworkDjango: {
+		envName:     "SECRET_KEY",
+		description: "Django SECRET_KEY（50文字）",
+		generate: func(g *Generator) (string, error) {
+			return random.String(g.rand, []rune(djangoAlphabet), 50)
+		},
+	},
+	// bin/rails secret と同じ64バイトの16進数（128文字）
+	FrameworkRails: {
+		envName:     "SECRET_KEY_BASE",
+		description: "Rails secret_key_base（128文字の16進数）",
+		generate: func(g *Generator) (string, error) {
+			return g.tokens.Generate(token.Options{Bytes: 64, Encoding: token.EncodingHex})
+		},
+	},
+	// php artisan key:generate と同じAES-256-CBC用の32バイト鍵
+	FrameworkLaravel: {
+		envName:     "APP_KEY",
+		description: "Laravel APP_KEY（base64:付きの32バイト鍵）",
+		generate: func(g *Generator) (string, error) {
+			key, err := g.tokens.Generate(token.Options{Bytes: 32, Encoding: token.EncodingBase64})
+			return "base64:" + key, err
+		},
+	},
+	// Flaskのドキュメントにある secrets.token_hex() と同じ32バイトの16進数
+	FrameworkFlask: {
+		envName:     "SECRET_KEY",
+		description: "Flask SECRET_KEY（64文字の16進数）",
+		generate: func(g *Generator) (string, error) {
+			return g.tokens.Generate(token.Options{Bytes: 32, Encoding: token.EncodingHex})
+		},
+	},
+	// NextAuth.jsのドキュメントにある openssl rand -base64 32 と同じ形式
+	FrameworkNextAuth: {
+		envName:     "NEXTAUTH_SECRET",
+		description: "NextAuth.js NEXTAUTH_SECRET（32バイトのbase64）",
+		generate: func(g *Generator) (string, error) {
+			return g.tokens.Generate(token.Options{Bytes: 32, Encoding: token.EncodingBase64})
+		},
+	},
+}
+
+// プリセットが使用するトークン生成のコントラクト
+type TokenGenerator interface {
+	Generate(opts token.Options) (string, error)
+}
+
+// フレームワークのシークレットキー
+type Secret struct {
+	Framework   Framework `json:"framework"`
+	EnvName     string    `json:"envName"`
+	Value       string    `json:"secret"`
+	Description string    `json:"description"`
+}
+
+type Generator struct {
+	tokens TokenGenerator
+	rand   io.Reader
+}
+
+// 16進数やbase64の鍵の生成に使うトークンジェネレーターを指定して作成
+func New(tokens TokenGenerator) *Generator {
+	return NewWithReader(tokens, rand.Reader)
+}
+
+// Djangoのように文字単位で選ぶ形式の乱数源も指定して作成
+func NewWithReader(tokens TokenGenerator, r io.Reader) *Generator {
+	return &Generator{tokens: tokens, rand: r}
+}
+
+// 対応しているフレームワークの一覧
+func Frameworks() []Framework {
+	names := make([]Framework, 0, len(frameworks))
+	for name := range frameworks {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
+
+// フレームワーク名を解析
+func ParseFramework(name string) (Framework, error) {
+	f := Framework(strings.ToLower(strings.TrimSpace(name)))
+	if _, ok := frameworks[f]; !ok {
+		return "", fmt.Errorf("未対応のフレームワーク: %s", name)
+	}
+	return f, nil
+}
+
+// フレームワークが期待する形式と長さのシークレットキーを生成
+func (g *Generator) Generate(f Framework) (*Secret, error) {
+	fw, ok := frameworks[f]
+	if !ok {
+		return nil, fmt.Errorf("未対応のフレームワーク: %s", f)
+	}
+	value, err := fw.generate(g)
+	if err != nil {
+		return nil, err
+	}
+	return &Secret{Framework: f, EnvName: fw.envName, Value: value, Description: fw.description}, nil
+}
+
+// .envファイルの1行としてレンダリング
+func (s *Secret) EnvLine() (string, error) {
+	return format.DotEnv([]format.Secret{{Key: s.EnvName, Value: s.Value}})
+}
@@ -0,0 +1,85 @@
+package preset
+
+import (
+	"encoding/base64"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/okamyuji/PasswordGenerator/internal/random"
+	"github.com/okamyuji/PasswordGenerator/internal/token"
+)
+
+func TestGenerator_Generate(t *testing.T) {
+	tests := []struct {
+		framework   Framework
+		wantEnvName string
+		wantPattern string
+	}{
+		{framework: FrameworkDjango, wantEnvName: "SECRET_KEY", wantPattern: `^[a-z0-9!@#$%^&*(\-_=+)]{50}$`},
+		{framework: FrameworkRails, wantEnvName: "SECRET_KEY_BASE", wantPattern: `^[0-9a-f]{128}$`},
+		{framework: FrameworkLaravel, wantEnvName: "APP_KEY", wantPattern: `^base64:[A-Za-z0-9+/]{43}=$`},
+		{framework: FrameworkFlask, wantEnvName: "SECRET_KEY", wantPattern: `^[0-9a-f]{64}$`},
+		{framework: FrameworkNextAuth, wantEnvName: "NEXTAUTH_SECRET", wantPattern: `^[A-Za-z0-9+/]{43}=$`},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.framework), func(t *testing.T) {
+			secret, err := New(token.New()).Generate(tt.framework)
+			if err != nil {
+				t.Fatalf("Generate() エラー = %v", err)
+			}
+			if secret.EnvName != tt.wantEnvName || secret.Framework != tt.framework || secret.Description == "" {
+				t.Errorf("Secret = %+v", secret)
+			}
+			if !regexp.MustCompile(tt.wantPattern).MatchString(secret.Value) {
+				t.Errorf("シークレット %q が形式 %s に一致しません", secret.Value, tt.wantPattern)
+			}
+
+			line, err := secret.EnvLine()
+			if err != nil {
+				t.Fatalf("EnvLine() エラー = %v", err)
+			}
+			if want := tt.wantEnvName + "='" + secret.Value + "'\n"; line != want {
+				t.Errorf("EnvLine() = %q, want %q", line, want)
+			}
+		})
+	}
+}
+
+func TestGenerator_GenerateLaravelKeyLength(t *testing.T) {
+	secret, err := New(token.New()).Generate(FrameworkLaravel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(secret.Value, "base64:"))
+	if err != nil || len(key) != 32 {
+		t.Errorf("APP_KEYの鍵長 = %d, %v, want 32", len(key), err)
+	}
+}
+
+func TestParseFramework(t *testing.T) {
+	if f, err := ParseFramework(" Django "); err != nil || f != FrameworkDjango {
+		t.Errorf("ParseFramework() = %v, %v", f, err)
+	}
+	if _, err := ParseFramework("spring"); err == nil {
+		t.Error("ParseFramework() エラーが返されませんでした")
+	}
+	if _, err := New(token.New()).Generate("spring"); err == nil {
+		t.Error("Generate() エラーが返されませんでした")
+	}
+	if got := len(Frameworks()); got != 5 {
+		t.Errorf("Frameworks() = %d件, want 5", got)
+	}
+}
+
+func TestGenerator_GenerateDeterministic(t *testing.T) {
+	rng := random.NewDeterministic("golden")
+	secret, err := NewWithReader(token.NewWithReader(rng), rng).Generate(FrameworkDjango)
+	if err != nil {
+		t.Fatalf("Generate() エラー = %v", err)
+	}
+	if want := "vk$qh@0dj%%=!a0(x(-7#ah4qg@h_u_4dmji+vu-0=4m#ii_6s"; secret.Value != want {
+		t.Errorf("Generate() = %q, want %q", secret.Value, want)
+	}
+}
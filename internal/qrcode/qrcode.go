@@ -0,0 +1,82 @@
+package qrcode
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"rsc.io/qr"
+)
+
+// QRコード周囲の余白（クワイエットゾーン）のモジュール数
+const quietZone = 4
+
+// 1モジュールあたりの既定のピクセル数
+const DefaultScale = 8
+
+// エンコード済みのQRコード
+type Code struct {
+	code *qr.Code
+}
+
+// テキストを誤り訂正レベルMでQRコードにエンコード
+func Encode(text string) (*Code, error) {
+	if text == "" {
+		return nil, fmt.Errorf("QRコードにする内容が空です")
+	}
+	code, err := qr.Encode(text, qr.M)
+	if err != nil {
+		return nil, fmt.Errorf("QRコードのエンコードに失敗: %w", err)
+	}
+	return &Code{code: code}, nil
+}
+
+// 1辺のモジュール数（余白を除く）
+func (c *Code) Size() int {
+	return c.code.Size
+}
+
+// (x, y)のモジュールが黒か判定
+func (c *Code) Black(x, y int) bool {
+	return c.code.Black(x, y)
+}
+
+// 余白付きのPNG画像としてレンダリング
+func (c *Code) PNG(scale int) []byte {
+	if scale <= 0 {
+		scale = DefaultScale
+	}
+	code := *c.code
+	code.Scale = scale
+	return code.PNG()
+}
+
+// 余白付きのSVG画像としてレンダリング
+//
+// 黒いモジュールを横方向に連結した1つのpathで描画し、拡大しても鮮明に表示できるようにする。
+func (c *Code) SVG() string {
+	size := c.code.Size + 2*quietZone
+	dim := strconv.Itoa(size)
+
+	var path strings.Builder
+	for y := 0; y < c.code.Size; y++ {
+		for x := 0; x < c.code.Size; x++ {
+			if !c.code.Black(x, y) {
+				continue
+			}
+			run := 1
+			for c.code.Black(x+run, y) {
+				run++
+			}
+			fmt.Fprintf(&path, "M%d %dh%dv1h-%dz", x+quietZone, y+quietZone, run, run)
+			x += run - 1
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 ` + dim + " " + dim + `" shape-rendering="crispEdges">`)
+	sb.WriteString(`<rect width="` + dim + `" height="` + dim + `" fill="#fff"/>`)
+	sb.WriteString(`<path fill="#000" d="` + path.String() + `"/>`)
+	sb.WriteString("</svg>")
+	return sb.String()
+}
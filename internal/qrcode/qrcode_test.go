@@ -0,0 +1,77 @@
+package qrcode
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+// 左上・右上・左下の7x7のファインダーパターンを確認
+func hasFinderPatterns(c *Code) bool {
+	n := c.Size()
+	for _, origin := range [][2]int{{0, 0}, {n - 7, 0}, {0, n - 7}} {
+		for i := 0; i < 7; i++ {
+			// 外枠はすべて黒
+			if !c.Black(origin[0]+i, origin[1]) || !c.Black(origin[0]+i, origin[1]+6) ||
+				!c.Black(origin[0], origin[1]+i) || !c.Black(origin[0]+6, origin[1]+i) {
+				return false
+			}
+		}
+		// 内側の白い枠と中央の3x3の黒
+		if c.Black(origin[0]+1, origin[1]+1) || !c.Black(origin[0]+3, origin[1]+3) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestEncode(t *testing.T) {
+	code, err := Encode("otpauth://totp/Example:alice?secret=JBSWY3DPEHPK3PXP&issuer=Example")
+	if err != nil {
+		t.Fatalf("Encode() エラー = %v", err)
+	}
+	if (code.Size()-17)%4 != 0 {
+		t.Errorf("Size() = %d, QRコードのサイズではありません", code.Size())
+	}
+	if !hasFinderPatterns(code) {
+		t.Error("ファインダーパターンが見つかりません")
+	}
+
+	if _, err := Encode(""); err == nil {
+		t.Error("Encode() 空の内容でエラーが返されませんでした")
+	}
+	if _, err := Encode(strings.Repeat("x", 4000)); err == nil {
+		t.Error("Encode() 容量を超える内容でエラーが返されませんでした")
+	}
+}
+
+func TestCode_PNG(t *testing.T) {
+	code, err := Encode("hello")
+	if err != nil {
+		t.Fatalf("Encode() エラー = %v", err)
+	}
+	img, err := png.Decode(bytes.NewReader(code.PNG(2)))
+	if err != nil {
+		t.Fatalf("PNGのデコードに失敗: %v", err)
+	}
+	want := (code.Size() + 2*quietZone) * 2
+	if b := img.Bounds(); b.Dx() != want || b.Dy() != want {
+		t.Errorf("PNGのサイズ = %v, want %d", b, want)
+	}
+}
+
+func TestCode_SVG(t *testing.T) {
+	code, err := Encode("hello")
+	if err != nil {
+		t.Fatalf("Encode() エラー = %v", err)
+	}
+	svg := code.SVG()
+	if !strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 29 29"`) {
+		t.Errorf("SVG() = %s", svg)
+	}
+	// 左上のファインダーパターンの最上段は余白の内側から7モジュール連続する
+	if !strings.Contains(svg, `d="M4 4h7v1h-7z`) {
+		t.Errorf("SVG() ファインダーパターンが描画されていません: %s", svg)
+	}
+}
@@ -0,0 +1,135 @@
+package random
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"sync"
+)
+
+// バッファサイズの範囲
+const (
+	minBufferSize = 64
+	MaxBufferSize = 4096
+)
+
+var bufferPool = sync.Pool{
+	New: func() any { return new([MaxBufferSize]byte) },
+}
+
+// 乱数源からブロック単位でまとめて読み込むリーダー
+//
+// 1文字ごとに乱数源を読むとシステムコールが律速になるため、バッファから切り出して使う。
+// ゴルーチン間で共有せず、使い終わったらReleaseでバッファを消去して返却する。
+type Buffered struct {
+	r    io.Reader
+	buf  *[MaxBufferSize]byte
+	size int // 1回の読み込みで補充するバイト数
+	pos  int
+	end  int
+}
+
+// 一度に補充するバイト数の目安を指定してBufferedを作成
+//
+// 乱数源から読み込むのは必要になった時点で、使われなかった残りは破棄される。
+func NewBuffered(r io.Reader, sizeHint int) *Buffered {
+	size := min(max(sizeHint, minBufferSize), MaxBufferSize)
+	return &Buffered{r: r, buf: bufferPool.Get().(*[MaxBufferSize]byte), size: size}
+}
+
+// バッファをゼロで消去してプールに返却
+func (b *Buffered) Release() {
+	if b.buf == nil {
+		return
+	}
+	clear(b.buf[:])
+	bufferPool.Put(b.buf)
+	b.buf = nil
+	b.pos, b.end = 0, 0
+}
+
+func (b *Buffered) fill() error {
+	if b.buf == nil {
+		return fmt.Errorf("解放済みのバッファです")
+	}
+	if _, err := io.ReadFull(b.r, b.buf[:b.size]); err != nil {
+		return err
+	}
+	b.pos, b.end = 0, b.size
+	return nil
+}
+
+func (b *Buffered) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if b.pos == b.end {
+			if err := b.fill(); err != nil {
+				return n, err
+			}
+		}
+		c := copy(p[n:], b.buf[b.pos:b.end])
+		b.pos += c
+		n += c
+	}
+	return n, nil
+}
+
+// 1バイトを読み出す
+func (b *Buffered) Byte() (byte, error) {
+	if b.pos == b.end {
+		if err := b.fill(); err != nil {
+			return 0, err
+		}
+	}
+	v := b.buf[b.pos]
+	b.pos++
+	return v, nil
+}
+
+// ビッグエンディアンの32ビット値を読み出す
+func (b *Buffered) Uint32() (uint32, error) {
+	if b.end-b.pos < 4 {
+		var v [4]byte
+		if _, err := b.Read(v[:]); err != nil {
+			return 0, err
+		}
+		return binary.BigEndian.Uint32(v[:]), nil
+	}
+	v := binary.BigEndian.Uint32(b.buf[b.pos:])
+	b.pos += 4
+	return v, nil
+}
+
+// [0, n)の一様な整数を返す
+//
+// nが256以下なら1バイト、それより大きければ32ビット値を使い、端数となる範囲は棄却して引き直す。
+func (b *Buffered) Intn(n int) (int, error) {
+	if n <= 0 || n > math.MaxUint32 {
+		return 0, fmt.Errorf("無効な範囲: %d", n)
+	}
+	if n <= 256 {
+		// 256をnで割った余りより小さい値を棄却する
+		threshold := byte(256 % n)
+		for {
+			v, err := b.Byte()
+			if err != nil {
+				return 0, err
+			}
+			if v >= threshold {
+				return int(v) % n, nil
+			}
+		}
+	}
+	bound := uint32(n)
+	threshold := -bound % bound
+	for {
+		v, err := b.Uint32()
+		if err != nil {
+			return 0, err
+		}
+		if v >= threshold {
+			return int(v % bound), nil
+		}
+	}
+}
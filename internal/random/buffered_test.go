@@ -0,0 +1,92 @@
+package random
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+)
+
+// 先頭に指定したバイト列を置き、最小のバッファサイズまで埋めたリーダー
+func paddedReader(prefix ...byte) *bytes.Reader {
+	return bytes.NewReader(append(prefix, make([]byte, minBufferSize*2)...))
+}
+
+func TestBuffered_Intn(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []byte
+		n     int
+		want  int
+	}{
+		// n=10のとき 256 mod 10 = 6 なので、0〜5は棄却されて次の値が使われる
+		{name: "1バイトで棄却", input: []byte{5, 17}, n: 10, want: 7},
+		{name: "256は棄却なし", input: []byte{0}, n: 256, want: 0},
+		// n=1000のとき 2^32 mod 1000 = 296
+		{name: "32ビットで棄却", input: []byte{0, 0, 1, 0x27, 0, 0, 0x04, 0xd2}, n: 1000, want: 234},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBuffered(paddedReader(tt.input...), 0)
+			defer b.Release()
+			got, err := b.Intn(tt.n)
+			if err != nil {
+				t.Fatalf("Intn() エラー = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Intn() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	b := NewBuffered(rand.Reader, 0)
+	defer b.Release()
+	if _, err := b.Intn(0); err == nil {
+		t.Error("Intn(0) エラーが返されませんでした")
+	}
+}
+
+func TestBuffered_Refill(t *testing.T) {
+	// バッファの境界をまたいでも元の列と同じ順で読み出せる
+	want := make([]byte, minBufferSize*3)
+	if _, err := rand.Read(want); err != nil {
+		t.Fatal(err)
+	}
+	b := NewBuffered(bytes.NewReader(want), 0)
+	defer b.Release()
+
+	got := make([]byte, 0, len(want))
+	for len(got) < len(want)-4 {
+		v, err := b.Byte()
+		if err != nil {
+			t.Fatalf("Byte() エラー = %v", err)
+		}
+		got = append(got, v)
+	}
+	var rest [4]byte
+	if _, err := b.Read(rest[:]); err != nil {
+		t.Fatalf("Read() エラー = %v", err)
+	}
+	got = append(got, rest[:]...)
+	if !bytes.Equal(got, want) {
+		t.Error("読み出した列が乱数源と一致しません")
+	}
+	if _, err := b.Byte(); err == nil {
+		t.Error("乱数源が尽きてもエラーが返されませんでした")
+	}
+}
+
+func TestBuffered_Release(t *testing.T) {
+	b := NewBuffered(rand.Reader, 0)
+	buf := b.buf
+	if _, err := b.Byte(); err != nil {
+		t.Fatal(err)
+	}
+	b.Release()
+	if !bytes.Equal(buf[:], make([]byte, MaxBufferSize)) {
+		t.Error("Release() でバッファが消去されていません")
+	}
+	if _, err := b.Byte(); err == nil {
+		t.Error("解放済みのバッファから読み出せました")
+	}
+}
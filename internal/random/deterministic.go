@@ -0,0 +1,28 @@
+package random
+
+import (
+	"crypto/sha256"
+	"math/rand/v2"
+	"sync"
+)
+
+// シードから決定的な乱数列を返すReader
+//
+// テストやゴールデンファイルで出力を固定するためのもので、秘密の生成には使用しない。
+// ChaCha8の出力はGoのバージョン間で固定されているため、同じシードからは常に同じ列が得られる。
+type Deterministic struct {
+	mu  sync.Mutex
+	src *rand.ChaCha8
+}
+
+// シード文字列のSHA-256をChaCha8の鍵とするReaderを作成
+func NewDeterministic(seed string) *Deterministic {
+	return &Deterministic{src: rand.NewChaCha8(sha256.Sum256([]byte(seed)))}
+}
+
+// 複数のゴルーチンから呼び出せるようロックして読み込む
+func (d *Deterministic) Read(p []byte) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.src.Read(p)
+}
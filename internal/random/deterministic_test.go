@@ -0,0 +1,48 @@
+package random
+
+import (
+	"bytes"
+	"encoding/hex"
+	"sync"
+	"testing"
+)
+
+func TestDeterministic_Read(t *testing.T) {
+	read := func(seed string) []byte {
+		b := make([]byte, 16)
+		if _, err := NewDeterministic(seed).Read(b); err != nil {
+			t.Fatalf("Read() エラー = %v", err)
+		}
+		return b
+	}
+
+	// ChaCha8の出力はGoのバージョン間で固定されている
+	if got := hex.EncodeToString(read("golden")); got != "17228871c30c61e2c6ba1a13000a8d6e" {
+		t.Errorf("Read() = %s", got)
+	}
+	if !bytes.Equal(read("golden"), read("golden")) {
+		t.Error("同じシードから異なる列が得られました")
+	}
+	if bytes.Equal(read("golden"), read("other")) {
+		t.Error("異なるシードから同じ列が得られました")
+	}
+}
+
+func TestDeterministic_Concurrent(t *testing.T) {
+	d := NewDeterministic("concurrent")
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			b := make([]byte, 64)
+			for j := 0; j < 100; j++ {
+				if _, err := d.Read(b); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
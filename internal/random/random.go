@@ -0,0 +1,46 @@
+package random
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// rから[0, n)の一様な整数を返す
+//
+// 剰余による偏りが生じないよう、32ビット値のうち端数となる範囲は棄却して引き直す。
+func Intn(r io.Reader, n int) (int, error) {
+	if n <= 0 || n > math.MaxUint32 {
+		return 0, fmt.Errorf("無効な範囲: %d", n)
+	}
+	bound := uint32(n)
+	// 2^32をboundで割った余りより小さい値を棄却する
+	threshold := -bound % bound
+	var b [4]byte
+	for {
+		if _, err := io.ReadFull(r, b[:]); err != nil {
+			return 0, err
+		}
+		v := binary.BigEndian.Uint32(b[:])
+		if v >= threshold {
+			return int(v % bound), nil
+		}
+	}
+}
+
+// アルファベットから一様に選んだlength文字の文字列を返す
+func String(r io.Reader, alphabet []rune, length int) (string, error) {
+	if len(alphabet) == 0 {
+		return "", fmt.Errorf("アルファベットが空です")
+	}
+	out := make([]rune, length)
+	for i := range out {
+		idx, err := Intn(r, len(alphabet))
+		if err != nil {
+			return "", err
+		}
+		out[i] = alphabet[idx]
+	}
+	return string(out), nil
+}
@@ -0,0 +1,58 @@
+package random
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+)
+
+func TestIntn_Range(t *testing.T) {
+	for _, n := range []int{1, 2, 10, 32, 37, 1000} {
+		for i := 0; i < 200; i++ {
+			v, err := Intn(rand.Reader, n)
+			if err != nil {
+				t.Fatalf("Intn(%d) エラー = %v", n, err)
+			}
+			if v < 0 || v >= n {
+				t.Fatalf("Intn(%d) = %d, 範囲外", n, v)
+			}
+		}
+	}
+	if _, err := Intn(rand.Reader, 0); err == nil {
+		t.Error("Intn(0) エラーが返されませんでした")
+	}
+}
+
+func TestIntn_Rejection(t *testing.T) {
+	// n=10のとき 2^32 mod 10 = 6 なので、0〜5は棄却されて次の値が使われる
+	r := bytes.NewReader([]byte{0, 0, 0, 5, 0, 0, 0, 17})
+	v, err := Intn(r, 10)
+	if err != nil {
+		t.Fatalf("Intn() エラー = %v", err)
+	}
+	if v != 7 {
+		t.Errorf("Intn() = %d, want 7", v)
+	}
+}
+
+func TestIntn_ShortRead(t *testing.T) {
+	if _, err := Intn(bytes.NewReader([]byte{1, 2}), 10); err == nil {
+		t.Error("Intn() 乱数が不足してもエラーが返されませんでした")
+	}
+}
+
+func TestString(t *testing.T) {
+	alphabet := []rune("あいう")
+	s, err := String(rand.Reader, alphabet, 50)
+	if err != nil {
+		t.Fatalf("String() エラー = %v", err)
+	}
+	if n := len([]rune(s)); n != 50 {
+		t.Errorf("String() 文字数 = %d, want 50", n)
+	}
+	for _, r := range s {
+		if !bytes.ContainsRune([]byte("あいう"), r) {
+			t.Errorf("String() = %q, アルファベット外の文字 %q", s, r)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package recovery
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// リカバリーコードの保存用ハッシュアルゴリズム
+type HashAlgorithm string
+
+const (
+	HashArgon2id HashAlgorithm = "argon2id"
+	HashBcrypt   HashAlgorithm = "bcrypt"
+)
+
+// OWASPの推奨値に基づくargon2idのパラメータ
+//
+// 1回の登録で複数のコードをハッシュ化するため、パスワード用よりメモリを抑えている。
+const (
+	argon2Time    = 2
+	argon2Memory  = 19 * 1024
+	argon2Threads = 1
+	argon2SaltLen = 16
+	argon2KeyLen  = 32
+)
+
+const bcryptCost = bcrypt.DefaultCost
+
+// アルゴリズム名を解析
+func ParseHashAlgorithm(name string) (HashAlgorithm, error) {
+	a := HashAlgorithm(strings.ToLower(strings.TrimSpace(name)))
+	switch a {
+	case HashArgon2id, HashBcrypt:
+		return a, nil
+	default:
+		return "", fmt.Errorf("未対応のハッシュアルゴリズム: %s", name)
+	}
+}
+
+// 正規化したコードのハッシュをPHC文字列（bcryptはModular Crypt形式）で返す
+func Hash(code string, alg HashAlgorithm) (string, error) {
+	normalized := []byte(Normalize(code))
+	switch alg {
+	case HashArgon2id:
+		salt := make([]byte, argon2SaltLen)
+		if _, err := rand.Read(salt); err != nil {
+			return "", err
+		}
+		key := argon2.IDKey(normalized, salt, argon2Time, argon2Memory, argon2Threads, argon2KeyLen)
+		return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+			argon2.Version, argon2Memory, argon2Time, argon2Threads,
+			base64.RawStdEncoding.EncodeToString(salt),
+			base64.RawStdEncoding.EncodeToString(key)), nil
+	case HashBcrypt:
+		hash, err := bcrypt.GenerateFromPassword(normalized, bcryptCost)
+		if err != nil {
+			return "", err
+		}
+		return string(hash), nil
+	default:
+		return "", fmt.Errorf("未対応のハッシュアルゴリズム: %s", alg)
+	}
+}
+
+// 入力されたコードが保存されたハッシュと一致するか検証
+func Verify(code, hash string) (bool, error) {
+	normalized := []byte(Normalize(code))
+	if strings.HasPrefix(hash, "$2") {
+		err := bcrypt.CompareHashAndPassword([]byte(hash), normalized)
+		if err == bcrypt.ErrMismatchedHashAndPassword {
+			return false, nil
+		}
+		return err == nil, err
+	}
+
+	var version, memory, time, threads int
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return false, fmt.Errorf("未対応のハッシュ形式です")
+	}
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return false, fmt.Errorf("未対応のargon2のバージョンです")
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &time, &threads); err != nil {
+		return false, fmt.Errorf("無効なargon2のパラメータです")
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false, fmt.Errorf("無効なソルトです")
+	}
+	want, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return false, fmt.Errorf("無効なハッシュ値です")
+	}
+	got := argon2.IDKey(normalized, salt, uint32(time), uint32(memory), uint8(threads), uint32(len(want)))
+	return subtle.ConstantTimeCompare(got, want) == 1, nil
+}
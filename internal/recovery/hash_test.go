@@ -0,0 +1,60 @@
+package recovery
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHashAndVerify(t *testing.T) {
+	tests := []struct {
+		alg        HashAlgorithm
+		wantPrefix string
+	}{
+		{alg: HashArgon2id, wantPrefix: "$argon2id$v=19$m=19456,t=2,p=1$"},
+		{alg: HashBcrypt, wantPrefix: "$2a$10$"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.alg), func(t *testing.T) {
+			hash, err := Hash("ABCD-EFGH", tt.alg)
+			if err != nil {
+				t.Fatalf("Hash() エラー = %v", err)
+			}
+			if !strings.HasPrefix(hash, tt.wantPrefix) {
+				t.Errorf("Hash() = %q, want prefix %q", hash, tt.wantPrefix)
+			}
+
+			for _, input := range []string{"ABCD-EFGH", "abcdefgh", "abcd efgh"} {
+				if ok, err := Verify(input, hash); err != nil || !ok {
+					t.Errorf("Verify(%q) = %v, %v, want true", input, ok, err)
+				}
+			}
+			if ok, err := Verify("ABCD-EFGJ", hash); err != nil || ok {
+				t.Errorf("Verify() 別のコード = %v, %v, want false", ok, err)
+			}
+		})
+	}
+}
+
+func TestVerify_InvalidHash(t *testing.T) {
+	for _, hash := range []string{
+		"",
+		"$argon2i$v=19$m=19456,t=2,p=1$c2FsdA$aGFzaA",
+		"$argon2id$v=16$m=19456,t=2,p=1$c2FsdA$aGFzaA",
+		"$argon2id$v=19$m=x$c2FsdA$aGFzaA",
+		"$argon2id$v=19$m=19456,t=2,p=1$!!$aGFzaA",
+	} {
+		if _, err := Verify("ABCD-EFGH", hash); err == nil {
+			t.Errorf("Verify(%q) エラーが返されませんでした", hash)
+		}
+	}
+}
+
+func TestParseHashAlgorithm(t *testing.T) {
+	if got, err := ParseHashAlgorithm(" BCRYPT "); err != nil || got != HashBcrypt {
+		t.Errorf("ParseHashAlgorithm() = %v, %v", got, err)
+	}
+	if _, err := ParseHashAlgorithm("sha1"); err == nil {
+		t.Error("ParseHashAlgorithm() エラーが返されませんでした")
+	}
+}
@@ -0,0 +1,194 @@
+package recovery
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/okamyuji/PasswordGenerator/internal/random"
+)
+
+// リカバリーコードに使用する文字の集合
+type Alphabet string
+
+const (
+	// 紛らわしいI・L・O・Uを除いたCrockford base32
+	AlphabetCrockford Alphabet = "crockford"
+	// 数字のみ
+	AlphabetNumeric Alphabet = "numeric"
+)
+
+var alphabetChars = map[Alphabet]string{
+	AlphabetCrockford: "0123456789ABCDEFGHJKMNPQRSTVWXYZ",
+	AlphabetNumeric:   "0123456789",
+}
+
+// 既定値と制約
+const (
+	DefaultCount = 10
+	// コードごとに低速なハッシュを計算するため、1回の生成がサーバーの書き込みタイムアウト
+	// （10秒）に収まり、匿名のリクエストでCPUとメモリを占有できない数に抑える
+	MaxCount      = 20
+	DefaultFormat = "xxxx-xxxx"
+	// 書式中のランダムな文字の位置
+	placeholder = 'x'
+	// 書式中で使用できる区切り文字
+	separators = "- ."
+	// リカバリーコード1つあたりの最小エントロピー
+	minEntropyBits = 20
+	// 重複した場合に引き直す回数の上限
+	maxRetries = 1000
+)
+
+// リカバリーコード生成のオプション
+type Options struct {
+	Count    int
+	Format   string // x がランダムな文字、それ以外は区切り文字（例: xxxx-xxxx）
+	Alphabet Alphabet
+	Hash     HashAlgorithm
+}
+
+// 1つのリカバリーコードと保存用のハッシュ
+type Code struct {
+	Code string `json:"code"`
+	Hash string `json:"hash"`
+}
+
+// 生成されたリカバリーコードのセット
+type Set struct {
+	Codes         []Code        `json:"codes"`
+	Format        string        `json:"format"`
+	Alphabet      Alphabet      `json:"alphabet"`
+	HashAlgorithm HashAlgorithm `json:"hashAlgorithm"`
+	EntropyBits   float64       `json:"entropyBits"` // コード1つあたり
+}
+
+type Generator struct {
+	rand io.Reader
+}
+
+// crypto/randを乱数源とするGeneratorを作成
+func New() *Generator {
+	return NewWithReader(rand.Reader)
+}
+
+// 指定された乱数源でコードを選ぶGeneratorを作成（ハッシュのソルトは常にcrypto/randを使う）
+func NewWithReader(r io.Reader) *Generator {
+	return &Generator{rand: r}
+}
+
+// 既定値を補完してオプションを検証
+func (o Options) normalize() (Options, error) {
+	if o.Count == 0 {
+		o.Count = DefaultCount
+	}
+	if o.Count < 1 || o.Count > MaxCount {
+		return o, fmt.Errorf("無効なコード数: %d (1〜%d)", o.Count, MaxCount)
+	}
+	if o.Format == "" {
+		o.Format = DefaultFormat
+	}
+	for _, r := range o.Format {
+		if r != placeholder && !strings.ContainsRune(separators, r) {
+			return o, fmt.Errorf("書式に使用できない文字です: %q", r)
+		}
+	}
+	if o.Alphabet == "" {
+		o.Alphabet = AlphabetCrockford
+	}
+	if _, ok := alphabetChars[o.Alphabet]; !ok {
+		return o, fmt.Errorf("未対応のアルファベット: %s", o.Alphabet)
+	}
+	if o.Hash == "" {
+		o.Hash = HashArgon2id
+	}
+	if _, err := ParseHashAlgorithm(string(o.Hash)); err != nil {
+		return o, err
+	}
+	if o.entropyBits() < minEntropyBits {
+		return o, fmt.Errorf("コードのエントロピーが不足しています: %.1fビット (最小: %dビット)", o.entropyBits(), minEntropyBits)
+	}
+	return o, nil
+}
+
+// コード1つあたりのエントロピー（ビット）
+func (o Options) entropyBits() float64 {
+	n := strings.Count(o.Format, string(placeholder))
+	return float64(n) * math.Log2(float64(len(alphabetChars[o.Alphabet])))
+}
+
+// セット内で重複しないリカバリーコードを生成し、それぞれのハッシュを計算
+func (g *Generator) Generate(opts Options) (*Set, error) {
+	opts, err := opts.normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	alphabet := []rune(alphabetChars[opts.Alphabet])
+	seen := make(map[string]bool, opts.Count)
+	set := &Set{
+		Format:        opts.Format,
+		Alphabet:      opts.Alphabet,
+		HashAlgorithm: opts.Hash,
+		EntropyBits:   math.Round(opts.entropyBits()*10) / 10,
+	}
+
+	for retries := 0; len(set.Codes) < opts.Count; {
+		code, err := formatCode(g.rand, opts.Format, alphabet)
+		if err != nil {
+			return nil, err
+		}
+		if seen[code] {
+			if retries++; retries > maxRetries {
+				return nil, fmt.Errorf("重複しないコードを生成できません。書式を長くしてください")
+			}
+			continue
+		}
+		seen[code] = true
+
+		hash, err := Hash(code, opts.Hash)
+		if err != nil {
+			return nil, err
+		}
+		set.Codes = append(set.Codes, Code{Code: code, Hash: hash})
+	}
+	return set, nil
+}
+
+// 書式のプレースホルダーをランダムな文字で置き換える
+func formatCode(rng io.Reader, format string, alphabet []rune) (string, error) {
+	var sb strings.Builder
+	for _, r := range format {
+		if r != placeholder {
+			sb.WriteRune(r)
+			continue
+		}
+		s, err := random.String(rng, alphabet, 1)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(s)
+	}
+	return sb.String(), nil
+}
+
+// 入力されたコードを照合用に正規化
+//
+// 区切り文字を除いて大文字化し、Crockfordの読み替え（O→0、I・L→1）を行う。
+func Normalize(code string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case strings.ContainsRune(separators, r):
+			return -1
+		case r == 'o' || r == 'O':
+			return '0'
+		case r == 'i' || r == 'I' || r == 'l' || r == 'L':
+			return '1'
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		}
+		return r
+	}, code)
+}
@@ -0,0 +1,139 @@
+package recovery
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/okamyuji/PasswordGenerator/internal/random"
+)
+
+func TestGenerator_Generate(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        Options
+		wantCount   int
+		wantPattern string
+		wantBits    float64
+	}{
+		{
+			name:        "既定値",
+			opts:        Options{Hash: HashBcrypt},
+			wantCount:   DefaultCount,
+			wantPattern: `^[0-9A-HJKMNP-TV-Z]{4}-[0-9A-HJKMNP-TV-Z]{4}$`,
+			wantBits:    40,
+		},
+		{
+			name:        "数字のみ",
+			opts:        Options{Count: 3, Format: "xxxxx xxxxx", Alphabet: AlphabetNumeric},
+			wantCount:   3,
+			wantPattern: `^[0-9]{5} [0-9]{5}$`,
+			wantBits:    33.2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set, err := New().Generate(tt.opts)
+			if err != nil {
+				t.Fatalf("Generate() エラー = %v", err)
+			}
+			if len(set.Codes) != tt.wantCount {
+				t.Fatalf("コード数 = %d, want %d", len(set.Codes), tt.wantCount)
+			}
+			if set.EntropyBits != tt.wantBits {
+				t.Errorf("EntropyBits = %v, want %v", set.EntropyBits, tt.wantBits)
+			}
+
+			re := regexp.MustCompile(tt.wantPattern)
+			seen := make(map[string]bool)
+			for _, c := range set.Codes {
+				if !re.MatchString(c.Code) {
+					t.Errorf("コード %q が書式に一致しません", c.Code)
+				}
+				if seen[c.Code] {
+					t.Errorf("コード %q が重複しています", c.Code)
+				}
+				seen[c.Code] = true
+			}
+
+			ok, err := Verify(set.Codes[0].Code, set.Codes[0].Hash)
+			if err != nil || !ok {
+				t.Errorf("Verify() = %v, %v, want true", ok, err)
+			}
+		})
+	}
+}
+
+func TestGenerator_GenerateErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+	}{
+		{name: "コード数超過", opts: Options{Count: MaxCount + 1}},
+		{name: "負のコード数", opts: Options{Count: -1}},
+		{name: "書式に不正な文字", opts: Options{Format: "xxxx/xxxx"}},
+		{name: "エントロピー不足", opts: Options{Format: "xxx"}},
+		{name: "未対応のアルファベット", opts: Options{Alphabet: "emoji"}},
+		{name: "未対応のハッシュ", opts: Options{Hash: "md5"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New().Generate(tt.opts); err == nil {
+				t.Error("Generate() エラーが返されませんでした")
+			}
+		})
+	}
+}
+
+// 1回の生成で計算する低速なハッシュの数を制限する
+func TestGenerator_MaxCount(t *testing.T) {
+	if MaxCount > 20 {
+		t.Fatalf("MaxCount = %d, 20以下にしてください", MaxCount)
+	}
+	set, err := New().Generate(Options{Count: MaxCount})
+	if err != nil {
+		t.Fatalf("Generate() エラー = %v", err)
+	}
+	if len(set.Codes) != MaxCount {
+		t.Errorf("コード数 = %d, want %d", len(set.Codes), MaxCount)
+	}
+	if _, err := New().Generate(Options{Count: 100}); err == nil {
+		t.Error("Generate() 上限を超えるコード数でエラーが返されませんでした")
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "ABCD-EFGH", want: "ABCDEFGH"},
+		{input: "abcd efgh", want: "ABCDEFGH"},
+		{input: "o1l2-i3O4", want: "01121304"},
+		{input: "12.34", want: "1234"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := Normalize(tt.input); got != tt.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerator_GenerateDeterministic(t *testing.T) {
+	set, err := NewWithReader(random.NewDeterministic("golden")).Generate(Options{Count: 3, Hash: HashBcrypt})
+	if err != nil {
+		t.Fatalf("Generate() エラー = %v", err)
+	}
+	codes := make([]string, len(set.Codes))
+	for i, c := range set.Codes {
+		codes[i] = c.Code
+	}
+	if got, want := strings.Join(codes, " "), "H2KE-QFJX 9PA9-6YAW X2BQ-J4NY"; got != want {
+		t.Errorf("コード = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,47 @@
+package recovery
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// 印刷用シートに表示する情報
+type Sheet struct {
+	Title       string
+	Account     string
+	GeneratedAt time.Time
+	Codes       []string
+}
+
+// 生成されたセットから印刷用シートを作成
+func NewSheet(set *Set, title, account string, now time.Time) Sheet {
+	if title == "" {
+		title = "リカバリーコード"
+	}
+	codes := make([]string, len(set.Codes))
+	for i, c := range set.Codes {
+		codes[i] = c.Code
+	}
+	return Sheet{
+		Title:       title,
+		Account:     account,
+		GeneratedAt: now.UTC().Truncate(time.Second),
+		Codes:       codes,
+	}
+}
+
+// 印刷用のテキストシート
+func (s Sheet) Text() string {
+	var sb strings.Builder
+	sb.WriteString(s.Title + "\n")
+	if s.Account != "" {
+		fmt.Fprintf(&sb, "アカウント: %s\n", s.Account)
+	}
+	fmt.Fprintf(&sb, "生成日時: %s\n\n", s.GeneratedAt.Format(time.RFC3339))
+	for i, code := range s.Codes {
+		fmt.Fprintf(&sb, "%2d. %s\n", i+1, code)
+	}
+	sb.WriteString("\n各コードは1回のみ使用できます。安全な場所に保管してください。\n")
+	return sb.String()
+}
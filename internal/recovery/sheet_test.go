@@ -0,0 +1,27 @@
+package recovery
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSheet_Text(t *testing.T) {
+	set := &Set{Codes: []Code{{Code: "AAAA-BBBB"}, {Code: "CCCC-DDDD"}}}
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	got := NewSheet(set, "Acme", "alice@example.com", now).Text()
+	want := "Acme\n" +
+		"アカウント: alice@example.com\n" +
+		"生成日時: 2024-01-02T03:04:05Z\n\n" +
+		" 1. AAAA-BBBB\n" +
+		" 2. CCCC-DDDD\n\n" +
+		"各コードは1回のみ使用できます。安全な場所に保管してください。\n"
+	if got != want {
+		t.Errorf("Text() = %q, want %q", got, want)
+	}
+
+	if got := NewSheet(set, "", "", now).Text(); !strings.HasPrefix(got, "リカバリーコード\n生成日時:") {
+		t.Errorf("Text() 既定のタイトル = %q", got)
+	}
+}
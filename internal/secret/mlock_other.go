@@ -0,0 +1,9 @@
+//go:build !linux && !darwin
+
+package secret
+
+import "fmt"
+
+func lockedBytes(n int) ([]byte, func(), error) {
+	return nil, nil, fmt.Errorf("この環境ではメモリのロックに対応していません")
+}
@@ -0,0 +1,37 @@
+//go:build linux || darwin
+
+package secret
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"syscall"
+)
+
+// 専用の匿名マッピングを確保してロックする
+//
+// Goのヒープ上のスライスをロックすると同じページの他のオブジェクトと解除が干渉するため、
+// 秘密ごとにページ単位の領域を割り当てる。
+func lockedBytes(n int) ([]byte, func(), error) {
+	pageSize := os.Getpagesize()
+	size := (n + pageSize - 1) / pageSize * pageSize
+	mem, err := syscall.Mmap(-1, 0, size, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_ANON|syscall.MAP_PRIVATE)
+	if err != nil {
+		return nil, nil, fmt.Errorf("秘密用のメモリを確保できません: %w", err)
+	}
+	if err := syscall.Mlock(mem); err != nil {
+		_ = syscall.Munmap(mem)
+		return nil, nil, fmt.Errorf("メモリをロックできません: %w", err)
+	}
+
+	var once sync.Once
+	release := func() {
+		once.Do(func() {
+			clear(mem)
+			_ = syscall.Munlock(mem)
+			_ = syscall.Munmap(mem)
+		})
+	}
+	return mem[:n:n], release, nil
+}
@@ -0,0 +1,129 @@
+package secret
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+)
+
+// ログやfmtでの出力時に値の代わりに表示する文字列
+const Redacted = "[REDACTED]"
+
+// 生成された秘密を保持するバッファ
+//
+// 秘密を不変のstringに変換せずに扱い、使い終わったらDestroyでゼロ埋めする。
+// String・Format・LogValueは伏せ字を返すため、誤ってログやfmtに渡しても値は出力されない。
+type Secret struct {
+	mu      sync.Mutex
+	b       []byte
+	release func() // ロックしたメモリの解放
+}
+
+// nバイトの秘密用バッファを確保
+func New(n int) *Secret {
+	return &Secret{b: make([]byte, n)}
+}
+
+// スワップに書き出されないようロックしたメモリにnバイトの秘密用バッファを確保
+//
+// メモリのロックに対応していない環境や、RLIMIT_MEMLOCKを超える場合はエラーを返す。
+func NewLocked(n int) (*Secret, error) {
+	if n == 0 {
+		return New(0), nil
+	}
+	b, release, err := lockedBytes(n)
+	if err != nil {
+		return nil, err
+	}
+	return &Secret{b: b, release: release}, nil
+}
+
+// 文字列をコピーしたSecretを作成（テストや既存の文字列を受け取る場合に使う）
+func FromString(s string) *Secret {
+	sec := New(len(s))
+	copy(sec.b, s)
+	return sec
+}
+
+// 秘密のバイト列
+//
+// 返されたスライスはSecretのバッファそのもので、Destroy後はゼロ埋めされる。呼び出し元で保持しない。
+func (s *Secret) Bytes() []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.b
+}
+
+func (s *Secret) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.b)
+}
+
+// 秘密をstringとして取り出す
+//
+// stringはゼロ埋めできないコピーになるため、文字列を要求するAPIに渡す場合にのみ使う。
+func (s *Secret) Reveal() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return string(s.b)
+}
+
+// 秘密がbと一致するかを定数時間で比較
+func (s *Secret) Equal(b []byte) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return subtle.ConstantTimeCompare(s.b, b) == 1
+}
+
+// 秘密をwに書き込む（中間のコピーを作らずにレスポンスへ出力する）
+func (s *Secret) WriteTo(w io.Writer) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n, err := w.Write(s.b)
+	return int64(n), err
+}
+
+// バッファをゼロ埋めし、ロックしたメモリを解放する
+//
+// 複数回呼び出しても安全で、以降のBytesは空のスライスを返す。
+func (s *Secret) Destroy() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clear(s.b)
+	s.b = nil
+	if s.release != nil {
+		s.release()
+		s.release = nil
+	}
+}
+
+func (s *Secret) String() string {
+	return Redacted
+}
+
+func (s *Secret) GoString() string {
+	return "secret.Secret(" + Redacted + ")"
+}
+
+// 全ての書式指定子（%x や %d を含む）で伏せ字を出力
+func (s *Secret) Format(f fmt.State, verb rune) {
+	_, _ = io.WriteString(f, Redacted)
+}
+
+// slogでの出力時に伏せ字を返す
+func (s *Secret) LogValue() slog.Value {
+	return slog.StringValue(Redacted)
+}
+
+// スライス内の全てのSecretを破棄
+func DestroyAll(secrets []*Secret) {
+	for _, s := range secrets {
+		s.Destroy()
+	}
+}
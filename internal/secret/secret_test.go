@@ -0,0 +1,73 @@
+package secret
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestSecret_Redaction(t *testing.T) {
+	s := FromString("hunter2")
+
+	for _, verb := range []string{"%s", "%v", "%+v", "%#v", "%q", "%x", "%X", "%d"} {
+		if got := fmt.Sprintf(verb, s); got != Redacted {
+			t.Errorf("Sprintf(%q) = %q, want %q", verb, got, Redacted)
+		}
+	}
+	if got := s.String(); got != Redacted {
+		t.Errorf("String() = %q", got)
+	}
+	if got := fmt.Sprint(struct{ Password *Secret }{s}); strings.Contains(got, "hunter2") {
+		t.Errorf("構造体の出力に秘密が含まれています: %q", got)
+	}
+
+	var buf bytes.Buffer
+	slog.New(slog.NewJSONHandler(&buf, nil)).Info("生成", "password", s)
+	if strings.Contains(buf.String(), "hunter2") || !strings.Contains(buf.String(), Redacted) {
+		t.Errorf("slogの出力 = %s", buf.String())
+	}
+}
+
+func TestSecret_Destroy(t *testing.T) {
+	s := FromString("hunter2")
+	b := s.Bytes()
+
+	var out bytes.Buffer
+	if _, err := s.WriteTo(&out); err != nil || out.String() != "hunter2" {
+		t.Fatalf("WriteTo() = %q, %v", out.String(), err)
+	}
+	if !s.Equal([]byte("hunter2")) || s.Equal([]byte("hunter3")) {
+		t.Error("Equal() の結果が正しくありません")
+	}
+
+	s.Destroy()
+	if !bytes.Equal(b, make([]byte, len(b))) {
+		t.Errorf("Destroy() 後のバッファ = %q", b)
+	}
+	if s.Len() != 0 || s.Reveal() != "" {
+		t.Error("Destroy() 後に秘密を取り出せました")
+	}
+	// 2回目の呼び出しやnilでも安全
+	s.Destroy()
+	(*Secret)(nil).Destroy()
+}
+
+func TestNewLocked(t *testing.T) {
+	s, err := NewLocked(64)
+	if err != nil {
+		t.Skipf("メモリをロックできない環境です: %v", err)
+	}
+	if s.Len() != 64 {
+		t.Errorf("Len() = %d, want 64", s.Len())
+	}
+	copy(s.Bytes(), "hunter2")
+	if !strings.HasPrefix(s.Reveal(), "hunter2") {
+		t.Errorf("Reveal() = %q", s.Reveal())
+	}
+	s.Destroy()
+	if s.Len() != 0 {
+		t.Error("Destroy() 後に秘密を取り出せました")
+	}
+}
@@ -0,0 +1,124 @@
+package shamir
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/okamyuji/PasswordGenerator/internal/mnemonic"
+	"github.com/okamyuji/PasswordGenerator/internal/token"
+)
+
+// シェアの文字列表現
+type Encoding string
+
+const (
+	// BIP-39の単語リストの単語（1語あたり11ビット）
+	EncodingMnemonic Encoding = "mnemonic"
+	// 4文字ごとにハイフンで区切ったCrockford base32
+	EncodingBase32 Encoding = "base32"
+)
+
+// シェアのバイナリ形式
+//
+//	バージョン(1) | ID(2) | しきい値(1) | シェア番号(1) | 値の長さ(1) | 値 | チェックサム(4)
+//
+// チェックサムはそれ以前のバイト列のSHA-256の先頭4バイトで、書き写しの誤りを検出する。
+const (
+	shareVersion   = 1
+	headerSize     = 6
+	checksumSize   = 4
+	base32GroupLen = 4
+)
+
+// エンコーディング名を解析
+func ParseEncoding(name string) (Encoding, error) {
+	e := Encoding(strings.ToLower(strings.TrimSpace(name)))
+	switch e {
+	case "":
+		return EncodingMnemonic, nil
+	case EncodingMnemonic, EncodingBase32:
+		return e, nil
+	default:
+		return "", fmt.Errorf("未対応のシェアのエンコーディング: %s", name)
+	}
+}
+
+// シェアを文字列にエンコード
+func (s Share) Encode(e Encoding) (string, error) {
+	if s.Index < 1 || s.Index > MaxShares || s.Threshold < MinThreshold || s.Threshold > MaxShares || len(s.Value) > 255 {
+		return "", errors.New("無効なシェアです")
+	}
+	data := make([]byte, 0, headerSize+len(s.Value)+checksumSize)
+	data = append(data, shareVersion)
+	data = binary.BigEndian.AppendUint16(data, s.ID)
+	data = append(data, byte(s.Threshold), byte(s.Index), byte(len(s.Value)))
+	data = append(data, s.Value...)
+	sum := sha256.Sum256(data)
+	data = append(data, sum[:checksumSize]...)
+	defer clear(data)
+
+	switch e {
+	case EncodingMnemonic:
+		return mnemonic.EncodeWords(data), nil
+	case EncodingBase32:
+		encoded, err := token.EncodingCrockford.Encode(data)
+		if err != nil {
+			return "", err
+		}
+		var sb strings.Builder
+		for i := 0; i < len(encoded); i += base32GroupLen {
+			if i > 0 {
+				sb.WriteByte('-')
+			}
+			sb.WriteString(encoded[i:min(i+base32GroupLen, len(encoded))])
+		}
+		return sb.String(), nil
+	default:
+		return "", fmt.Errorf("未対応のシェアのエンコーディング: %s", e)
+	}
+}
+
+// 文字列のシェアを解析し、チェックサムを検証
+//
+// 空白で区切られた複数の単語はニーモニック、それ以外はbase32として扱う。
+func ParseShare(s string) (Share, error) {
+	s = strings.TrimSpace(s)
+	var data []byte
+	var err error
+	if len(strings.Fields(s)) > 1 {
+		data, err = mnemonic.DecodeWords(s)
+	} else {
+		data, err = token.EncodingCrockford.Decode(s)
+	}
+	if err != nil {
+		return Share{}, fmt.Errorf("無効なシェア: %w", err)
+	}
+	defer clear(data)
+
+	if len(data) < headerSize+checksumSize || data[0] != shareVersion {
+		return Share{}, errors.New("無効なシェアです")
+	}
+	n := headerSize + int(data[5]) + checksumSize
+	// ニーモニックでは余りのビットにより末尾に0のバイトが1つ付くことがある
+	if len(data) != n && !(len(data) == n+1 && data[n] == 0) {
+		return Share{}, errors.New("シェアの長さが一致しません")
+	}
+	sum := sha256.Sum256(data[:n-checksumSize])
+	if string(sum[:checksumSize]) != string(data[n-checksumSize:n]) {
+		return Share{}, errors.New("シェアのチェックサムが一致しません。書き写しに誤りがないか確認してください")
+	}
+
+	share := Share{
+		ID:        binary.BigEndian.Uint16(data[1:3]),
+		Threshold: int(data[3]),
+		Index:     int(data[4]),
+		Value:     append([]byte(nil), data[headerSize:n-checksumSize]...),
+	}
+	if share.Index < 1 || share.Threshold < MinThreshold {
+		return Share{}, errors.New("無効なシェアです")
+	}
+	return share, nil
+}
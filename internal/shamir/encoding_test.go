@@ -0,0 +1,70 @@
+package shamir
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestShare_EncodeParse(t *testing.T) {
+	for _, size := range []int{1, 16, 20, 32, MaxSecretSize} {
+		shares, err := New().Split(bytes.Repeat([]byte{'x'}, size), Options{Shares: 3, Threshold: 2})
+		if err != nil {
+			t.Fatalf("Split() エラー = %v", err)
+		}
+		for _, e := range []Encoding{EncodingMnemonic, EncodingBase32} {
+			encoded, err := shares[2].Encode(e)
+			if err != nil {
+				t.Fatalf("Encode(%s) エラー = %v", e, err)
+			}
+			if e == EncodingBase32 && !strings.Contains(encoded, "-") {
+				t.Errorf("Encode(base32) がハイフンで区切られていません: %s", encoded)
+			}
+			// 大文字小文字と前後の空白は区別しない
+			got, err := ParseShare("  " + strings.ToUpper(encoded) + "\n")
+			if err != nil {
+				t.Fatalf("ParseShare(%s, %dバイト) エラー = %v", e, size, err)
+			}
+			if got.ID != shares[2].ID || got.Threshold != 2 || got.Index != 3 || !bytes.Equal(got.Value, shares[2].Value) {
+				t.Errorf("ParseShare(%s) = %+v, want %+v", e, got, shares[2])
+			}
+		}
+	}
+}
+
+func TestParseShare_DetectsTypos(t *testing.T) {
+	shares, err := New().Split([]byte("break-glass"), Options{Shares: 2, Threshold: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	words, _ := shares[0].Encode(EncodingMnemonic)
+	b32, _ := shares[0].Encode(EncodingBase32)
+
+	fields := strings.Fields(words)
+	fields[1], fields[2] = fields[2], fields[1]
+	swapped := strings.Join(fields, " ")
+	typo := []byte(b32)
+	if typo[0] == '0' {
+		typo[0] = '1'
+	} else {
+		typo[0] = '0'
+	}
+
+	for _, s := range []string{"", "abandon abandon", swapped, string(typo), b32[:len(b32)-5], "not-a-share!"} {
+		if _, err := ParseShare(s); err == nil {
+			t.Errorf("ParseShare(%q) エラーが返されませんでした", s)
+		}
+	}
+}
+
+func TestParseEncoding(t *testing.T) {
+	if e, err := ParseEncoding(""); err != nil || e != EncodingMnemonic {
+		t.Errorf("ParseEncoding(\"\") = %v, %v", e, err)
+	}
+	if e, err := ParseEncoding("Base32"); err != nil || e != EncodingBase32 {
+		t.Errorf("ParseEncoding(Base32) = %v, %v", e, err)
+	}
+	if _, err := ParseEncoding("hex"); err == nil {
+		t.Error("ParseEncoding() 未対応のエンコーディングでエラーが返されませんでした")
+	}
+}
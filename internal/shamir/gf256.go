@@ -0,0 +1,33 @@
+package shamir
+
+// GF(2^8)の演算（AESと同じ既約多項式 x^8 + x^4 + x^3 + x + 1）
+//
+// 秘密に依存する値を扱うため、表引きや分岐を使わず一定時間で計算する。
+
+// 乗算
+func mul(a, b byte) byte {
+	var p byte
+	for range 8 {
+		p ^= -(b & 1) & a
+		a = a<<1 ^ 0x1b&-(a>>7)
+		b >>= 1
+	}
+	return p
+}
+
+// 逆元（a^254）。0の逆元は0とする
+func inv(a byte) byte {
+	a2 := mul(a, a)
+	a4 := mul(a2, a2)
+	a8 := mul(a4, a4)
+	a16 := mul(a8, a8)
+	a32 := mul(a16, a16)
+	a64 := mul(a32, a32)
+	a128 := mul(a64, a64)
+	return mul(mul(mul(mul(mul(mul(a128, a64), a32), a16), a8), a4), a2)
+}
+
+// 除算
+func div(a, b byte) byte {
+	return mul(a, inv(b))
+}
@@ -0,0 +1,176 @@
+package shamir
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/okamyuji/PasswordGenerator/internal/secret"
+)
+
+// 分割の既定値と制約
+const (
+	DefaultShares    = 5
+	DefaultThreshold = 3
+	MinThreshold     = 2
+	// GF(256)のx座標は1〜255
+	MaxShares = 255
+	// 分割できる秘密の最大バイト数
+	MaxSecretSize = 128
+	// 復元の検証のため秘密に付加するSHA-256の先頭バイト数
+	digestSize = 4
+)
+
+// 分割のオプション
+type Options struct {
+	Shares    int // シェアの総数N
+	Threshold int // 復元に必要なシェア数K
+}
+
+// 1つのシェア
+type Share struct {
+	ID        uint16 // 同じ分割から作られたシェアに共通の識別子
+	Threshold int
+	Index     int    // x座標（1〜255）
+	Value     []byte // 秘密と検証用ダイジェストの各バイトの多項式の値
+}
+
+// 秘密をシャミアの秘密分散法でシェアに分割する
+type Generator struct {
+	rand io.Reader
+}
+
+// crypto/randを乱数源とするGeneratorを作成
+func New() *Generator {
+	return NewWithReader(rand.Reader)
+}
+
+// 指定された乱数源で多項式の係数を選ぶGeneratorを作成
+func NewWithReader(r io.Reader) *Generator {
+	return &Generator{rand: r}
+}
+
+// 既定値を補完してオプションを検証
+func (o Options) normalize() (Options, error) {
+	if o.Shares == 0 {
+		o.Shares = DefaultShares
+	}
+	if o.Threshold == 0 {
+		o.Threshold = min(DefaultThreshold, o.Shares)
+	}
+	if o.Threshold < MinThreshold || o.Threshold > o.Shares {
+		return o, fmt.Errorf("無効なしきい値: %d (%d〜シェア数%d)", o.Threshold, MinThreshold, o.Shares)
+	}
+	if o.Shares > MaxShares {
+		return o, fmt.Errorf("シェア数が最大値を超えています: %d (最大: %d)", o.Shares, MaxShares)
+	}
+	return o, nil
+}
+
+// 秘密をN個のシェアに分割し、そのうちK個で復元できるようにする
+//
+// 秘密の末尾にSHA-256の先頭4バイトを付加してから分割し、復元時に検証する。
+func (g *Generator) Split(s []byte, opts Options) ([]Share, error) {
+	opts, err := opts.normalize()
+	if err != nil {
+		return nil, err
+	}
+	if len(s) == 0 || len(s) > MaxSecretSize {
+		return nil, fmt.Errorf("無効な秘密の長さ: %dバイト (1〜%dバイト)", len(s), MaxSecretSize)
+	}
+
+	var id [2]byte
+	if _, err := io.ReadFull(g.rand, id[:]); err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(s)
+	payload := append(append(make([]byte, 0, len(s)+digestSize), s...), sum[:digestSize]...)
+	defer clear(payload)
+
+	shares := make([]Share, opts.Shares)
+	for i := range shares {
+		shares[i] = Share{
+			ID:        binary.BigEndian.Uint16(id[:]),
+			Threshold: opts.Threshold,
+			Index:     i + 1,
+			Value:     make([]byte, len(payload)),
+		}
+	}
+
+	// 各バイトごとに、定数項を秘密とするK-1次の多項式をランダムに選ぶ
+	coefficients := make([]byte, opts.Threshold-1)
+	defer clear(coefficients)
+	for j, b := range payload {
+		if _, err := io.ReadFull(g.rand, coefficients); err != nil {
+			return nil, err
+		}
+		for i := range shares {
+			x := byte(shares[i].Index)
+			var y byte
+			for k := len(coefficients) - 1; k >= 0; k-- {
+				y = mul(y^coefficients[k], x)
+			}
+			shares[i].Value[j] = y ^ b
+		}
+	}
+	return shares, nil
+}
+
+// シェアから秘密を復元し、付加したダイジェストで検証する
+//
+// しきい値以上のシェアが必要で、別の分割のシェアや同じシェアの重複はエラーにする。
+func Combine(shares []Share) (*secret.Secret, error) {
+	if len(shares) == 0 {
+		return nil, errors.New("シェアが指定されていません")
+	}
+	first := shares[0]
+	if len(shares) < first.Threshold {
+		return nil, fmt.Errorf("シェアが不足しています: %d個 (必要: %d個)", len(shares), first.Threshold)
+	}
+	if len(first.Value) <= digestSize {
+		return nil, errors.New("無効なシェアです")
+	}
+	seen := make(map[int]bool, len(shares))
+	for _, s := range shares {
+		if s.ID != first.ID || s.Threshold != first.Threshold || len(s.Value) != len(first.Value) {
+			return nil, errors.New("別の分割で作られたシェアが含まれています")
+		}
+		if s.Index < 1 || s.Index > MaxShares {
+			return nil, fmt.Errorf("無効なシェア番号: %d", s.Index)
+		}
+		if seen[s.Index] {
+			return nil, fmt.Errorf("シェアが重複しています: %d番", s.Index)
+		}
+		seen[s.Index] = true
+	}
+
+	// x=0でのラグランジュ補間
+	payload := make([]byte, len(first.Value))
+	defer clear(payload)
+	for i, si := range shares {
+		xi := byte(si.Index)
+		basis := byte(1)
+		for j, sj := range shares {
+			if i != j {
+				xj := byte(sj.Index)
+				basis = mul(basis, div(xj, xj^xi))
+			}
+		}
+		for k, y := range si.Value {
+			payload[k] ^= mul(basis, y)
+		}
+	}
+
+	n := len(payload) - digestSize
+	sum := sha256.Sum256(payload[:n])
+	if subtle.ConstantTimeCompare(sum[:digestSize], payload[n:]) != 1 {
+		return nil, errors.New("秘密を検証できません。シェアが誤っているか破損しています")
+	}
+	result := secret.New(n)
+	copy(result.Bytes(), payload[:n])
+	return result, nil
+}
@@ -0,0 +1,146 @@
+package shamir
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/okamyuji/PasswordGenerator/internal/random"
+)
+
+func TestGF256(t *testing.T) {
+	// FIPS 197の例: {57} • {83} = {c1}
+	if got := mul(0x57, 0x83); got != 0xc1 {
+		t.Errorf("mul(0x57, 0x83) = %#x, want 0xc1", got)
+	}
+	for a := 1; a < 256; a++ {
+		if got := mul(byte(a), inv(byte(a))); got != 1 {
+			t.Fatalf("mul(%#x, inv(%#x)) = %#x, want 1", a, a, got)
+		}
+		if got := div(mul(byte(a), 0x1d), 0x1d); got != byte(a) {
+			t.Fatalf("div(mul(%#x, 0x1d), 0x1d) = %#x", a, got)
+		}
+	}
+	if inv(0) != 0 {
+		t.Error("inv(0) が0ではありません")
+	}
+}
+
+func TestSplitCombine_AllSubsets(t *testing.T) {
+	secret := []byte("W!T6CySHWX1JKji)")
+	shares, err := New().Split(secret, Options{Shares: 5, Threshold: 3})
+	if err != nil {
+		t.Fatalf("Split() エラー = %v", err)
+	}
+	if len(shares) != 5 {
+		t.Fatalf("シェア数 = %d, want 5", len(shares))
+	}
+
+	// 3個以上の全ての組み合わせで復元できる
+	for mask := 0; mask < 1<<len(shares); mask++ {
+		var subset []Share
+		for i, s := range shares {
+			if mask&(1<<i) != 0 {
+				subset = append(subset, s)
+			}
+		}
+		got, err := Combine(subset)
+		if len(subset) < 3 {
+			if err == nil {
+				t.Errorf("Combine(%d個) しきい値未満でエラーが返されませんでした", len(subset))
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Combine(%b) エラー = %v", mask, err)
+		}
+		if !got.Equal(secret) {
+			t.Errorf("Combine(%b) = %q, want %q", mask, got.Reveal(), secret)
+		}
+		got.Destroy()
+	}
+}
+
+func TestSplit_Deterministic(t *testing.T) {
+	split := func() []Share {
+		shares, err := NewWithReader(random.NewDeterministic("shamir")).Split([]byte("secret"), Options{Shares: 3, Threshold: 2})
+		if err != nil {
+			t.Fatalf("Split() エラー = %v", err)
+		}
+		return shares
+	}
+	a, b := split(), split()
+	for i := range a {
+		if a[i].ID != b[i].ID || !bytes.Equal(a[i].Value, b[i].Value) {
+			t.Errorf("同じシードで異なるシェアが生成されました: %d番", i+1)
+		}
+		if bytes.Contains(a[i].Value, []byte("secret")) {
+			t.Errorf("シェアに秘密がそのまま含まれています: %d番", i+1)
+		}
+	}
+}
+
+func TestSplit_Defaults(t *testing.T) {
+	shares, err := New().Split([]byte("secret"), Options{})
+	if err != nil {
+		t.Fatalf("Split() エラー = %v", err)
+	}
+	if len(shares) != DefaultShares || shares[0].Threshold != DefaultThreshold {
+		t.Errorf("Split() シェア数 = %d, しきい値 = %d", len(shares), shares[0].Threshold)
+	}
+}
+
+func TestSplit_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		secret []byte
+		opts   Options
+	}{
+		{name: "しきい値1", secret: []byte("s"), opts: Options{Shares: 3, Threshold: 1}},
+		{name: "しきい値がシェア数を超える", secret: []byte("s"), opts: Options{Shares: 3, Threshold: 4}},
+		{name: "シェア数が多すぎる", secret: []byte("s"), opts: Options{Shares: 256, Threshold: 2}},
+		{name: "空の秘密", secret: nil, opts: Options{Shares: 3, Threshold: 2}},
+		{name: "長すぎる秘密", secret: make([]byte, MaxSecretSize+1), opts: Options{Shares: 3, Threshold: 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New().Split(tt.secret, tt.opts); err == nil {
+				t.Error("Split() エラーが返されませんでした")
+			}
+		})
+	}
+}
+
+func TestCombine_Invalid(t *testing.T) {
+	g := New()
+	opts := Options{Shares: 3, Threshold: 2}
+	a, err := g.Split([]byte("first secret"), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := g.Split([]byte("other secret"), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b[1].ID = a[0].ID
+	tampered := a[1]
+	tampered.Value = append([]byte(nil), a[1].Value...)
+	tampered.Value[0] ^= 1
+
+	tests := []struct {
+		name   string
+		shares []Share
+	}{
+		{name: "なし", shares: nil},
+		{name: "重複", shares: []Share{a[0], a[0]}},
+		{name: "別の分割", shares: []Share{a[0], b[2]}},
+		{name: "IDが同じ別の分割（ダイジェストで検出）", shares: []Share{a[0], b[1]}},
+		{name: "改ざん", shares: []Share{a[0], tampered}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Combine(tt.shares); err == nil {
+				t.Error("Combine() エラーが返されませんでした")
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package share
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// エントリのファイルの拡張子
+const entryExt = ".json"
+
+// ディレクトリにエントリを1ファイルずつ保存するStore
+//
+// 再起動してもリンクは有効なまま残る。ファイルは所有者のみ読み書きできる権限で作成する。
+// ディスクを使い切られないよう、保存できるエントリ数はMaxEntriesまでに制限する。
+type FileStore struct {
+	dir        string
+	mu         sync.Mutex
+	maxEntries int
+}
+
+// ディレクトリを作成してFileStoreを返す
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("共有リンクの保存先を作成できません: %w", err)
+	}
+	return &FileStore{dir: dir, maxEntries: MaxEntries}, nil
+}
+
+// IDに対応するファイルのパス（不正なIDではディレクトリの外を参照しないようfalseを返す）
+func (s *FileStore) path(id string) (string, bool) {
+	if !ValidID(id) {
+		return "", false
+	}
+	return filepath.Join(s.dir, id+entryExt), true
+}
+
+func (s *FileStore) Put(ctx context.Context, id string, e Entry) error {
+	path, ok := s.path(id)
+	if !ok {
+		return fmt.Errorf("無効な共有リンクのIDです: %q", id)
+	}
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n, err := s.count()
+	if err != nil {
+		return fmt.Errorf("共有リンクを保存できません: %w", err)
+	}
+	if n >= s.maxEntries {
+		return fmt.Errorf("保存できる共有リンクの数が上限に達しました (最大: %d)", s.maxEntries)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return fmt.Errorf("共有リンクを保存できません: %w", err)
+	}
+	if _, err := f.Write(body); err != nil {
+		f.Close()
+		os.Remove(path)
+		return fmt.Errorf("共有リンクを保存できません: %w", err)
+	}
+	return f.Close()
+}
+
+func (s *FileStore) Take(ctx context.Context, id string, now time.Time) (*Entry, error) {
+	path, ok := s.path(id)
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, err := readEntry(path)
+	if err != nil {
+		return nil, err
+	}
+	if !now.Before(e.ExpiresAt) || e.Views < 1 {
+		os.Remove(path)
+		return nil, ErrNotFound
+	}
+	if e.Views--; e.Views == 0 {
+		// 削除できなければ秘密を返さない（二度目の閲覧を防ぐ）
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("共有リンクを削除できません: %w", err)
+		}
+		return e, nil
+	}
+	body, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, body, 0o600); err != nil {
+		return nil, fmt.Errorf("共有リンクを更新できません: %w", err)
+	}
+	return e, nil
+}
+
+func (s *FileStore) Sweep(ctx context.Context, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	files, err := os.ReadDir(s.dir)
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), entryExt) {
+			continue
+		}
+		path := filepath.Join(s.dir, f.Name())
+		e, err := readEntry(path)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil || !now.Before(e.ExpiresAt) {
+			os.Remove(path)
+		}
+	}
+	return nil
+}
+
+// 保存されているエントリのファイル数
+func (s *FileStore) count() (int, error) {
+	d, err := os.Open(s.dir)
+	if err != nil {
+		return 0, err
+	}
+	defer d.Close()
+	names, err := d.Readdirnames(-1)
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, name := range names {
+		if strings.HasSuffix(name, entryExt) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func readEntry(path string) (*Entry, error) {
+	body, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var e Entry
+	if err := json.Unmarshal(body, &e); err != nil {
+		return nil, fmt.Errorf("共有リンクのファイルが壊れています: %w", err)
+	}
+	return &e, nil
+}
@@ -0,0 +1,62 @@
+package share
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// プロセスのメモリに保存するStore（再起動で全てのリンクが失効する）
+type MemoryStore struct {
+	mu         sync.Mutex
+	entries    map[string]Entry
+	maxEntries int
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: make(map[string]Entry), maxEntries: MaxEntries}
+}
+
+func (s *MemoryStore) Put(ctx context.Context, id string, e Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.entries[id]; ok {
+		return fmt.Errorf("共有リンクのIDが重複しています")
+	}
+	if len(s.entries) >= s.maxEntries {
+		return fmt.Errorf("保存できる共有リンクの数が上限に達しました (最大: %d)", s.maxEntries)
+	}
+	s.entries[id] = e
+	return nil
+}
+
+func (s *MemoryStore) Take(ctx context.Context, id string, now time.Time) (*Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if !now.Before(e.ExpiresAt) || e.Views < 1 {
+		delete(s.entries, id)
+		return nil, ErrNotFound
+	}
+	if e.Views--; e.Views == 0 {
+		delete(s.entries, id)
+	} else {
+		s.entries[id] = e
+	}
+	return &e, nil
+}
+
+func (s *MemoryStore) Sweep(ctx context.Context, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, e := range s.entries {
+		if !now.Before(e.ExpiresAt) {
+			delete(s.entries, id)
+		}
+	}
+	return nil
+}
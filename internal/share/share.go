@@ -0,0 +1,175 @@
+package share
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"time"
+)
+
+// 有効期限と秘密の大きさの制約
+const (
+	DefaultTTL    = 24 * time.Hour
+	MinTTL        = time.Minute
+	MaxTTL        = 7 * 24 * time.Hour
+	MaxSecretSize = 64 * 1024
+)
+
+// 鍵・ID・ノンスの長さ（バイト）
+const (
+	keySize   = 32
+	idSize    = 16
+	nonceSize = 12
+)
+
+// エンコードしたIDの長さ（base64url、パディングなし）
+var idLength = base64.RawURLEncoding.EncodedLen(idSize)
+
+// AES-256-GCMで暗号化された秘密
+//
+// 復号鍵はリンクのURLフラグメントにのみ含まれ、サーバーには保存されない。
+// IDを追加認証データとするため、別のリンクの暗号文とは入れ替えられない。
+type Sealed struct {
+	Ciphertext []byte `json:"ciphertext"`
+	Nonce      []byte `json:"nonce"`
+}
+
+// 作成された共有リンク
+type Link struct {
+	ID        string    `json:"id"`
+	Key       string    `json:"-"` // base64urlの復号鍵（URLフラグメント）
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// 閲覧ページのパス（フラグメントに復号鍵を含む）
+func (l *Link) Path() string {
+	return "/share/" + l.ID + "#" + l.Key
+}
+
+// 1回だけ閲覧できる共有リンクを作成・開封する
+type Service struct {
+	store Store
+	rand  io.Reader
+	now   func() time.Time
+}
+
+// crypto/randで鍵とIDを生成するServiceを作成
+func New(store Store) *Service {
+	return NewWithReader(store, rand.Reader)
+}
+
+// 指定された乱数源で鍵とIDを生成するServiceを作成
+func NewWithReader(store Store, r io.Reader) *Service {
+	return &Service{store: store, rand: r, now: time.Now}
+}
+
+// リンクごとの鍵で秘密を暗号化して保存し、1回だけ閲覧できるリンクを返す
+//
+// ttlが0の場合はDefaultTTL。平文と鍵はこの関数の外に保存されない。
+func (s *Service) Create(ctx context.Context, plaintext []byte, ttl time.Duration) (*Link, error) {
+	if len(plaintext) == 0 {
+		return nil, fmt.Errorf("共有する秘密が空です")
+	}
+	if len(plaintext) > MaxSecretSize {
+		return nil, fmt.Errorf("共有する秘密が大きすぎます: %dバイト (最大: %dバイト)", len(plaintext), MaxSecretSize)
+	}
+	if ttl == 0 {
+		ttl = DefaultTTL
+	}
+	if ttl < MinTTL || ttl > MaxTTL {
+		return nil, fmt.Errorf("無効な有効期限: %s (%s〜%s)", ttl, MinTTL, MaxTTL)
+	}
+
+	buf := make([]byte, keySize+idSize+nonceSize)
+	defer clear(buf)
+	if _, err := io.ReadFull(s.rand, buf); err != nil {
+		return nil, err
+	}
+	key, rawID, nonce := buf[:keySize], buf[keySize:keySize+idSize], buf[keySize+idSize:]
+	id := base64.RawURLEncoding.EncodeToString(rawID)
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	link := &Link{
+		ID:        id,
+		Key:       base64.RawURLEncoding.EncodeToString(key),
+		ExpiresAt: s.now().Add(ttl).UTC(),
+	}
+	entry := Entry{
+		Sealed: Sealed{
+			Ciphertext: aead.Seal(nil, nonce, plaintext, []byte(id)),
+			Nonce:      append([]byte(nil), nonce...),
+		},
+		ExpiresAt: link.ExpiresAt,
+		Views:     1,
+	}
+	if err := s.store.Put(ctx, id, entry); err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+// 暗号化された秘密を取り出し、リンクを消費する（以降の開封はErrNotFound）
+func (s *Service) Open(ctx context.Context, id string) (*Sealed, error) {
+	if !ValidID(id) {
+		return nil, ErrNotFound
+	}
+	e, err := s.store.Take(ctx, id, s.now())
+	if err != nil {
+		return nil, err
+	}
+	return &e.Sealed, nil
+}
+
+// 期限切れのリンクを削除する
+func (s *Service) Sweep(ctx context.Context) error {
+	return s.store.Sweep(ctx, s.now())
+}
+
+// URLフラグメントの鍵で秘密を復号する（閲覧ページのJavaScriptと同じ処理）
+func Decrypt(id string, sealed *Sealed, key string) ([]byte, error) {
+	k, err := base64.RawURLEncoding.DecodeString(key)
+	if err != nil || len(k) != keySize {
+		return nil, fmt.Errorf("無効な復号鍵です")
+	}
+	defer clear(k)
+	aead, err := newAEAD(k)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed.Nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("無効なノンスです")
+	}
+	plaintext, err := aead.Open(nil, sealed.Nonce, sealed.Ciphertext, []byte(id))
+	if err != nil {
+		return nil, fmt.Errorf("秘密を復号できません。リンクが正しいか確認してください")
+	}
+	return plaintext, nil
+}
+
+// リンクのIDとして正しい形式か（base64urlの22文字）
+func ValidID(id string) bool {
+	if len(id) != idLength {
+		return false
+	}
+	for _, c := range id {
+		if !(c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-' || c == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
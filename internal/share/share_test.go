@@ -0,0 +1,125 @@
+package share
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestService_CreateOpen(t *testing.T) {
+	ctx := context.Background()
+	s := New(NewMemoryStore())
+
+	link, err := s.Create(ctx, []byte("hunter2"), time.Hour)
+	if err != nil {
+		t.Fatalf("Create() エラー = %v", err)
+	}
+	if !ValidID(link.ID) {
+		t.Errorf("ID = %q, 無効な形式", link.ID)
+	}
+	if want := "/share/" + link.ID + "#" + link.Key; link.Path() != want {
+		t.Errorf("Path() = %q, want %q", link.Path(), want)
+	}
+
+	sealed, err := s.Open(ctx, link.ID)
+	if err != nil {
+		t.Fatalf("Open() エラー = %v", err)
+	}
+	if bytes.Contains(sealed.Ciphertext, []byte("hunter2")) {
+		t.Error("暗号文に平文が含まれています")
+	}
+	plaintext, err := Decrypt(link.ID, sealed, link.Key)
+	if err != nil || string(plaintext) != "hunter2" {
+		t.Errorf("Decrypt() = %q, %v", plaintext, err)
+	}
+
+	// 1回開封したリンクは消費済み
+	if _, err := s.Open(ctx, link.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("2回目のOpen() エラー = %v, want ErrNotFound", err)
+	}
+}
+
+func TestService_Expiry(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := New(NewMemoryStore())
+	s.now = func() time.Time { return now }
+
+	link, err := s.Create(ctx, []byte("hunter2"), 0)
+	if err != nil {
+		t.Fatalf("Create() エラー = %v", err)
+	}
+	if !link.ExpiresAt.Equal(now.Add(DefaultTTL)) {
+		t.Errorf("ExpiresAt = %v, want %v", link.ExpiresAt, now.Add(DefaultTTL))
+	}
+
+	now = now.Add(DefaultTTL)
+	if _, err := s.Open(ctx, link.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("期限切れのOpen() エラー = %v, want ErrNotFound", err)
+	}
+}
+
+func TestService_CreateValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		plaintext []byte
+		ttl       time.Duration
+	}{
+		{name: "空の秘密", plaintext: nil},
+		{name: "大きすぎる秘密", plaintext: make([]byte, MaxSecretSize+1)},
+		{name: "短すぎる有効期限", plaintext: []byte("x"), ttl: time.Second},
+		{name: "長すぎる有効期限", plaintext: []byte("x"), ttl: MaxTTL + time.Hour},
+	}
+
+	s := New(NewMemoryStore())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.Create(context.Background(), tt.plaintext, tt.ttl); err == nil {
+				t.Error("Create() エラーが返されませんでした")
+			}
+		})
+	}
+}
+
+func TestDecrypt_Tampered(t *testing.T) {
+	ctx := context.Background()
+	s := New(NewMemoryStore())
+	a, _ := s.Create(ctx, []byte("secret-a"), time.Hour)
+	b, _ := s.Create(ctx, []byte("secret-b"), time.Hour)
+	sealed, err := s.Open(ctx, a.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 別のリンクのIDや鍵では復号できない
+	if _, err := Decrypt(b.ID, sealed, a.Key); err == nil {
+		t.Error("別のIDで復号できました")
+	}
+	if _, err := Decrypt(a.ID, sealed, b.Key); err == nil {
+		t.Error("別の鍵で復号できました")
+	}
+	if _, err := Decrypt(a.ID, sealed, "short"); err == nil {
+		t.Error("無効な鍵で復号できました")
+	}
+}
+
+func TestValidID(t *testing.T) {
+	tests := []struct {
+		id   string
+		want bool
+	}{
+		{id: "AAAAAAAAAAAAAAAAAAAAAA", want: true},
+		{id: "abcdefghij-_0123456789", want: true},
+		{id: "AAAAAAAAAAAAAAAAAAAAA", want: false},
+		{id: "../../../../etc/passwd", want: false},
+		{id: strings.Repeat("A", 21) + "=", want: false},
+	}
+	for _, tt := range tests {
+		if got := ValidID(tt.id); got != tt.want {
+			t.Errorf("ValidID(%q) = %v, want %v", tt.id, got, tt.want)
+		}
+	}
+}
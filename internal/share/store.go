@@ -0,0 +1,35 @@
+package share
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// 1つのStoreに保存できるエントリ数の上限（メモリやディスクを使い切られないようにする）
+const MaxEntries = 10000
+
+// 共有リンクが存在しない、閲覧済み、または期限切れの場合のエラー
+var ErrNotFound = errors.New("共有リンクが見つからないか、既に閲覧済みまたは期限切れです")
+
+// 保存されるエントリ（平文と復号鍵は含まない）
+type Entry struct {
+	Sealed    Sealed    `json:"sealed"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	Views     int       `json:"views"` // 残りの閲覧回数
+}
+
+// 共有する秘密の保存先のコントラクト
+//
+// メモリとファイルの実装を用意している。Redisなどの外部ストアに差し替える場合は、
+// Takeを閲覧回数の消費と削除を不可分に行う操作（GETDEL等）で実装する。
+type Store interface {
+	// idでエントリを保存する
+	Put(ctx context.Context, id string, e Entry) error
+	// 閲覧回数を1つ消費してエントリを返す
+	//
+	// 残り回数が0になったエントリは削除する。存在しないか期限切れの場合はErrNotFoundを返す。
+	Take(ctx context.Context, id string, now time.Time) (*Entry, error)
+	// 期限切れのエントリを削除する
+	Sweep(ctx context.Context, now time.Time) error
+}
@@ -0,0 +1,131 @@
+package share
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStores(t *testing.T) {
+	stores := map[string]func(t *testing.T) Store{
+		"memory": func(t *testing.T) Store { return NewMemoryStore() },
+		"file": func(t *testing.T) Store {
+			s, err := NewFileStore(filepath.Join(t.TempDir(), "share"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			return s
+		},
+	}
+
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	entry := func(views int) Entry {
+		return Entry{Sealed: Sealed{Ciphertext: []byte{1, 2, 3}, Nonce: []byte{4}}, ExpiresAt: now.Add(time.Hour), Views: views}
+	}
+	const id, other = "AAAAAAAAAAAAAAAAAAAAAA", "BBBBBBBBBBBBBBBBBBBBBB"
+
+	for name, newStore := range stores {
+		t.Run(name+"/閲覧回数", func(t *testing.T) {
+			s := newStore(t)
+			if err := s.Put(ctx, id, entry(2)); err != nil {
+				t.Fatalf("Put() エラー = %v", err)
+			}
+			if err := s.Put(ctx, id, entry(1)); err == nil {
+				t.Error("Put() 重複したIDでエラーが返されませんでした")
+			}
+			for i := 0; i < 2; i++ {
+				e, err := s.Take(ctx, id, now)
+				if err != nil {
+					t.Fatalf("Take() %d回目 エラー = %v", i+1, err)
+				}
+				if e.Views != 1-i {
+					t.Errorf("Take() 残り回数 = %d, want %d", e.Views, 1-i)
+				}
+			}
+			if _, err := s.Take(ctx, id, now); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Take() 消費後のエラー = %v, want ErrNotFound", err)
+			}
+		})
+
+		t.Run(name+"/有効期限", func(t *testing.T) {
+			s := newStore(t)
+			if err := s.Put(ctx, id, entry(1)); err != nil {
+				t.Fatal(err)
+			}
+			if err := s.Put(ctx, other, entry(1)); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := s.Take(ctx, id, now.Add(time.Hour)); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Take() 期限切れのエラー = %v, want ErrNotFound", err)
+			}
+			if err := s.Sweep(ctx, now.Add(time.Hour)); err != nil {
+				t.Fatalf("Sweep() エラー = %v", err)
+			}
+			if _, err := s.Take(ctx, other, now); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Sweep() 後のエラー = %v, want ErrNotFound", err)
+			}
+		})
+
+		t.Run(name+"/エントリ数の上限", func(t *testing.T) {
+			s := newStore(t)
+			setMaxEntries(s, 1)
+			if err := s.Put(ctx, id, entry(1)); err != nil {
+				t.Fatalf("Put() エラー = %v", err)
+			}
+			if err := s.Put(ctx, other, entry(1)); err == nil {
+				t.Error("Put() 上限を超えてもエラーが返されませんでした")
+			}
+			// 閲覧して削除されると再び保存できる
+			if _, err := s.Take(ctx, id, now); err != nil {
+				t.Fatal(err)
+			}
+			if err := s.Put(ctx, other, entry(1)); err != nil {
+				t.Errorf("Put() 削除後のエラー = %v", err)
+			}
+		})
+
+		t.Run(name+"/存在しないID", func(t *testing.T) {
+			if _, err := newStore(t).Take(ctx, other, now); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Take() エラー = %v, want ErrNotFound", err)
+			}
+		})
+	}
+}
+
+// テストのためにエントリ数の上限を下げる
+func setMaxEntries(s Store, n int) {
+	switch s := s.(type) {
+	case *MemoryStore:
+		s.maxEntries = n
+	case *FileStore:
+		s.maxEntries = n
+	}
+}
+
+func TestFileStore_Permissions(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "share")
+	s, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const id = "AAAAAAAAAAAAAAAAAAAAAA"
+	if err := s.Put(context.Background(), id, Entry{ExpiresAt: time.Now().Add(time.Hour), Views: 1}); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(filepath.Join(dir, id+entryExt))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("ファイルの権限 = %o, want 600", perm)
+	}
+
+	// 不正なIDではディレクトリの外を参照しない
+	if err := s.Put(context.Background(), "../escape", Entry{}); err == nil {
+		t.Errorf("Put() 不正なIDのエラー = %v", err)
+	}
+}
@@ -0,0 +1,276 @@
+package sheet
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/okamyuji/PasswordGenerator/internal/qrcode"
+)
+
+// A4縦（ポイント）とレイアウト
+const (
+	pageWidth  = 595
+	pageHeight = 842
+	margin     = 56
+	qrSize     = 120
+	// パスワードの1行に並べるまとまりの数
+	groupsPerLine = 5
+	// 読みの表の列数
+	spellingColumns = 3
+)
+
+// PDFのフォントリソース名
+const (
+	// 日本語用のCIDフォント（Adobe-Japan1の標準フォントで、埋め込まずビューアーのフォントを使う）
+	fontGothic = "F1"
+	// 等幅のCourier（標準14フォント）
+	fontMono = "F2"
+)
+
+// オブジェクト番号（ページとその内容ストリームは固定オブジェクトの後に続く）
+const (
+	objCatalog = iota + 1
+	objPages
+	objGothic
+	objGothicCID
+	objGothicDescriptor
+	objMono
+	objInfo
+	objFirstPage
+)
+
+// 印刷用シートをPDFとして出力
+//
+// 外部ライブラリを使わずにPDF 1.4を直接組み立てる。1人につき1ページで、
+// QRコードはベクターの矩形として描画する。
+func (s *Sheet) PDF() ([]byte, error) {
+	var d pdfDocument
+	d.buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	kids := make([]string, len(s.Pages))
+	for i := range s.Pages {
+		kids[i] = fmt.Sprintf("%d 0 R", objFirstPage+2*i)
+	}
+	d.object(objCatalog, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", objPages))
+	d.object(objPages, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(s.Pages)))
+	d.object(objGothic, fmt.Sprintf("<< /Type /Font /Subtype /Type0 /BaseFont /HeiseiKakuGo-W5 /Encoding /UniJIS-UCS2-HW-H /DescendantFonts [%d 0 R] >>", objGothicCID))
+	d.object(objGothicCID, fmt.Sprintf("<< /Type /Font /Subtype /CIDFontType0 /BaseFont /HeiseiKakuGo-W5 "+
+		"/CIDSystemInfo << /Registry (Adobe) /Ordering (Japan1) /Supplement 2 >> /FontDescriptor %d 0 R /DW 1000 /W [1 95 500] >>", objGothicDescriptor))
+	d.object(objGothicDescriptor, "<< /Type /FontDescriptor /FontName /HeiseiKakuGo-W5 /Flags 4 /FontBBox [-92 -250 1010 922] "+
+		"/ItalicAngle 0 /Ascent 752 /Descent -221 /CapHeight 737 /StemV 114 >>")
+	d.object(objMono, "<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+	d.object(objInfo, fmt.Sprintf("<< /Title %s /Producer (PasswordGenerator) /CreationDate (D:%s) >>",
+		textString(s.Title), s.GeneratedAt.Format("20060102150405Z")))
+
+	for i, p := range s.Pages {
+		page, contents := objFirstPage+2*i, objFirstPage+2*i+1
+		d.object(page, fmt.Sprintf("<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %d %d] "+
+			"/Resources << /Font << /%s %d 0 R /%s %d 0 R >> >> /Contents %d 0 R >>",
+			objPages, pageWidth, pageHeight, fontGothic, objGothic, fontMono, objMono, contents))
+		if err := d.stream(contents, s.pageContent(p)); err != nil {
+			return nil, err
+		}
+	}
+	return d.finish(objInfo), nil
+}
+
+// 1ページ分の描画命令
+func (s *Sheet) pageContent(p Page) []byte {
+	var c pdfContent
+	c.text(fontGothic, 18, margin, 70, s.Title)
+	c.text(fontGothic, 9, margin, 90, fmt.Sprintf("生成日時: %s    %d / %d", s.GeneratedAt.Format("2006-01-02 15:04:05 UTC"), p.Number, len(s.Pages)))
+	c.line(margin, 102, pageWidth-margin, 102)
+
+	c.text(fontGothic, 10, margin, 130, "ユーザー名")
+	c.text(fontGothic, 16, margin, 152, p.Username)
+
+	// パスワードはまとまりごとに区切り、1行にgroupsPerLine個ずつ並べる
+	c.text(fontGothic, 10, margin, 185, "パスワード")
+	top := 212.0
+	for i := 0; i < len(p.Groups); i += groupsPerLine {
+		line := strings.Join(p.Groups[i:min(i+groupsPerLine, len(p.Groups))], " ")
+		c.text(textFont(line), 20, margin, top, line)
+		top += 26
+	}
+
+	if p.qr != nil {
+		c.qrCode(p.qr, pageWidth-margin-qrSize, 120, qrSize)
+	}
+
+	// 1文字ずつの読みの表
+	top = max(top, 250) + 24
+	c.text(fontGothic, 10, margin, top, "読み（大文字はALFA、小文字はalfaのように表記）")
+	top += 20
+	rows := (len(p.Spelling) + spellingColumns - 1) / spellingColumns
+	columnWidth := float64(pageWidth-2*margin) / spellingColumns
+	for i, l := range p.Spelling {
+		x := margin + float64(i/rows)*columnWidth
+		y := top + float64(i%rows)*13
+		c.text(fontMono, 9, x, y, fmt.Sprintf("%2d", i+1))
+		c.text(textFont(l.Char), 11, x+18, y, l.Char)
+		c.text(textFont(l.English), 9, x+34, y, l.English)
+	}
+	top += float64(rows)*13 + 24
+
+	c.line(margin, top-12, pageWidth-margin, top-12)
+	c.text(fontGothic, 10, margin, top+4, "パスワードポリシー")
+	for i, line := range s.Policy {
+		c.text(fontGothic, 9, margin+8, top+22+float64(i)*13, "・"+line)
+	}
+	return c.buf.Bytes()
+}
+
+// ASCIIのみならCourier、それ以外は日本語フォントを使う
+func textFont(s string) string {
+	for _, r := range s {
+		if r < 0x20 || r > 0x7e {
+			return fontGothic
+		}
+	}
+	return fontMono
+}
+
+// ページの描画命令（座標は左上を原点としたポイントで受け取る）
+type pdfContent struct {
+	buf bytes.Buffer
+}
+
+// topはベースラインの位置
+func (c *pdfContent) text(font string, size, x, top float64, s string) {
+	var encoded string
+	if font == fontGothic {
+		encoded = utf16Hex(s)
+	} else {
+		encoded = literal(s)
+	}
+	fmt.Fprintf(&c.buf, "BT /%s %s Tf %s %s Td %s Tj ET\n", font, num(size), num(x), num(pageHeight-top), encoded)
+}
+
+func (c *pdfContent) line(x1, top1, x2, top2 float64) {
+	fmt.Fprintf(&c.buf, "0.5 w %s %s m %s %s l S\n", num(x1), num(pageHeight-top1), num(x2), num(pageHeight-top2))
+}
+
+// 余白を含めてsize×sizeに収まるようにQRコードを描画
+func (c *pdfContent) qrCode(code *qrcode.Code, x, top, size float64) {
+	const quietZone = 4
+	module := size / float64(code.Size()+2*quietZone)
+	c.buf.WriteString("0 g\n")
+	for my := 0; my < code.Size(); my++ {
+		for mx := 0; mx < code.Size(); mx++ {
+			if !code.Black(mx, my) {
+				continue
+			}
+			run := 1
+			for mx+run < code.Size() && code.Black(mx+run, my) {
+				run++
+			}
+			fmt.Fprintf(&c.buf, "%s %s %s %s re\n",
+				num(x+float64(quietZone+mx)*module), num(pageHeight-top-float64(quietZone+my+1)*module),
+				num(float64(run)*module), num(module))
+			mx += run - 1
+		}
+	}
+	c.buf.WriteString("f\n")
+}
+
+// 小数点以下2桁までの数値
+func num(v float64) string {
+	s := strings.TrimRight(fmt.Sprintf("%.2f", v), "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// UTF-16BEの16進文字列（基本多言語面以外の文字は?に置き換える）
+func utf16Hex(s string) string {
+	var sb strings.Builder
+	sb.WriteByte('<')
+	for _, r := range s {
+		if r > 0xffff || utf16.IsSurrogate(r) {
+			r = '?'
+		}
+		fmt.Fprintf(&sb, "%04X", r)
+	}
+	sb.WriteByte('>')
+	return sb.String()
+}
+
+// 文書情報のテキスト文字列（BOM付きUTF-16BE）
+func textString(s string) string {
+	return "<FEFF" + utf16Hex(s)[1:]
+}
+
+// 括弧とバックスラッシュをエスケープしたリテラル文字列（ASCII以外は?に置き換える）
+func literal(s string) string {
+	var sb strings.Builder
+	sb.WriteByte('(')
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		case r < 0x20 || r > 0x7e:
+			sb.WriteByte('?')
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteByte(')')
+	return sb.String()
+}
+
+// オブジェクトのバイトオフセットを記録しながらPDFを書き出す
+type pdfDocument struct {
+	buf     bytes.Buffer
+	offsets []int
+}
+
+// オブジェクトは番号順に書き込む
+func (d *pdfDocument) object(num int, body string) {
+	d.begin(num)
+	d.buf.WriteString(body)
+	d.buf.WriteString("\nendobj\n")
+}
+
+// Flateで圧縮したストリームオブジェクト
+//
+// 内容にはパスワードが含まれるため、書き込み後に元の内容と圧縮途中のバッファをゼロ埋めする。
+func (d *pdfDocument) stream(num int, data []byte) error {
+	defer clear(data)
+	var compressed bytes.Buffer
+	defer func() { clear(compressed.Bytes()) }()
+	zw := zlib.NewWriter(&compressed)
+	if _, err := zw.Write(data); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+
+	d.begin(num)
+	fmt.Fprintf(&d.buf, "<< /Length %d /Filter /FlateDecode >>\nstream\n", compressed.Len())
+	d.buf.Write(compressed.Bytes())
+	d.buf.WriteString("\nendstream\nendobj\n")
+	return nil
+}
+
+func (d *pdfDocument) begin(num int) {
+	if num != len(d.offsets)+1 {
+		panic(fmt.Sprintf("PDFのオブジェクトが番号順ではありません: %d", num))
+	}
+	d.offsets = append(d.offsets, d.buf.Len())
+	fmt.Fprintf(&d.buf, "%d 0 obj\n", num)
+}
+
+// 相互参照表とトレーラーを書き込んで完成させる
+func (d *pdfDocument) finish(info int) []byte {
+	xref := d.buf.Len()
+	fmt.Fprintf(&d.buf, "xref\n0 %d\n0000000000 65535 f \n", len(d.offsets)+1)
+	for _, off := range d.offsets {
+		fmt.Fprintf(&d.buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&d.buf, "trailer\n<< /Size %d /Root %d 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		len(d.offsets)+1, objCatalog, info, xref)
+	return d.buf.Bytes()
+}
@@ -0,0 +1,95 @@
+package sheet
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/okamyuji/PasswordGenerator/internal/format"
+)
+
+func TestSheet_PDF(t *testing.T) {
+	creds := []format.Credential{
+		{Username: "alice", Password: `xK7#(m\Q2!`},
+		{Username: "山田", Password: "Ab3$Cd4%"},
+		{Username: "carol", Password: "Zz9^Yy8&"},
+	}
+	s, err := New(creds, testConfig, "入社手続き", time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pdf, err := s.PDF()
+	if err != nil {
+		t.Fatalf("PDF() エラー = %v", err)
+	}
+
+	if !bytes.HasPrefix(pdf, []byte("%PDF-1.4\n")) || !bytes.HasSuffix(pdf, []byte("%%EOF\n")) {
+		t.Fatal("PDF() のヘッダーまたはトレーラーが不正です")
+	}
+	if !bytes.Contains(pdf, []byte("/Count 3")) {
+		t.Error("PDF() のページ数が3ではありません")
+	}
+	if !bytes.Contains(pdf, []byte("/CreationDate (D:20260401000000Z)")) {
+		t.Error("PDF() に作成日時がありません")
+	}
+
+	// 相互参照表の各オフセットが対応するオブジェクトの先頭を指している
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(pdf)
+	if m == nil {
+		t.Fatal("startxref がありません")
+	}
+	xref, _ := strconv.Atoi(string(m[1]))
+	if !bytes.HasPrefix(pdf[xref:], []byte("xref\n")) {
+		t.Fatalf("startxref が相互参照表を指していません: %d", xref)
+	}
+	lines := strings.Split(string(pdf[xref:]), "\n")
+	count, _ := strconv.Atoi(strings.Fields(lines[1])[1])
+	if count != objFirstPage+2*len(creds) {
+		t.Errorf("オブジェクト数 = %d, want %d", count, objFirstPage+2*len(creds))
+	}
+	for num := 1; num < count; num++ {
+		offset, _ := strconv.Atoi(lines[2+num][:10])
+		if want := fmt.Sprintf("%d 0 obj\n", num); !bytes.HasPrefix(pdf[offset:], []byte(want)) {
+			t.Errorf("オブジェクト%dのオフセットが不正です: %d", num, offset)
+		}
+	}
+
+	// 1ページ目の内容にエスケープされたパスワードと読みが含まれる
+	content := pageStream(t, pdf, objFirstPage+1)
+	for _, want := range []string{`(xK7# \(m\\Q 2!)`, "(KILO)", "(open paren)", "re\n", "/F1 18 Tf"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("1ページ目に %q が含まれていません", want)
+		}
+	}
+	// 日本語のユーザー名はCIDフォントのUTF-16で書き込む
+	if content := pageStream(t, pdf, objFirstPage+3); !strings.Contains(content, "<5C717530>") {
+		t.Error("2ページ目に日本語のユーザー名が含まれていません")
+	}
+}
+
+// 指定したオブジェクトのストリームを展開して返す
+func pageStream(t *testing.T, pdf []byte, num int) string {
+	t.Helper()
+	start := bytes.Index(pdf, []byte(fmt.Sprintf("\n%d 0 obj\n", num)))
+	if start < 0 {
+		t.Fatalf("オブジェクト%dがありません", num)
+	}
+	body := pdf[start:]
+	body = body[bytes.Index(body, []byte("stream\n"))+len("stream\n"):]
+	body = body[:bytes.Index(body, []byte("\nendstream"))]
+	zr, err := zlib.NewReader(bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("ストリームの展開に失敗: %v", err)
+	}
+	content, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("ストリームの展開に失敗: %v", err)
+	}
+	return string(content)
+}
@@ -0,0 +1,154 @@
+package sheet
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+
+	"github.com/okamyuji/PasswordGenerator/internal/config"
+	"github.com/okamyuji/PasswordGenerator/internal/format"
+	"github.com/okamyuji/PasswordGenerator/internal/phonetic"
+	"github.com/okamyuji/PasswordGenerator/internal/qrcode"
+)
+
+// 1ページに読みの表を収められるパスワードの最大長
+const MaxPasswordLength = 64
+
+// 1回に作成できるシートのユーザー数（ページ数）の上限
+//
+// 1つのリクエストで大量のページを生成させないよう、呼び出し側でパスワードを
+// 生成する前にユーザー数を確認する。
+const MaxUsers = 20
+
+// 既定のタイトル
+const DefaultTitle = "初期パスワードのお知らせ"
+
+// 1人分の認証情報のページ
+type Page struct {
+	Number   int // 1から始まるページ番号
+	Username string
+	Password string
+	Groups   []string // 読み上げや書き写しのために区切ったパスワード
+	Spelling []phonetic.Letter
+	qr       *qrcode.Code
+}
+
+// 印刷用の認証情報シート（1人につき1ページ）
+type Sheet struct {
+	Title       string
+	GeneratedAt time.Time
+	Policy      []string
+	Pages       []Page
+}
+
+// 認証情報の一覧から印刷用シートを作成
+func New(creds []format.Credential, cfg config.PasswordConfig, title string, now time.Time) (*Sheet, error) {
+	if len(creds) == 0 {
+		return nil, fmt.Errorf("認証情報がありません")
+	}
+	if cfg.Length > MaxPasswordLength {
+		return nil, fmt.Errorf("印刷用シートのパスワード長が最大値を超えています: %d (最大: %d)", cfg.Length, MaxPasswordLength)
+	}
+	policy, err := Policy(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if title == "" {
+		title = DefaultTitle
+	}
+
+	s := &Sheet{
+		Title:       title,
+		GeneratedAt: now.UTC().Truncate(time.Second),
+		Policy:      policy,
+		Pages:       make([]Page, len(creds)),
+	}
+	for i, c := range creds {
+		qr, err := qrcode.Encode(c.Password)
+		if err != nil {
+			return nil, err
+		}
+		s.Pages[i] = Page{
+			Number:   i + 1,
+			Username: c.Username,
+			Password: c.Password,
+			Groups:   phonetic.Chunk(c.Password, phonetic.DefaultChunkSize),
+			Spelling: phonetic.Spell(c.Password),
+			qr:       qr,
+		}
+	}
+	return s, nil
+}
+
+// パスワードを読み取るQRコードのSVG（テンプレートに埋め込む）
+func (p Page) QRCode() template.HTML {
+	if p.qr == nil {
+		return ""
+	}
+	return template.HTML(p.qr.SVG())
+}
+
+// パスワードポリシーの要約
+func Policy(cfg config.PasswordConfig) ([]string, error) {
+	entropy, err := cfg.EntropyBits()
+	if err != nil {
+		return nil, err
+	}
+
+	var classes []string
+	for _, c := range []struct {
+		enabled bool
+		name    string
+	}{
+		{cfg.UseUppercase, "大文字"},
+		{cfg.UseLowercase, "小文字"},
+		{cfg.UseNumbers, "数字"},
+		{cfg.UseSymbols, "記号"},
+		{cfg.UseHiragana, "ひらがな"},
+		{cfg.UseKatakana, "カタカナ"},
+		{cfg.UseFullWidthDigits, "全角数字"},
+	} {
+		if c.enabled {
+			classes = append(classes, c.name)
+		}
+	}
+	for _, b := range cfg.UnicodeBlocks {
+		classes = append(classes, string(b))
+	}
+
+	policy := []string{
+		fmt.Sprintf("長さ: %d文字", cfg.Length),
+		"文字種: " + strings.Join(classes, "・"),
+	}
+	if cfg.SymbolProfile != "" {
+		policy = append(policy, fmt.Sprintf("記号プロファイル: %s", cfg.SymbolProfile))
+	}
+	if len(cfg.KeyboardLayouts) > 0 {
+		layouts := make([]string, len(cfg.KeyboardLayouts))
+		for i, l := range cfg.KeyboardLayouts {
+			layouts[i] = string(l)
+		}
+		policy = append(policy, "キーボード配列: "+strings.Join(layouts, "・"))
+	}
+	if cfg.MinimizeLayerChanges {
+		policy = append(policy, "モバイルキーボードのレイヤー切り替えを抑制")
+	}
+	if cfg.Rules.MaxRepeat > 0 {
+		policy = append(policy, fmt.Sprintf("同じ文字の連続: %d文字まで", cfg.Rules.MaxRepeat))
+	}
+	if cfg.Rules.MaxSequence > 0 {
+		policy = append(policy, fmt.Sprintf("連続した並び（abc・321など）: %d文字まで", cfg.Rules.MaxSequence))
+	}
+	if cfg.Rules.FirstClass != "" {
+		policy = append(policy, fmt.Sprintf("先頭の文字: %s", cfg.Rules.FirstClass))
+	}
+	if cfg.Rules.LastClass != "" {
+		policy = append(policy, fmt.Sprintf("末尾の文字: %s", cfg.Rules.LastClass))
+	}
+	return append(policy,
+		fmt.Sprintf("強度: 約%dビット", int(entropy)),
+		"初回ログイン後に必ず変更してください",
+		"この用紙は内容を確認したらシュレッダーで破棄してください",
+	), nil
+}
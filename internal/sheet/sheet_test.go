@@ -0,0 +1,95 @@
+package sheet
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/okamyuji/PasswordGenerator/internal/config"
+	"github.com/okamyuji/PasswordGenerator/internal/format"
+)
+
+var testConfig = config.PasswordConfig{Length: 8, UseUppercase: true, UseLowercase: true, UseNumbers: true, UseSymbols: true}
+
+func TestNew(t *testing.T) {
+	creds := []format.Credential{{Username: "alice", Password: "xK7#mQ2!"}, {Username: "bob", Password: "Ab3$Cd4%"}}
+	s, err := New(creds, testConfig, "", time.Date(2026, 4, 1, 9, 0, 0, 5, time.FixedZone("JST", 9*3600)))
+	if err != nil {
+		t.Fatalf("New() エラー = %v", err)
+	}
+	if s.Title != DefaultTitle || s.GeneratedAt != time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC) {
+		t.Errorf("New() Title = %q, GeneratedAt = %v", s.Title, s.GeneratedAt)
+	}
+	if len(s.Pages) != 2 || s.Pages[1].Number != 2 {
+		t.Fatalf("ページ数 = %d, want 2", len(s.Pages))
+	}
+
+	p := s.Pages[0]
+	if strings.Join(p.Groups, "-") != "xK7#-mQ2!" {
+		t.Errorf("Groups = %q", p.Groups)
+	}
+	if len(p.Spelling) != 8 || p.Spelling[1].English != "KILO" || p.Spelling[3].English != "hash" {
+		t.Errorf("Spelling = %v", p.Spelling)
+	}
+	if svg := string(p.QRCode()); !strings.HasPrefix(svg, "<svg") {
+		t.Errorf("QRCode() = %q", svg)
+	}
+}
+
+func TestNew_Invalid(t *testing.T) {
+	now := time.Now()
+	if _, err := New(nil, testConfig, "", now); err == nil {
+		t.Error("New() 認証情報なしでエラーが返されませんでした")
+	}
+	long := testConfig
+	long.Length = MaxPasswordLength + 1
+	creds := []format.Credential{{Username: "alice", Password: strings.Repeat("a", long.Length)}}
+	if _, err := New(creds, long, "", now); err == nil {
+		t.Error("New() 長すぎるパスワードでエラーが返されませんでした")
+	}
+}
+
+func TestPolicy(t *testing.T) {
+	cfg := config.PasswordConfig{Length: 16, UseLowercase: true, UseNumbers: true, UseSymbols: true, SymbolProfile: config.SymbolProfileURL}
+	policy, err := Policy(cfg)
+	if err != nil {
+		t.Fatalf("Policy() エラー = %v", err)
+	}
+	joined := strings.Join(policy, "\n")
+	for _, want := range []string{"長さ: 16文字", "文字種: 小文字・数字・記号", "記号プロファイル: url", "強度: 約", "変更してください"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("Policy() に %q が含まれていません: %s", want, joined)
+		}
+	}
+
+	// かなの強度はUTF-8のバイト数ではなく文字数（71文字）から求める
+	kana, err := Policy(config.PasswordConfig{Length: 8, UseHiragana: true})
+	if err != nil {
+		t.Fatalf("Policy() エラー = %v", err)
+	}
+	if joined := strings.Join(kana, "\n"); !strings.Contains(joined, "文字種: ひらがな") || !strings.Contains(joined, "強度: 約49ビット") {
+		t.Errorf("Policy() かなの要約が不正です: %s", joined)
+	}
+
+	layouts, err := Policy(config.PasswordConfig{Length: 8, UseLowercase: true, KeyboardLayouts: []config.KeyboardLayout{config.KeyboardUS, config.KeyboardJIS}, MinimizeLayerChanges: true})
+	if err != nil {
+		t.Fatalf("Policy() エラー = %v", err)
+	}
+	if joined := strings.Join(layouts, "\n"); !strings.Contains(joined, "キーボード配列: us・jis") || !strings.Contains(joined, "レイヤー切り替え") {
+		t.Errorf("Policy() キーボード配列の要約が不正です: %s", joined)
+	}
+
+	rules, err := Policy(config.PasswordConfig{Length: 8, UseLowercase: true, UseNumbers: true, Rules: config.Rules{MaxRepeat: 2, MaxSequence: 3, FirstClass: config.ClassLetter}})
+	if err != nil {
+		t.Fatalf("Policy() エラー = %v", err)
+	}
+	joined = strings.Join(rules, "\n")
+	for _, want := range []string{"同じ文字の連続: 2文字まで", "連続した並び（abc・321など）: 3文字まで", "先頭の文字: letter"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("Policy() に %q が含まれていません: %s", want, joined)
+		}
+	}
+	if _, err := Policy(config.PasswordConfig{Length: 8}); err == nil {
+		t.Error("Policy() 文字種なしでエラーが返されませんでした")
+	}
+}
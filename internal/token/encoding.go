@@ -0,0 +1,118 @@
+package token
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// トークンのエンコーディング
+type Encoding string
+
+const (
+	EncodingHex       Encoding = "hex"
+	EncodingBase32    Encoding = "base32"    // RFC 4648（パディングなし）
+	EncodingBase64URL Encoding = "base64url" // RFC 4648 URLセーフ（パディングなし）
+	EncodingBase64    Encoding = "base64"    // RFC 4648 標準（パディングあり、openssl rand -base64と同じ）
+	EncodingZBase32   Encoding = "zbase32"   // 人間が扱いやすい順序のz-base-32
+	EncodingCrockford Encoding = "crockford" // Crockford base32
+)
+
+const (
+	zBase32Alphabet   = "ybndrfg8ejkmcpqxot1uwisza345h769"
+	crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+)
+
+var (
+	zBase32Encoding   = base32.NewEncoding(zBase32Alphabet).WithPadding(base32.NoPadding)
+	crockfordEncoding = base32.NewEncoding(crockfordAlphabet).WithPadding(base32.NoPadding)
+)
+
+// エンコーディング名を解析
+func ParseEncoding(name string) (Encoding, error) {
+	e := Encoding(strings.ToLower(strings.TrimSpace(name)))
+	switch e {
+	case EncodingHex, EncodingBase32, EncodingBase64URL, EncodingBase64, EncodingZBase32, EncodingCrockford:
+		return e, nil
+	case "z-base-32":
+		return EncodingZBase32, nil
+	default:
+		return "", fmt.Errorf("未対応のエンコーディング: %s", name)
+	}
+}
+
+// バイト列をエンコード
+func (e Encoding) Encode(b []byte) (string, error) {
+	switch e {
+	case EncodingHex:
+		return hex.EncodeToString(b), nil
+	case EncodingBase32:
+		return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b), nil
+	case EncodingBase64URL:
+		return base64.RawURLEncoding.EncodeToString(b), nil
+	case EncodingBase64:
+		return base64.StdEncoding.EncodeToString(b), nil
+	case EncodingZBase32:
+		return zBase32Encoding.EncodeToString(b), nil
+	case EncodingCrockford:
+		return crockfordEncoding.EncodeToString(b), nil
+	default:
+		return "", fmt.Errorf("未対応のエンコーディング: %s", e)
+	}
+}
+
+// 文字列をデコード
+//
+// Crockford base32は仕様どおり大文字小文字を区別せず、O・I・Lを0・1・1として扱う。
+func (e Encoding) Decode(s string) ([]byte, error) {
+	switch e {
+	case EncodingHex:
+		return hex.DecodeString(s)
+	case EncodingBase32:
+		return base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(s)
+	case EncodingBase64URL:
+		return base64.RawURLEncoding.DecodeString(s)
+	case EncodingBase64:
+		return base64.StdEncoding.DecodeString(s)
+	case EncodingZBase32:
+		return zBase32Encoding.DecodeString(s)
+	case EncodingCrockford:
+		return crockfordEncoding.DecodeString(normalizeCrockford(s))
+	default:
+		return nil, fmt.Errorf("未対応のエンコーディング: %s", e)
+	}
+}
+
+// エンコード後の文字数
+func (e Encoding) EncodedLen(n int) int {
+	switch e {
+	case EncodingHex:
+		return hex.EncodedLen(n)
+	case EncodingBase64URL:
+		return base64.RawURLEncoding.EncodedLen(n)
+	case EncodingBase64:
+		return base64.StdEncoding.EncodedLen(n)
+	default:
+		return base32.StdEncoding.WithPadding(base32.NoPadding).EncodedLen(n)
+	}
+}
+
+// Crockford base32の入力を正規化（ハイフンは区切りとして無視する）
+func normalizeCrockford(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '-':
+			return -1
+		case 'o', 'O':
+			return '0'
+		case 'i', 'I', 'l', 'L':
+			return '1'
+		}
+		if r >= 'a' && r <= 'z' {
+			return r - 'a' + 'A'
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,61 @@
+package token
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEncoding_RoundTrip(t *testing.T) {
+	data := []byte{0x00, 0x44, 0x32, 0x14, 0xc7, 0x42, 0x54, 0xb6, 0x35, 0xcf, 0x84, 0x65, 0x3a, 0x56, 0xd7, 0xc6, 0x75, 0xbe, 0x77, 0xdf}
+
+	tests := []struct {
+		encoding Encoding
+		want     string
+	}{
+		{encoding: EncodingHex, want: "00443214c74254b635cf84653a56d7c675be77df"},
+		{encoding: EncodingBase32, want: "ABCDEFGHIJKLMNOPQRSTUVWXYZ234567"},
+		{encoding: EncodingBase64URL, want: "AEQyFMdCVLY1z4RlOlbXxnW-d98"},
+		{encoding: EncodingBase64, want: "AEQyFMdCVLY1z4RlOlbXxnW+d98="},
+		{encoding: EncodingZBase32, want: "ybndrfg8ejkmcpqxot1uwisza345h769"},
+		{encoding: EncodingCrockford, want: "0123456789ABCDEFGHJKMNPQRSTVWXYZ"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.encoding), func(t *testing.T) {
+			got, err := tt.encoding.Encode(data)
+			if err != nil {
+				t.Fatalf("Encode() エラー = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Encode() = %v, want %v", got, tt.want)
+			}
+			if len(got) != tt.encoding.EncodedLen(len(data)) {
+				t.Errorf("EncodedLen() = %d, want %d", tt.encoding.EncodedLen(len(data)), len(got))
+			}
+			decoded, err := tt.encoding.Decode(got)
+			if err != nil || !bytes.Equal(decoded, data) {
+				t.Errorf("Decode() = %x, %v, want %x", decoded, err, data)
+			}
+		})
+	}
+}
+
+func TestEncoding_CrockfordNormalization(t *testing.T) {
+	got, err := EncodingCrockford.Decode("oI23-456789abcdefghjkmnpqrstvwxyz")
+	if err != nil {
+		t.Fatalf("Decode() エラー = %v", err)
+	}
+	want, _ := EncodingCrockford.Decode("0123456789ABCDEFGHJKMNPQRSTVWXYZ")
+	if !bytes.Equal(got, want) {
+		t.Errorf("Decode() = %x, want %x", got, want)
+	}
+}
+
+func TestParseEncoding(t *testing.T) {
+	if e, err := ParseEncoding("Z-Base-32"); err != nil || e != EncodingZBase32 {
+		t.Errorf("ParseEncoding() = %v, %v", e, err)
+	}
+	if _, err := ParseEncoding("base58"); err == nil {
+		t.Error("ParseEncoding() 未対応のエンコーディングでエラーが返されませんでした")
+	}
+}
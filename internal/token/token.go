@@ -0,0 +1,191 @@
+package token
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// トークンに付加するチェックサム
+type Checksum string
+
+const (
+	ChecksumNone      Checksum = ""
+	ChecksumCRC32     Checksum = "crc32"     // GitHub形式のCRC32（シークレットスキャナー向け）
+	ChecksumCrockford Checksum = "crockford" // Crockford base32のmod 37チェック文字
+)
+
+// トークンの長さの制約
+const (
+	MinTokenBytes     = 16 // 128ビット
+	MaxTokenBytes     = 512
+	DefaultTokenBytes = 32
+	maxPrefixLength   = 32
+)
+
+// Crockford base32のチェック文字（値32〜36は * ~ $ = U）
+const crockfordCheckAlphabet = crockfordAlphabet + "*~$=U"
+
+var prefixPattern = regexp.MustCompile(`^[A-Za-z0-9_-]*$`)
+
+// トークン生成のオプション
+type Options struct {
+	Bytes       int      // 乱数のバイト数（EntropyBitsより優先）
+	EntropyBits int      // 必要なエントロピー（ビット）。8の倍数に切り上げる
+	Encoding    Encoding // 省略時はbase64url
+	Prefix      string   // 例: ghp_
+	Checksum    Checksum
+}
+
+type Generator struct {
+	rand io.Reader
+}
+
+// crypto/randを乱数源とするGeneratorを作成
+func New() *Generator {
+	return NewWithReader(rand.Reader)
+}
+
+// 指定された乱数源を使うGeneratorを作成
+func NewWithReader(r io.Reader) *Generator {
+	return &Generator{rand: r}
+}
+
+// 生成する乱数のバイト数
+func (o Options) ByteLength() int {
+	switch {
+	case o.Bytes != 0:
+		return o.Bytes
+	case o.EntropyBits != 0:
+		return (o.EntropyBits + 7) / 8
+	default:
+		return DefaultTokenBytes
+	}
+}
+
+// 既定値を補完してオプションを検証
+func (o Options) normalize() (Options, error) {
+	if o.Encoding == "" {
+		o.Encoding = EncodingBase64URL
+	}
+	if _, err := ParseEncoding(string(o.Encoding)); err != nil {
+		return o, err
+	}
+	o.Bytes = o.ByteLength()
+	if o.Bytes < MinTokenBytes || o.Bytes > MaxTokenBytes {
+		return o, fmt.Errorf("無効なトークン長: %dバイト (%d〜%dバイト)", o.Bytes, MinTokenBytes, MaxTokenBytes)
+	}
+	if len(o.Prefix) > maxPrefixLength || !prefixPattern.MatchString(o.Prefix) {
+		return o, fmt.Errorf("無効なプレフィックス: %q", o.Prefix)
+	}
+	switch o.Checksum {
+	case ChecksumNone, ChecksumCRC32:
+	case ChecksumCrockford:
+		if o.Encoding != EncodingCrockford {
+			return o, fmt.Errorf("crockfordチェックサムはcrockfordエンコーディングでのみ使用できます")
+		}
+	default:
+		return o, fmt.Errorf("未対応のチェックサム: %s", o.Checksum)
+	}
+	return o, nil
+}
+
+// ランダムなトークンを生成
+//
+// 形式は <プレフィックス><乱数をエンコードした本体><チェックサム> となる。
+func (g *Generator) Generate(opts Options) (string, error) {
+	opts, err := opts.normalize()
+	if err != nil {
+		return "", err
+	}
+
+	b := make([]byte, opts.Bytes)
+	if _, err := io.ReadFull(g.rand, b); err != nil {
+		return "", err
+	}
+	body, err := opts.Encoding.Encode(b)
+	if err != nil {
+		return "", err
+	}
+	sum, err := checksum(body, opts)
+	if err != nil {
+		return "", err
+	}
+	return opts.Prefix + body + sum, nil
+}
+
+// トークンのプレフィックス、エンコーディング、長さ、チェックサムを検証
+func Validate(token string, opts Options) error {
+	opts, err := opts.normalize()
+	if err != nil {
+		return err
+	}
+
+	rest, ok := strings.CutPrefix(token, opts.Prefix)
+	if !ok {
+		return fmt.Errorf("プレフィックスが一致しません")
+	}
+	sumLen := checksumLen(opts)
+	bodyLen := opts.Encoding.EncodedLen(opts.Bytes)
+	if opts.Encoding == EncodingCrockford {
+		rest = strings.ReplaceAll(rest, "-", "")
+	}
+	if len(rest) != bodyLen+sumLen {
+		return fmt.Errorf("トークンの長さが不正です")
+	}
+
+	body, sum := rest[:bodyLen], rest[bodyLen:]
+	if opts.Encoding == EncodingCrockford {
+		body = normalizeCrockford(body)
+		sum = strings.ToUpper(sum)
+	}
+	if _, err := opts.Encoding.Decode(body); err != nil {
+		return fmt.Errorf("トークンをデコードできません: %w", err)
+	}
+	want, err := checksum(body, opts)
+	if err != nil {
+		return err
+	}
+	if sum != want {
+		return fmt.Errorf("チェックサムが一致しません")
+	}
+	return nil
+}
+
+func checksumLen(opts Options) int {
+	switch opts.Checksum {
+	case ChecksumCRC32:
+		return opts.Encoding.EncodedLen(4)
+	case ChecksumCrockford:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// エンコード済みの本体からチェックサム部分を計算
+func checksum(body string, opts Options) (string, error) {
+	switch opts.Checksum {
+	case ChecksumCRC32:
+		var b [4]byte
+		binary.BigEndian.PutUint32(b[:], crc32.ChecksumIEEE([]byte(body)))
+		return opts.Encoding.Encode(b[:])
+	case ChecksumCrockford:
+		return string(crockfordCheckAlphabet[crockfordMod37(body)]), nil
+	default:
+		return "", nil
+	}
+}
+
+// Crockford base32の文字列を数値とみなしたときの37の剰余
+func crockfordMod37(s string) int {
+	mod := 0
+	for i := 0; i < len(s); i++ {
+		mod = (mod*32 + strings.IndexByte(crockfordAlphabet, s[i])) % 37
+	}
+	return mod
+}
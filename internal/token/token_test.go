@@ -0,0 +1,125 @@
+package token
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/okamyuji/PasswordGenerator/internal/random"
+)
+
+func TestGenerator_Generate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    Options
+		wantLen int
+		wantErr bool
+	}{
+		{name: "既定値", opts: Options{}, wantLen: 43},
+		{name: "hex 16バイト", opts: Options{Bytes: 16, Encoding: EncodingHex}, wantLen: 32},
+		{name: "エントロピー指定", opts: Options{EntropyBits: 130, Encoding: EncodingBase32}, wantLen: 28},
+		{name: "GitHub形式", opts: Options{Bytes: 24, Prefix: "ghp_", Checksum: ChecksumCRC32}, wantLen: 4 + 32 + 6},
+		{name: "Crockfordチェック文字", opts: Options{Bytes: 20, Encoding: EncodingCrockford, Checksum: ChecksumCrockford}, wantLen: 33},
+		{name: "短すぎる", opts: Options{Bytes: 8}, wantErr: true},
+		{name: "長すぎる", opts: Options{Bytes: MaxTokenBytes + 1}, wantErr: true},
+		{name: "不正なプレフィックス", opts: Options{Prefix: "a b"}, wantErr: true},
+		{name: "Crockford以外でのチェック文字", opts: Options{Checksum: ChecksumCrockford}, wantErr: true},
+		{name: "未対応のチェックサム", opts: Options{Checksum: "md5"}, wantErr: true},
+	}
+
+	g := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := g.Generate(tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Generate() エラー = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != tt.wantLen {
+				t.Errorf("Generate() = %v, 長さ = %d, want %d", got, len(got), tt.wantLen)
+			}
+			if !strings.HasPrefix(got, tt.opts.Prefix) {
+				t.Errorf("Generate() = %v, プレフィックスがありません", got)
+			}
+			if err := Validate(got, tt.opts); err != nil {
+				t.Errorf("Validate(%v) エラー = %v", got, err)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	opts := Options{Bytes: 24, Prefix: "ghp_", Checksum: ChecksumCRC32}
+	tok, err := New().Generate(opts)
+	if err != nil {
+		t.Fatalf("Generate() エラー = %v", err)
+	}
+
+	// 本体の1文字を変更するとチェックサムが一致しなくなる
+	body := []byte(tok)
+	if body[10] == 'A' {
+		body[10] = 'B'
+	} else {
+		body[10] = 'A'
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		opts  Options
+	}{
+		{name: "プレフィックスが異なる", token: "gho_" + tok[4:], opts: opts},
+		{name: "長さが異なる", token: tok + "A", opts: opts},
+		{name: "本体の改ざん", token: string(body), opts: opts},
+		{name: "デコードできない", token: "ghp_" + strings.Repeat("!", len(tok)-4), opts: opts},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Validate(tt.token, tt.opts); err == nil {
+				t.Errorf("Validate(%v) エラーが返されませんでした", tt.token)
+			}
+		})
+	}
+}
+
+func TestValidate_CrockfordLenient(t *testing.T) {
+	opts := Options{Bytes: 20, Encoding: EncodingCrockford, Checksum: ChecksumCrockford}
+	tok, err := New().Generate(opts)
+	if err != nil {
+		t.Fatalf("Generate() エラー = %v", err)
+	}
+	// 小文字化やハイフン区切りでも検証できる
+	lenient := strings.ToLower(tok[:8]) + "-" + tok[8:]
+	if err := Validate(lenient, opts); err != nil {
+		t.Errorf("Validate(%v) エラー = %v", lenient, err)
+	}
+}
+
+func TestCrockfordMod37(t *testing.T) {
+	tests := []struct {
+		body string
+		want byte
+	}{
+		{body: "1", want: '1'},
+		{body: "Z", want: 'Z'},
+		{body: "10", want: '*'}, // 32
+		{body: "11", want: '~'}, // 33
+		{body: "14", want: 'U'}, // 36
+	}
+	for _, tt := range tests {
+		if got := crockfordCheckAlphabet[crockfordMod37(tt.body)]; got != tt.want {
+			t.Errorf("crockfordMod37(%q) = %c, want %c", tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestGenerator_GenerateDeterministic(t *testing.T) {
+	got, err := NewWithReader(random.NewDeterministic("golden")).Generate(Options{Prefix: "ghp_", Checksum: ChecksumCRC32})
+	if err != nil {
+		t.Fatalf("Generate() エラー = %v", err)
+	}
+	if want := "ghp_FyKIccMMYeLGuhoTAAqNblru07dqE_Fvyx1AMpcBj10QO0X8Q"; got != want {
+		t.Errorf("Generate() = %q, want %q", got, want)
+	}
+}
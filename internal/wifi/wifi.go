@@ -0,0 +1,123 @@
+package wifi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/okamyuji/PasswordGenerator/internal/config"
+)
+
+// WPA2パスフレーズの長さ（IEEE 802.11i）
+const (
+	MinPassphraseLength = 8
+	MaxPassphraseLength = 63
+)
+
+// SSIDの最大バイト数
+const MaxSSIDLength = 32
+
+// 既定のパスフレーズの長さ
+const DefaultPassphraseLength = 20
+
+// QRコードで共有するWi-Fiの接続情報
+type Network struct {
+	SSID       string
+	Passphrase string
+	Hidden     bool // SSIDをブロードキャストしないネットワーク
+}
+
+// WPA2パスフレーズとして有効か検証
+//
+// パスフレーズは8〜63文字の印字可能なASCII文字（0x20〜0x7E）に限られる。
+func ValidatePassphrase(p string) error {
+	if len(p) < MinPassphraseLength || len(p) > MaxPassphraseLength {
+		return fmt.Errorf("WPA2のパスフレーズは%d〜%d文字です: %d文字", MinPassphraseLength, MaxPassphraseLength, len(p))
+	}
+	for i := 0; i < len(p); i++ {
+		if !validPassphraseChar(rune(p[i])) {
+			return fmt.Errorf("WPA2のパスフレーズに使用できない文字が含まれています")
+		}
+	}
+	return nil
+}
+
+// SSIDが空でなく32バイト以内か検証
+func ValidateSSID(ssid string) error {
+	if ssid == "" {
+		return fmt.Errorf("SSIDを指定してください")
+	}
+	if len(ssid) > MaxSSIDLength {
+		return fmt.Errorf("SSIDが長すぎます: %dバイト (最大: %dバイト)", len(ssid), MaxSSIDLength)
+	}
+	return nil
+}
+
+func validPassphraseChar(r rune) bool {
+	return r >= 0x20 && r <= 0x7e
+}
+
+// パスワード設定をWPA2パスフレーズの制約に合わせる
+//
+// 長さが0なら既定値を使い、8〜63文字の範囲外やASCII以外の文字種はエラーとする。
+// 記号はASCIIの印字可能文字に絞り込む。
+func PassphraseConfig(cfg config.PasswordConfig) (config.PasswordConfig, error) {
+	if cfg.Length == 0 {
+		cfg.Length = DefaultPassphraseLength
+	}
+	if cfg.Length < MinPassphraseLength || cfg.Length > MaxPassphraseLength {
+		return cfg, fmt.Errorf("WPA2のパスフレーズは%d〜%d文字です: %d文字", MinPassphraseLength, MaxPassphraseLength, cfg.Length)
+	}
+	// WPA2はASCIIのみを受け付けるため、警告ではなくエラーとする
+	if cfg.UseHiragana || cfg.UseKatakana || cfg.UseFullWidthDigits || len(cfg.UnicodeBlocks) > 0 {
+		return cfg, fmt.Errorf("WPA2のパスフレーズにはかな・全角文字・Unicodeブロックを使用できません")
+	}
+	if !cfg.UseSymbols {
+		return cfg, nil
+	}
+
+	symbols := config.Symbols
+	if cfg.CustomSymbols != "" {
+		symbols = cfg.CustomSymbols
+	}
+	var sb strings.Builder
+	for _, r := range symbols {
+		if validPassphraseChar(r) {
+			sb.WriteRune(r)
+		}
+	}
+	if sb.Len() == 0 {
+		return cfg, fmt.Errorf("WPA2のパスフレーズに使用できる記号がありません")
+	}
+	cfg.CustomSymbols = sb.String()
+	return cfg, nil
+}
+
+// QRコードに埋め込むWIFI:形式の文字列
+//
+// SSIDとパスフレーズの \ ; , " : はバックスラッシュでエスケープする。
+func (n Network) Payload() (string, error) {
+	if err := ValidateSSID(n.SSID); err != nil {
+		return "", err
+	}
+	if err := ValidatePassphrase(n.Passphrase); err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("WIFI:T:WPA;S:")
+	sb.WriteString(escape(n.SSID))
+	sb.WriteString(";P:")
+	sb.WriteString(escape(n.Passphrase))
+	sb.WriteString(";")
+	if n.Hidden {
+		sb.WriteString("H:true;")
+	}
+	sb.WriteString(";")
+	return sb.String(), nil
+}
+
+var escaper = strings.NewReplacer(`\`, `\\`, `;`, `\;`, `,`, `\,`, `"`, `\"`, `:`, `\:`)
+
+func escape(s string) string {
+	return escaper.Replace(s)
+}
@@ -0,0 +1,86 @@
+package wifi
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/okamyuji/PasswordGenerator/internal/config"
+)
+
+func TestNetwork_Payload(t *testing.T) {
+	tests := []struct {
+		name    string
+		network Network
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "基本",
+			network: Network{SSID: "Guest", Passphrase: "xK7#mQ2!"},
+			want:    "WIFI:T:WPA;S:Guest;P:xK7#mQ2!;;",
+		},
+		{
+			name:    "エスケープ",
+			network: Network{SSID: `Cafe "A;B"`, Passphrase: `a\b;c,d:e"f`},
+			want:    `WIFI:T:WPA;S:Cafe \"A\;B\";P:a\\b\;c\,d\:e\"f;;`,
+		},
+		{
+			name:    "ステルスSSID",
+			network: Network{SSID: "Office", Passphrase: "password", Hidden: true},
+			want:    "WIFI:T:WPA;S:Office;P:password;H:true;;",
+		},
+		{name: "SSIDなし", network: Network{Passphrase: "password"}, wantErr: true},
+		{name: "SSIDが長すぎる", network: Network{SSID: strings.Repeat("s", 33), Passphrase: "password"}, wantErr: true},
+		{name: "パスフレーズが短すぎる", network: Network{SSID: "Guest", Passphrase: "1234567"}, wantErr: true},
+		{name: "パスフレーズが長すぎる", network: Network{SSID: "Guest", Passphrase: strings.Repeat("a", 64)}, wantErr: true},
+		{name: "ASCII以外の文字", network: Network{SSID: "Guest", Passphrase: "パスワードです!"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.network.Payload()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Payload() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Payload() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPassphraseConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         config.PasswordConfig
+		wantLength  int
+		wantSymbols string
+		wantErr     bool
+	}{
+		{name: "既定の長さ", cfg: config.PasswordConfig{UseLowercase: true}, wantLength: DefaultPassphraseLength},
+		{name: "ASCII以外の記号を除外", cfg: config.PasswordConfig{Length: 12, UseSymbols: true, CustomSymbols: "!★#"}, wantLength: 12, wantSymbols: "!#"},
+		{name: "既定の記号", cfg: config.PasswordConfig{Length: 63, UseSymbols: true}, wantLength: 63, wantSymbols: config.Symbols},
+		{name: "使用できる記号がない", cfg: config.PasswordConfig{Length: 12, UseSymbols: true, CustomSymbols: "★"}, wantErr: true},
+		{name: "ひらがな", cfg: config.PasswordConfig{Length: 12, UseHiragana: true}, wantErr: true},
+		{name: "短すぎる", cfg: config.PasswordConfig{Length: 7, UseLowercase: true}, wantErr: true},
+		{name: "長すぎる", cfg: config.PasswordConfig{Length: 64, UseLowercase: true}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PassphraseConfig(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PassphraseConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Length != tt.wantLength {
+				t.Errorf("Length = %d, want %d", got.Length, tt.wantLength)
+			}
+			if tt.wantSymbols != "" && got.CustomSymbols != tt.wantSymbols {
+				t.Errorf("CustomSymbols = %q, want %q", got.CustomSymbols, tt.wantSymbols)
+			}
+		})
+	}
+}